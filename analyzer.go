@@ -0,0 +1,540 @@
+// Copyright 2016 ego authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package riot
+
+import (
+	"log"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/go-ego/gse"
+	"github.com/go-ego/riot/types"
+)
+
+// Token the token of the analyzed text
+type Token struct {
+	Text string
+	// 在文本中的位置，gse 分词为字节位置，其他分词器为从 1 开始的序号
+	Start int
+}
+
+// CharFilter filter the text before it is tokenized
+type CharFilter interface {
+	Filter(text string) string
+}
+
+// CharFilterFunc adapt the function to the CharFilter
+type CharFilterFunc func(text string) string
+
+// Filter call f(text)
+func (f CharFilterFunc) Filter(text string) string {
+	return f(text)
+}
+
+// Tokenizer split the text into the tokens
+type Tokenizer interface {
+	Tokenize(text string) []Token
+}
+
+// TokenizerFunc adapt the function to the Tokenizer
+type TokenizerFunc func(text string) []Token
+
+// Tokenize call f(text)
+func (f TokenizerFunc) Tokenize(text string) []Token {
+	return f(text)
+}
+
+// TokenFilter filter, change or add the tokens
+type TokenFilter interface {
+	Filter(tokens []Token) []Token
+}
+
+// TokenFilterFunc adapt the function to the TokenFilter
+type TokenFilterFunc func(tokens []Token) []Token
+
+// Filter call f(tokens)
+func (f TokenFilterFunc) Filter(tokens []Token) []Token {
+	return f(tokens)
+}
+
+// Analyzer the text analysis pipeline:
+// char filters -> tokenizer -> token filters
+type Analyzer struct {
+	CharFilters []CharFilter
+	// 为 nil 时不分析文档正文，只使用 DocData.Tokens
+	Tokenizer Tokenizer
+	Filters   []TokenFilter
+
+	// 为 true 时正文不为空的文档忽略 DocData.Tokens
+	ContentOnly bool
+}
+
+// Analyze analyze the text, numTokens is the number of the tokens
+// before the token filters, which is used as the length of the document
+func (a *Analyzer) Analyze(text string) (tokens []Token, numTokens int) {
+	if a.Tokenizer == nil {
+		return
+	}
+
+	text = filterText(text, a.CharFilters)
+	tokens = a.Tokenizer.Tokenize(text)
+	numTokens = len(tokens)
+	for _, filter := range a.Filters {
+		tokens = filter.Filter(tokens)
+	}
+
+	return
+}
+
+// LowercaseFilter lower case the text
+var LowercaseFilter = CharFilterFunc(strings.ToLower)
+
+// StopFilter remove the stop tokens
+func StopFilter(stop *StopTokens) TokenFilter {
+	return TokenFilterFunc(func(tokens []Token) []Token {
+		out := tokens[:0]
+		for _, token := range tokens {
+			if !stop.IsStopToken(token.Text) {
+				out = append(out, token)
+			}
+		}
+		return out
+	})
+}
+
+// GseTokenizer tokenize the text with the gse segmenter
+type GseTokenizer struct {
+	Segmenter *gse.Segmenter
+	// gse 搜索模式
+	SearchMode bool
+	// 为 true 时使用 Cut 或者 CutSearch，否则使用 ModeSegment
+	Cut bool
+	Hmm bool
+	// 不为 nil 时分词前加读锁，引擎修改词典时加写锁
+	Lock *sync.RWMutex
+}
+
+// Tokenize tokenize the text
+func (t *GseTokenizer) Tokenize(text string) []Token {
+	if t.Lock != nil {
+		t.Lock.RLock()
+		defer t.Lock.RUnlock()
+	}
+
+	if !t.Cut {
+		segments := t.Segmenter.ModeSegment([]byte(text), t.SearchMode)
+		tokens := make([]Token, len(segments))
+		for i, segment := range segments {
+			tokens[i] = Token{Text: segment.Token().Text(), Start: segment.Start()}
+		}
+		return tokens
+	}
+
+	var words []string
+	if t.SearchMode {
+		words = t.Segmenter.CutSearch(text, t.Hmm)
+	} else {
+		words = t.Segmenter.Cut(text, t.Hmm)
+	}
+
+	tokens := make([]Token, len(words))
+	for i, word := range words {
+		tokens[i] = Token{Text: word, Start: i + 1}
+	}
+	return tokens
+}
+
+// WhitespaceTokenizer split the text by the spaces
+var WhitespaceTokenizer = TokenizerFunc(func(text string) []Token {
+	var tokens []Token
+	for _, word := range strings.Split(text, " ") {
+		if word != "" {
+			tokens = append(tokens, Token{Text: word, Start: len(tokens) + 1})
+		}
+	}
+	return tokens
+})
+
+// PrefixTokenizer split the text into the words (by the spaces) or
+// the characters, each unit and the prefix of the text ending with it
+// are the tokens; All adds the joined units following each unit
+type PrefixTokenizer struct {
+	Words bool
+	All   bool
+}
+
+// Tokenize tokenize the text
+func (t PrefixTokenizer) Tokenize(text string) []Token {
+	sep := ""
+	if t.Words {
+		sep = " "
+	}
+
+	return prefixTokens(strings.Split(text, sep), t.All)
+}
+
+func prefixTokens(units []string, all bool) []Token {
+	var (
+		tokens []Token
+		prefix string
+	)
+
+	emit := func(text string) {
+		tokens = append(tokens, Token{Text: text, Start: len(tokens) + 1})
+	}
+
+	for i, unit := range units {
+		if unit == "" {
+			continue
+		}
+
+		emit(unit)
+		prefix += unit
+		emit(prefix)
+
+		if all {
+			var joined string
+			for _, next := range units[i+1:] {
+				joined += next
+				emit(joined)
+			}
+		}
+	}
+
+	return tokens
+}
+
+// MultiTokenizer join the tokens of the tokenizers, nil is skipped
+func MultiTokenizer(tokenizers ...Tokenizer) Tokenizer {
+	return TokenizerFunc(func(text string) []Token {
+		var tokens []Token
+		for _, t := range tokenizers {
+			if t != nil {
+				tokens = append(tokens, t.Tokenize(text)...)
+			}
+		}
+		return tokens
+	})
+}
+
+// NGramTokenizer split the words of the letters and digits into the
+// grams of Min to Max characters, the words shorter than Min are kept;
+// Start is the position of the first character of the gram from 1
+type NGramTokenizer struct {
+	Min, Max int
+}
+
+// Tokenize tokenize the text
+func (t NGramTokenizer) Tokenize(text string) []Token {
+	return gramTokens(text, t.Min, t.Max, false)
+}
+
+// EdgeNGramTokenizer split the words of the letters and digits into
+// the prefixes of Min to Max characters, the words shorter than Min
+// are kept; Start is the position of the word from 1
+type EdgeNGramTokenizer struct {
+	Min, Max int
+}
+
+// Tokenize tokenize the text
+func (t EdgeNGramTokenizer) Tokenize(text string) []Token {
+	return gramTokens(text, t.Min, t.Max, true)
+}
+
+func gramTokens(text string, min, max int, edge bool) []Token {
+	if min <= 0 {
+		min = 1
+	}
+	if max < min {
+		max = min
+	}
+
+	var (
+		tokens []Token
+		word   []rune
+	)
+
+	emit := func(pos int) {
+		if len(word) > 0 && len(word) < min {
+			tokens = append(tokens, Token{Text: string(word), Start: pos - len(word) + 1})
+		}
+
+		for i := range word {
+			for n := min; n <= max && i+n <= len(word); n++ {
+				tokens = append(tokens, Token{Text: string(word[i : i+n]),
+					Start: pos - len(word) + i + 1})
+			}
+			if edge {
+				break
+			}
+		}
+		word = word[:0]
+	}
+
+	pos := 0
+	for _, r := range text {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			word = append(word, r)
+		} else {
+			emit(pos)
+		}
+		pos++
+	}
+	emit(pos)
+
+	return tokens
+}
+
+// analyzers 注册的分析器
+type analyzers struct {
+	sync.RWMutex
+	m map[string]*Analyzer
+}
+
+// RegisterAnalyzer register the analyzer under the name,
+// the built-in analyzers can be replaced; register it before Init
+// to use it in EngineOpts.Analyzer or EngineOpts.SearchAnalyzer
+func (engine *Engine) RegisterAnalyzer(name string, analyzer *Analyzer) {
+	engine.analyzers.Lock()
+	defer engine.analyzers.Unlock()
+
+	if engine.analyzers.m == nil {
+		engine.analyzers.m = make(map[string]*Analyzer)
+	}
+	engine.analyzers.m[name] = analyzer
+}
+
+// Analyzer get the registered analyzer
+func (engine *Engine) Analyzer(name string) (*Analyzer, bool) {
+	engine.analyzers.RLock()
+	defer engine.analyzers.RUnlock()
+
+	analyzer, ok := engine.analyzers.m[name]
+	return analyzer, ok
+}
+
+// builtinAnalyzers 内置的分析器，和之前 Using 的取值对应
+//
+//	gse         Using 0，gse 分词，加上 DocData.Tokens
+//	gse_content Using 1，gse 分词，正文为空时使用 DocData.Tokens
+//	tokens      Using 2，只使用 DocData.Tokens
+//	gse_chars   Using 3，gse 分词和字符的前缀组合
+//	words       Using 4 或 NotUseGse，单词的前缀组合
+//	chars       Using 5，字符的前缀组合
+//	chars_all   Using 6，字符的全部组合
+//	gse_search  gse Cut 或者 CutSearch 分词
+//	whitespace  按空格分词，NotUseGse 时 Segment 使用
+//	english     英文单词，去掉所有格和附加符号，并提取词干
+//	simple      字母和数字组成的单词
+//	ngram       单词的 2 到 3 个字符的 n-gram，用于子字段
+//	edge_ngram  单词的 1 到 20 个字符的前缀，用于子字段
+func (engine *Engine) builtinAnalyzers() map[string]*Analyzer {
+	options := engine.initOptions
+	stop := []TokenFilter{StopFilter(&engine.stopTokens)}
+	lower := []CharFilter{LowercaseFilter}
+	gseIndex := &GseTokenizer{Segmenter: &engine.segmenter,
+		SearchMode: options.GseMode, Lock: &engine.dict.lock}
+
+	return map[string]*Analyzer{
+		"gse": {Tokenizer: gseIndex, Filters: stop},
+		"gse_content": {Tokenizer: gseIndex, Filters: stop,
+			ContentOnly: true},
+		"tokens": {},
+		"gse_chars": {CharFilters: lower, Filters: stop,
+			Tokenizer: MultiTokenizer(gseIndex, PrefixTokenizer{})},
+		"words":     {CharFilters: lower, Tokenizer: PrefixTokenizer{Words: true}, Filters: stop},
+		"chars":     {CharFilters: lower, Tokenizer: PrefixTokenizer{}, Filters: stop},
+		"chars_all": {CharFilters: lower, Tokenizer: PrefixTokenizer{All: true}, Filters: stop},
+		"gse_search": {CharFilters: lower, Filters: stop,
+			Tokenizer: &GseTokenizer{Segmenter: &engine.segmenter,
+				SearchMode: options.GseMode, Cut: true, Hmm: options.Hmm,
+				Lock: &engine.dict.lock}},
+		"whitespace": {CharFilters: lower, Tokenizer: WhitespaceTokenizer},
+		"english": {CharFilters: lower, Tokenizer: WordTokenizer,
+			Filters: []TokenFilter{PossessiveFilter, ASCIIFoldingFilter,
+				stop[0], PorterStemFilter}},
+		"simple":     {CharFilters: lower, Tokenizer: WordTokenizer},
+		"ngram":      {CharFilters: lower, Tokenizer: NGramTokenizer{Min: 2, Max: 3}},
+		"edge_ngram": {CharFilters: lower, Tokenizer: EdgeNGramTokenizer{Min: 1, Max: 20}},
+	}
+}
+
+// charFilters 内置的字符过滤器，用于 EngineOpts.CharFilters
+var charFilters = map[string]CharFilter{
+	"t2s":      T2SFilter,
+	"s2t":      S2TFilter,
+	"width":    WidthFilter,
+	"cn_punct": PunctFilter,
+}
+
+// namedCharFilters 按名字查找字符过滤器
+func namedCharFilters(names []string) []CharFilter {
+	filters := make([]CharFilter, 0, len(names))
+	for _, name := range names {
+		filter, ok := charFilters[name]
+		if !ok {
+			log.Fatalf("Unknown char filter: %s", name)
+		}
+		filters = append(filters, filter)
+	}
+
+	return filters
+}
+
+// tokenFilters 内置的关键词过滤器，用于 EngineOpts.TokenFilters
+var tokenFilters = map[string]TokenFilter{
+	"possessive":    PossessiveFilter,
+	"ascii_folding": ASCIIFoldingFilter,
+	"porter_stem":   PorterStemFilter,
+}
+
+// namedTokenFilters 按名字查找关键词过滤器
+func namedTokenFilters(names []string) []TokenFilter {
+	filters := make([]TokenFilter, 0, len(names))
+	for _, name := range names {
+		filter, ok := tokenFilters[name]
+		if !ok {
+			log.Fatalf("Unknown token filter: %s", name)
+		}
+		filters = append(filters, filter)
+	}
+
+	return filters
+}
+
+// withFilters 复制分析器，在最前面加入字符过滤器，在最后加入关键词过滤器
+func withFilters(analyzer *Analyzer, chars []CharFilter,
+	filters []TokenFilter) *Analyzer {
+	if len(chars) == 0 && len(filters) == 0 || analyzer.Tokenizer == nil {
+		return analyzer
+	}
+
+	a := *analyzer
+	a.CharFilters = append(append([]CharFilter(nil), chars...), a.CharFilters...)
+	a.Filters = append(append([]TokenFilter(nil), a.Filters...), filters...)
+	return &a
+}
+
+// filterText 对文本使用字符过滤器
+func filterText(text string, filters []CharFilter) string {
+	for _, filter := range filters {
+		text = filter.Filter(text)
+	}
+	return text
+}
+
+// legacyAnalyzers 没有设置分析器时由 Using 和 NotUseGse 决定
+//
+// 查询使用和索引相同的 gse 分词，不使用 gse 时按空格分词。
+// 前缀组合的模式查询的是前缀本身，查询的文本不再做前缀组合。
+// Segment 仍然使用 Cut 或者 CutSearch，见 segmentAnalyzer。
+func legacyAnalyzers(options types.EngineOpts) (index, search string) {
+	search = "gse"
+	if options.NotUseGse {
+		search = "whitespace"
+	}
+
+	switch {
+	case options.NotUseGse && options.Using == 0:
+		index = "words"
+	case options.Using == 0:
+		index = "gse"
+	case options.Using == 1:
+		index = "gse_content"
+	case options.Using == 2:
+		index = "tokens"
+	case options.Using == 3:
+		index = "gse_chars"
+	case options.Using == 4:
+		index = "words"
+	case options.Using == 6:
+		index = "chars_all"
+	default:
+		index = "chars"
+	}
+
+	return
+}
+
+// newSegmentAnalyzer Segment 使用的分析器，gse Cut 或者 CutSearch 分词，
+// 使用 Hmm 并保留大小写，不使用 gse 时按空格分词
+func (engine *Engine) newSegmentAnalyzer(options types.EngineOpts) *Analyzer {
+	stop := []TokenFilter{StopFilter(&engine.stopTokens)}
+	if options.NotUseGse {
+		return &Analyzer{Tokenizer: WhitespaceTokenizer, Filters: stop}
+	}
+
+	return &Analyzer{Filters: stop, Tokenizer: &GseTokenizer{
+		Segmenter: &engine.segmenter, SearchMode: options.GseMode,
+		Cut: true, Hmm: options.Hmm, Lock: &engine.dict.lock}}
+}
+
+// initAnalyzers 注册内置的分析器，并选择索引和查询使用的分析器
+func (engine *Engine) initAnalyzers(options types.EngineOpts) {
+	for name, analyzer := range engine.builtinAnalyzers() {
+		if _, ok := engine.Analyzer(name); !ok {
+			engine.RegisterAnalyzer(name, analyzer)
+		}
+	}
+
+	index, search := options.Analyzer, options.SearchAnalyzer
+	if index == "" {
+		var legacy string
+		index, legacy = legacyAnalyzers(options)
+		if search == "" {
+			search = legacy
+		}
+	}
+	// 没有设置 SearchAnalyzer 时索引和查询使用同一个分析器
+	if search == "" {
+		search = index
+	}
+
+	var ok bool
+	if engine.analyzer, ok = engine.Analyzer(index); !ok {
+		log.Fatalf("Unknown analyzer: %s", index)
+	}
+	if engine.searchAnalyzer, ok = engine.Analyzer(search); !ok {
+		log.Fatalf("Unknown search analyzer: %s", search)
+	}
+	engine.segmentAnalyzer = engine.newSegmentAnalyzer(options)
+
+	if options.PinYin {
+		// 加入正文的拼音
+		analyzer := *engine.analyzer
+		analyzer.Tokenizer = MultiTokenizer(analyzer.Tokenizer,
+			TokenizerFunc(func(text string) []Token {
+				var tokens []Token
+				for i, str := range engine.PinYin(text) {
+					tokens = append(tokens, Token{Text: str, Start: i})
+				}
+				return tokens
+			}))
+		engine.analyzer = &analyzer
+	}
+
+	// 索引、查询和 Segment 都使用的过滤器
+	engine.charFilters = namedCharFilters(options.CharFilters)
+	engine.tokenFilters = namedTokenFilters(options.TokenFilters)
+	engine.analyzer = withFilters(engine.analyzer,
+		engine.charFilters, engine.tokenFilters)
+	engine.searchAnalyzer = withFilters(engine.searchAnalyzer,
+		engine.charFilters, engine.tokenFilters)
+	engine.segmentAnalyzer = withFilters(engine.segmentAnalyzer,
+		engine.charFilters, engine.tokenFilters)
+
+	engine.initSubFields(options)
+}
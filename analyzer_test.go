@@ -0,0 +1,78 @@
+package riot
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-ego/riot/types"
+	"github.com/vcaesar/tt"
+)
+
+func tokenTexts(tokens []Token) []string {
+	var texts []string
+	for _, token := range tokens {
+		texts = append(texts, token.Text)
+	}
+	return texts
+}
+
+func TestAnalyzer(t *testing.T) {
+	var stop StopTokens
+	stop.Init("./testdata/test_stop_dict.txt")
+
+	analyzer := Analyzer{
+		CharFilters: []CharFilter{LowercaseFilter},
+		Tokenizer:   WhitespaceTokenizer,
+		Filters:     []TokenFilter{StopFilter(&stop)},
+	}
+	tokens, numTokens := analyzer.Analyze("Hello  baidu World")
+	tt.Equal(t, []string{"hello", "world"}, tokenTexts(tokens))
+	tt.Equal(t, 3, numTokens)
+	tt.Equal(t, 3, tokens[1].Start)
+
+	tokens = PrefixTokenizer{}.Tokenize("abc")
+	tt.Equal(t, []string{"a", "a", "b", "ab", "c", "abc"}, tokenTexts(tokens))
+	tokens = PrefixTokenizer{Words: true, All: true}.Tokenize("a b c")
+	tt.Equal(t, []string{"a", "a", "b", "bc", "b", "ab", "c", "c", "abc"},
+		tokenTexts(tokens))
+
+	index, search := legacyAnalyzers(types.EngineOpts{Using: 1})
+	tt.Equal(t, "gse_content", index)
+	tt.Equal(t, "gse", search)
+	index, search = legacyAnalyzers(types.EngineOpts{NotUseGse: true})
+	tt.Equal(t, "words", index)
+	tt.Equal(t, "whitespace", search)
+}
+
+func TestCustomAnalyzer(t *testing.T) {
+	var engine Engine
+	// 按逗号分隔的标签，索引和查询使用同一个分析器
+	engine.RegisterAnalyzer("comma", &Analyzer{
+		CharFilters: []CharFilter{LowercaseFilter},
+		Tokenizer: TokenizerFunc(func(text string) []Token {
+			var tokens []Token
+			for i, word := range strings.Split(text, ",") {
+				tokens = append(tokens, Token{Text: strings.TrimSpace(word), Start: i})
+			}
+			return tokens
+		}),
+	})
+	engine.Init(types.EngineOpts{
+		GseDict:     "./testdata/test_dict.txt",
+		IndexerOpts: inxOpts,
+		Analyzer:    "comma",
+	})
+	defer engine.Close()
+
+	_, ok := engine.Analyzer("gse_search")
+	tt.True(t, ok)
+
+	engine.Index("1", types.DocData{Content: "Red Apple, Green"})
+	engine.Index("2", types.DocData{Content: "red apple"})
+	engine.Flush()
+
+	outputs := engine.Search(types.SearchReq{Text: "RED APPLE"})
+	tt.Equal(t, []string{"red apple"}, outputs.Tokens)
+	tt.Equal(t, 2, outputs.NumDocs)
+	tt.Equal(t, 1, len(searchIds(&engine, "green, red apple")))
+}
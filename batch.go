@@ -0,0 +1,207 @@
+// Copyright 2016 ego authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package riot
+
+import (
+	"log"
+	"sync"
+	"sync/atomic"
+
+	"github.com/go-ego/murmur"
+	"github.com/go-ego/riot/types"
+	"github.com/go-ego/riot/utils"
+)
+
+// IndexBatch add the documents to the index in batch
+// 批量将文档加入索引
+//
+// 文档并行分词后按 shard 分组加入索引器和排序器，使用持久化存储时
+// 每个存储 shard 只批量写入一次。
+// 返回值和 docs 一一对应，nil 表示成功。
+// 和 Index 一样，函数返回时文档可能还没有加入索引中。
+func (engine *Engine) IndexBatch(docs []types.BatchDoc,
+	forceUpdate ...bool) []error {
+	if !engine.initialized {
+		log.Fatal("The engine must be initialized first.")
+	}
+
+	errs := make([]error, len(docs))
+	if err := engine.begin(); err != nil {
+		for i := range errs {
+			errs[i] = err
+		}
+		return errs
+	}
+	defer engine.end()
+
+	var force bool
+	if len(forceUpdate) > 0 {
+		force = forceUpdate[0]
+	}
+
+	if engine.initOptions.Versioning || engine.initOptions.TTL > 0 {
+		// 记录分配的版本和过期时间，不修改调用者的 docs
+		docs = append([]types.BatchDoc(nil), docs...)
+	}
+
+	valid := make([]int, 0, len(docs))
+	for i := range docs {
+		if docs[i].DocId == "0" {
+			errs[i] = ErrInvalidDocId
+			continue
+		}
+
+		engine.withTTL(&docs[i].Data)
+		if engine.initOptions.Versioning {
+			version, err := engine.acceptVersion(
+				docs[i].DocId, docs[i].Data.Version, false)
+			if err != nil {
+				errs[i] = err
+				continue
+			}
+			docs[i].Data.Version = version
+		}
+		valid = append(valid, i)
+	}
+
+	atomic.AddUint64(&engine.numIndexingReqs, uint64(len(valid)))
+	if force {
+		atomic.AddUint64(&engine.numForceUpdatingReqs, 1)
+	}
+
+	shards, inxDocs := engine.segmentBatch(docs, valid)
+
+	// 按 shard 分组加入索引器和排序器
+	groups := make([][]int, engine.initOptions.NumShards)
+	for _, i := range valid {
+		groups[shards[i]] = append(groups[shards[i]], i)
+	}
+
+	for shard, group := range groups {
+		for _, i := range group {
+			data := docs[i].Data
+			version := docVersion{version: data.Version}
+			if !engine.sendDoc(shard, docs[i].DocId, version,
+				indexerAddDocReq{doc: inxDocs[i]},
+				rankerAddDocReq{
+					docId: docs[i].DocId, fields: data.Fields,
+					content: data.Content, attri: data.Attri}, true) {
+				// 过期的版本或者已经改变路由的文档不加入索引
+				atomic.AddUint64(&engine.numDocsIndexed, 1)
+			}
+		}
+	}
+
+	if force {
+		for shard := 0; shard < engine.initOptions.NumShards; shard++ {
+			engine.indexerAddDocChans[shard] <- indexerAddDocReq{
+				forceUpdate: true}
+		}
+	}
+
+	if engine.initOptions.UseStore {
+		engine.storeBatch(docs, valid, errs)
+	}
+
+	for _, i := range valid {
+		engine.track(docs[i].DocId, false)
+	}
+
+	return errs
+}
+
+// segmentBatch 使用 NumGseThreads 个协程并行分词
+func (engine *Engine) segmentBatch(docs []types.BatchDoc, valid []int) (
+	[]int, []*types.DocIndex) {
+	shards := make([]int, len(docs))
+	inxDocs := make([]*types.DocIndex, len(docs))
+
+	numThreads := utils.MinInt(engine.initOptions.NumGseThreads, len(valid))
+
+	var wg sync.WaitGroup
+	wg.Add(numThreads)
+	for t := 0; t < numThreads; t++ {
+		go func(t int) {
+			defer wg.Done()
+
+			for j := t; j < len(valid); j += numThreads {
+				i := valid[j]
+				docId, data := docs[i].DocId, docs[i].Data
+
+				shard, old := engine.route(docId, data.Routing)
+				if old != shard {
+					engine.moveDoc(docId, old)
+				}
+				engine.setExpiry(docId, data.ExpireAt, data.Version)
+
+				shards[i] = shard
+				inxDocs[i] = engine.makeDocIndex(segmenterReq{
+					docId: docId, shard: shard, data: data})
+			}
+		}(t)
+	}
+	wg.Wait()
+
+	return shards, inxDocs
+}
+
+// storeBatch 按存储 shard 分组，每个 shard 批量写入一次
+func (engine *Engine) storeBatch(docs []types.BatchDoc, valid []int,
+	errs []error) {
+	numShards := engine.initOptions.StoreShards
+	groups := make([][]int, numShards)
+	keys := make([][][]byte, numShards)
+	values := make([][][]byte, numShards)
+	versions := make([][]uint64, numShards)
+
+	for _, i := range valid {
+		val, err := encodeDoc(docs[i].Data)
+		if err != nil {
+			errs[i] = err
+			atomic.AddUint64(&engine.numDocsStored, 1)
+			continue
+		}
+
+		shard := murmur.Sum32(docs[i].DocId) % uint32(numShards)
+		groups[shard] = append(groups[shard], i)
+		keys[shard] = append(keys[shard], []byte(docs[i].DocId))
+		values[shard] = append(values[shard], val)
+		versions[shard] = append(versions[shard], docs[i].Data.Version)
+	}
+
+	errChans := make([]chan error, numShards)
+	for shard := 0; shard < numShards; shard++ {
+		if len(groups[shard]) == 0 {
+			continue
+		}
+
+		errChans[shard] = make(chan error, 1)
+		engine.storeIndexDocChans[shard] <- storeIndexDocReq{
+			keys: keys[shard], values: values[shard], versions: versions[shard],
+			errChan: errChans[shard]}
+	}
+
+	for shard, errChan := range errChans {
+		if errChan == nil {
+			continue
+		}
+
+		if err := <-errChan; err != nil {
+			for _, i := range groups[shard] {
+				errs[i] = err
+			}
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package riot
+
+import (
+	"encoding/gob"
+	"os"
+	"testing"
+
+	"github.com/go-ego/riot/types"
+	"github.com/vcaesar/tt"
+)
+
+func batchDocs() []types.BatchDoc {
+	return []types.BatchDoc{
+		{DocId: "1", Data: types.DocData{
+			Content: "The world, 有七十亿人口人口", Fields: score1}},
+		{DocId: "2", Data: types.DocData{Content: "The world, 人口"}},
+		{DocId: "3", Data: types.DocData{Content: "The world"}},
+		{DocId: "4", Data: types.DocData{
+			Content: "有人口", Fields: ScoringFields{2, 3, 1}}},
+		{DocId: "5", Data: types.DocData{
+			Content: "The world, 七十亿人口", Fields: score091}},
+		{DocId: "6", Data: types.DocData{
+			Content: "有七十亿人口", Fields: ScoringFields{2, 3, 3}}},
+		{DocId: "0", Data: types.DocData{Content: "The world"}},
+	}
+}
+
+func TestIndexBatch(t *testing.T) {
+	gob.Register(ScoringFields{})
+
+	var opts = types.EngineOpts{
+		Using:       1,
+		GseDict:     "./testdata/test_dict.txt",
+		DefRankOpts: &rankOptsMax10,
+		IndexerOpts: inxOpts,
+		UseStore:    true,
+		StoreFolder: "riot.batch",
+		StoreShards: 2,
+	}
+
+	var engine Engine
+	engine.Init(opts)
+
+	errs := engine.IndexBatch(batchDocs(), true)
+	tt.Expect(t, "7", len(errs))
+	for i := 0; i < 6; i++ {
+		tt.Nil(t, errs[i])
+	}
+	tt.Equal(t, ErrInvalidDocId, errs[6])
+
+	engine.Flush()
+	tt.Expect(t, "6", engine.NumDocsIndexed())
+	tt.Expect(t, "6", len(engine.GetDBAllIds()))
+
+	outputs := engine.Search(Req1)
+	outDocs := outputs.Docs.(types.ScoredDocs)
+	tt.Expect(t, "3", len(outDocs))
+
+	tt.Expect(t, "2", outDocs[0].DocId)
+	tt.Expect(t, "333", int(outDocs[0].Scores[0]*1000))
+	tt.Expect(t, "[4 11]", outDocs[0].TokenSnippetLocs)
+
+	tt.Expect(t, "5", outDocs[1].DocId)
+	tt.Expect(t, "1", outDocs[2].DocId)
+
+	engine.Close()
+
+	errs = engine.IndexBatch(batchDocs())
+	tt.Equal(t, ErrEngineClosed, errs[0])
+	os.RemoveAll("riot.batch")
+}
@@ -0,0 +1,228 @@
+// Copyright 2016 ego authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package riot
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/go-ego/riot/types"
+)
+
+var (
+	// ErrNoChangeLog the change log is disabled, see EngineOpts.ChangeLogSize
+	ErrNoChangeLog = errors.New("the change log is disabled")
+
+	// ErrChangesTruncated the change events after the sequence number
+	// are no longer retained
+	ErrChangesTruncated = errors.New("the change events have been truncated")
+)
+
+// 订阅通道的缓冲，订阅者没有及时接收时通道被关闭
+const changeBuffer = 1024
+
+// changeLog 文档的变更事件
+type changeLog struct {
+	sync.Mutex
+	size int
+	seq  uint64
+	// 最近的事件，至少保留 size 个
+	events []types.ChangeEvent
+	// 索引中的文档，用于区分加入和更新
+	docs map[string]bool
+	subs []chan types.ChangeEvent
+}
+
+// openChangeLog 在 Init 时调用，Reshard 之后保留事件和订阅
+func (engine *Engine) openChangeLog(options types.EngineOpts) {
+	if options.ChangeLogSize <= 0 {
+		return
+	}
+
+	engine.changes.size = options.ChangeLogSize
+	engine.changes.docs = make(map[string]bool)
+}
+
+// emit 记录事件并发送给订阅者，调用前需要加锁
+func (c *changeLog) emit(docId string, op types.ChangeOp, version uint64) {
+	c.seq++
+	event := types.ChangeEvent{Seq: c.seq, DocId: docId, Op: op,
+		Version: version, Time: time.Now().UnixNano()}
+
+	c.events = append(c.events, event)
+	if len(c.events) >= 2*c.size {
+		c.events = append([]types.ChangeEvent(nil), c.events[len(c.events)-c.size:]...)
+	}
+
+	subs := c.subs[:0]
+	for _, sub := range c.subs {
+		select {
+		case sub <- event:
+			subs = append(subs, sub)
+		default:
+			// 订阅者太慢，关闭通道，订阅者可以从收到的最后一个序号继续订阅
+			close(sub)
+		}
+	}
+	c.subs = subs
+}
+
+// indexed 文档发送到所在 shard 的索引器时调用，调用者持有文档的版本锁，
+// 同一个文档的事件和加入 shard 的顺序一致
+func (engine *Engine) indexed(docId string, version uint64) {
+	c := &engine.changes
+	if c.size <= 0 {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	op := types.ChangeAdd
+	if c.docs[docId] {
+		op = types.ChangeUpdate
+	}
+	c.docs[docId] = true
+	c.emit(docId, op, version)
+}
+
+// updated 更新文档的评分字段或属性
+func (engine *Engine) updated(docId string) {
+	engine.track(docId, false)
+
+	c := &engine.changes
+	if c.size <= 0 {
+		return
+	}
+
+	version, _ := engine.DocVersion(docId)
+
+	c.Lock()
+	defer c.Unlock()
+
+	c.emit(docId, types.ChangeUpdate, version)
+}
+
+// removed 删除请求发送到所在 shard 时调用，和 indexed 一样持有版本锁
+func (engine *Engine) removed(docId string, version uint64) {
+	c := &engine.changes
+	if c.size <= 0 {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	delete(c.docs, docId)
+	c.emit(docId, types.ChangeRemove, version)
+}
+
+// recovered 从持久化存储恢复的文档，不产生事件
+func (engine *Engine) recovered(docId string) {
+	c := &engine.changes
+	if c.size <= 0 {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	c.docs[docId] = true
+}
+
+// ChangeSeq get the sequence number of the last change event
+func (engine *Engine) ChangeSeq() uint64 {
+	engine.changes.Lock()
+	defer engine.changes.Unlock()
+
+	return engine.changes.seq
+}
+
+// Subscribe subscribe the change events whose sequence number is greater
+// than after, pass ChangeSeq() to receive only the new events.
+//
+// The events are sent in order, the channel is closed when the subscriber
+// does not keep up or the engine is closed, then subscribe again after
+// the last received sequence number to resume; ErrChangesTruncated is
+// returned if the events are no longer retained or after is greater
+// than ChangeSeq(), e.g. a sequence number from before a restart.
+func (engine *Engine) Subscribe(after uint64) (<-chan types.ChangeEvent, error) {
+	if err := engine.begin(); err != nil {
+		return nil, err
+	}
+	defer engine.end()
+
+	c := &engine.changes
+	if c.size <= 0 {
+		return nil, ErrNoChangeLog
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	// 序号在重新打开引擎之后从 0 开始，之前的序号无法继续
+	if after > c.seq {
+		return nil, ErrChangesTruncated
+	}
+
+	var replay []types.ChangeEvent
+	if after < c.seq {
+		oldest := c.seq + 1
+		if len(c.events) > 0 {
+			oldest = c.events[0].Seq
+		}
+		if after+1 < oldest {
+			return nil, ErrChangesTruncated
+		}
+
+		replay = c.events[len(c.events)-int(c.seq-after):]
+	}
+
+	sub := make(chan types.ChangeEvent, len(replay)+changeBuffer)
+	for _, event := range replay {
+		sub <- event
+	}
+	c.subs = append(c.subs, sub)
+
+	return sub, nil
+}
+
+// Unsubscribe stop the subscription and close the channel
+func (engine *Engine) Unsubscribe(events <-chan types.ChangeEvent) {
+	c := &engine.changes
+	c.Lock()
+	defer c.Unlock()
+
+	for i, sub := range c.subs {
+		if (<-chan types.ChangeEvent)(sub) == events {
+			close(sub)
+			c.subs = append(c.subs[:i], c.subs[i+1:]...)
+			return
+		}
+	}
+}
+
+// closeChanges 关闭全部订阅
+func (engine *Engine) closeChanges() {
+	c := &engine.changes
+	c.Lock()
+	defer c.Unlock()
+
+	for _, sub := range c.subs {
+		close(sub)
+	}
+	c.subs = nil
+}
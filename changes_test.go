@@ -0,0 +1,108 @@
+package riot
+
+import (
+	"os"
+	"testing"
+
+	"github.com/go-ego/riot/types"
+	"github.com/vcaesar/tt"
+)
+
+func changeOpts(size int) types.EngineOpts {
+	return types.EngineOpts{
+		Using:         1,
+		GseDict:       "./testdata/test_dict.txt",
+		IndexerOpts:   inxOpts,
+		ChangeLogSize: size,
+	}
+}
+
+func TestChangeEvents(t *testing.T) {
+	var engine Engine
+	engine.Init(changeOpts(2))
+
+	events, err := engine.Subscribe(engine.ChangeSeq())
+	tt.Nil(t, err)
+
+	engine.Index("1", types.DocData{Content: "The world"})
+	engine.Index("1", types.DocData{Content: "The world, 人口"})
+	engine.IndexBatch([]types.BatchDoc{
+		{DocId: "2", Data: types.DocData{Content: "有人口"}}})
+	engine.Flush()
+	tt.Nil(t, engine.UpdateFields("2", ScoringFields{A: 1}))
+	engine.RemoveDoc("1", true)
+
+	var ops []types.ChangeOp
+	for i := uint64(1); i <= 5; i++ {
+		event := <-events
+		tt.Equal(t, i, event.Seq)
+		tt.True(t, event.Time > 0)
+		ops = append(ops, event.Op)
+	}
+	tt.Equal(t, []types.ChangeOp{types.ChangeAdd, types.ChangeUpdate,
+		types.ChangeAdd, types.ChangeUpdate, types.ChangeRemove}, ops)
+	tt.Equal(t, uint64(5), engine.ChangeSeq())
+
+	// 从序号继续订阅
+	resumed, err := engine.Subscribe(3)
+	tt.Nil(t, err)
+	event := <-resumed
+	tt.Equal(t, uint64(4), event.Seq)
+	tt.Equal(t, "2", event.DocId)
+	event = <-resumed
+	tt.Equal(t, "1", event.DocId)
+	tt.Equal(t, types.ChangeRemove, event.Op)
+
+	_, err = engine.Subscribe(0)
+	tt.Equal(t, ErrChangesTruncated, err)
+	// 重新打开之前的序号
+	_, err = engine.Subscribe(engine.ChangeSeq() + 1)
+	tt.Equal(t, ErrChangesTruncated, err)
+
+	engine.Unsubscribe(resumed)
+	_, ok := <-resumed
+	tt.False(t, ok)
+
+	engine.Close()
+	_, ok = <-events
+	tt.False(t, ok)
+}
+
+func TestChangeRecovered(t *testing.T) {
+	os.RemoveAll("riot.changes")
+	defer os.RemoveAll("riot.changes")
+
+	opts := changeOpts(10)
+	opts.UseStore = true
+	opts.StoreFolder = "riot.changes"
+
+	var engine Engine
+	engine.Init(opts)
+	engine.Index("1", types.DocData{Content: "The world"})
+	engine.Flush()
+	tt.Equal(t, uint64(1), engine.ChangeSeq())
+	engine.Close()
+
+	// 从存储恢复的文档不产生事件，之后的写入仍然是更新
+	var engine1 Engine
+	engine1.Init(opts)
+	defer engine1.Close()
+	engine1.Flush()
+	tt.Equal(t, uint64(0), engine1.ChangeSeq())
+
+	events, err := engine1.Subscribe(0)
+	tt.Nil(t, err)
+	engine1.Index("1", types.DocData{Content: "The world, 人口"})
+	engine1.Flush()
+	event := <-events
+	tt.Equal(t, types.ChangeUpdate, event.Op)
+}
+
+func TestNoChangeLog(t *testing.T) {
+	var engine Engine
+	engine.Init(changeOpts(0))
+	defer engine.Close()
+
+	_, err := engine.Subscribe(0)
+	tt.Equal(t, ErrNoChangeLog, err)
+}
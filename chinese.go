@@ -0,0 +1,221 @@
+// Copyright 2016 ego authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package riot
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// simpTrad 简体和繁体一一对应的字
+const simpTrad = `爱愛 罢罷 备備 贝貝 笔筆 边邊 变變 宾賓 补補 参參 蚕蠶 灿燦 层層 产產
+长長 尝嘗 厂廠 车車 彻徹 陈陳 尘塵 衬襯 称稱 惩懲 迟遲 齿齒 虫蟲 处處 础礎 触觸
+传傳 疮瘡 闯闖 创創 词詞 从從 聪聰 丛叢 错錯 达達 带帶 单單 担擔 胆膽 当當 挡擋
+党黨 导導 灯燈 邓鄧 敌敵 递遞 点點 电電 垫墊 钓釣 调調 叠疊 顶頂 东東 动動 冻凍
+独獨 读讀 断斷 对對 队隊 吨噸 夺奪 堕墮 恶惡 儿兒 尔爾 饵餌 发發 罚罰 阀閥 饭飯
+访訪 纺紡 飞飛 费費 坟墳 奋奮 粪糞 丰豐 风風 凤鳳 妇婦 复復 负負 该該 盖蓋 赶趕
+冈岡 刚剛 钢鋼 纲綱 个個 给給 巩鞏 贡貢 沟溝 构構 购購 顾顧 关關 观觀 馆館 惯慣
+广廣 归歸 规規 龟龜 国國 过過 汉漢 号號 轰轟 护護 沪滬 画畫 划劃 话話 华華 怀懷
+坏壞 欢歡 环環 还還 换換 唤喚 挥揮 辉輝 会會 汇匯 获獲 货貨 祸禍 击擊 机機 积積
+饥飢 鸡雞 极極 际際 纪紀 计計 记記 级級 挤擠 济濟 继繼 价價 驾駕 坚堅 监監 间間
+艰艱 拣揀 检檢 减減 荐薦 剑劍 见見 渐漸 践踐 舰艦 键鍵 讲講 奖獎 将將 浆漿 胶膠
+阶階 节節 杰傑 结結 洁潔 紧緊 仅僅 进進 尽盡 劲勁 惊驚 经經 镜鏡 竞競 旧舊 举舉
+剧劇 据據 惧懼 觉覺 决決 军軍 开開 凯凱 壳殼 课課 垦墾 恳懇 库庫 块塊 宽寬 矿礦
+亏虧 扩擴 阔闊 腊臘 蜡蠟 来來 兰蘭 拦攔 栏欄 蓝藍 篮籃 览覽 懒懶 烂爛 滥濫 劳勞
+乐樂 类類 垒壘 泪淚 离離 礼禮 历歷 丽麗 厉厲 励勵 连連 联聯 怜憐 帘簾 炼煉 练練
+恋戀 脸臉 两兩 辆輛 凉涼 粮糧 疗療 辽遼 猎獵 临臨 邻鄰 灵靈 岭嶺 龄齡 领領 刘劉
+浏瀏 龙龍 楼樓 芦蘆 炉爐 卢盧 陆陸 录錄 虑慮 乱亂 伦倫 论論 轮輪 罗羅 萝蘿 逻邏
+锣鑼 骡騾 络絡 妈媽 马馬 吗嗎 买買 卖賣 麦麥 满滿 猫貓 门門 们們 梦夢 弥彌 庙廟
+灭滅 鸣鳴 亩畝 难難 脑腦 恼惱 拟擬 鸟鳥 宁寧 农農 浓濃 欧歐 盘盤 赔賠 喷噴 鹏鵬
+骗騙 飘飄 贫貧 苹蘋 凭憑 评評 泼潑 扑撲 铺鋪 谱譜 齐齊 骑騎 岂豈 启啟 气氣 弃棄
+铅鉛 迁遷 签簽 钱錢 浅淺 枪槍 墙牆 抢搶 桥橋 乔喬 侨僑 窍竅 亲親 轻輕 庆慶 穷窮
+区區 驱驅 趋趨 权權 劝勸 确確 让讓 热熱 认認 荣榮 软軟 锐銳 润潤 洒灑 伞傘 丧喪
+扫掃 杀殺 晒曬 伤傷 赏賞 烧燒 绍紹 设設 摄攝 审審 肾腎 渗滲 声聲 绳繩 圣聖 胜勝
+师師 诗詩 狮獅 湿濕 时時 实實 识識 势勢 适適 释釋 饰飾 视視 试試 寿壽 兽獸 书書
+输輸 属屬 术術 树樹 帅帥 双雙 谁誰 税稅 顺順 说說 丝絲 饲飼 颂頌 诉訴 苏蘇 肃肅
+虽雖 随隨 岁歲 孙孫 损損 笋筍 锁鎖 琐瑣 态態 谈談 叹嘆 汤湯 烫燙 涛濤 讨討 腾騰
+题題 体體 条條 铁鐵 厅廳 听聽 头頭 图圖 团團 椭橢 袜襪 湾灣 弯彎 万萬 网網 韦韋
+违違 围圍 为為 伟偉 卫衛 纬緯 稳穩 问問 无無 务務 雾霧 误誤 戏戲 细細 虾蝦 吓嚇
+厦廈 鲜鮮 闲閒 显顯 险險 现現 县縣 宪憲 线線 乡鄉 详詳 响響 项項 萧蕭 销銷 晓曉
+协協 胁脅 写寫 谢謝 兴興 许許 续續 绪緒 选選 学學 寻尋 询詢 训訓 讯訊 压壓 鸦鴉
+亚亞 严嚴 盐鹽 颜顏 验驗 厌厭 阳陽 养養 样樣 杨楊 痒癢 药藥 爷爺 业業 叶葉 页頁
+医醫 仪儀 遗遺 亿億 忆憶 艺藝 议議 义義 异異 译譯 阴陰 银銀 饮飲 隐隱 应應 营營
+樱櫻 鹰鷹 赢贏 拥擁 佣傭 涌湧 优優 忧憂 邮郵 犹猶 鱼魚 与與 语語 狱獄 预預 誉譽
+员員 园園 圆圓 远遠 愿願 约約 跃躍 阅閱 云雲 运運 韵韻 杂雜 灾災 载載 赞贊 凿鑿
+枣棗 责責 择擇 泽澤 贼賊 赠贈 闸閘 诈詐 斋齋 债債 战戰 张張 涨漲 帐帳 账賬 胀脹
+赵趙 这這 针針 侦偵 诊診 阵陣 镇鎮 争爭 挣掙 证證 织織 执執 职職 纸紙 质質 钟鐘
+终終 种種 肿腫 众眾 昼晝 皱皺 猪豬 烛燭 嘱囑 筑築 专專 转轉 赚賺 庄莊 装裝 壮壯
+状狀 准準 桩樁 资資 综綜 总總 纵縱 邹鄒 组組 钻鑽 数數 码碼 么麼 场場 报報 坛壇
+够夠 夹夾 宝寶 岛島 币幣 帮幫 废廢 弹彈 户戶 扬揚 挂掛 摆擺 摇搖 撑撐 晋晉 暂暫
+柜櫃 标標 栋棟 档檔 横橫 残殘 毕畢 毙斃 没沒 测測 浑渾 涂塗 渊淵 温溫 滚滾 滞滯
+滤濾 潜潛 烟煙 焕煥 牵牽 狭狹 献獻 畅暢 疯瘋 盏盞 睁睜 矫矯 窃竊 笼籠 简簡 纠糾
+红紅 纯純 纱紗 纳納 纹紋 绑綁 绕繞 绘繪 绝絕 统統 绩績 维維 绿綠 缓緩 编編 缘緣
+缩縮 肠腸 肤膚 脉脈 脚腳 茧繭 莲蓮 虏擄 蚀蝕 蛮蠻 订訂 讳諱 诚誠 诞誕 请請 诸諸
+谊誼 谋謀 谎謊 谜謎 谣謠 谦謙 谨謹 财財 贤賢 败敗 贩販 贪貪 贯貫 贴貼 贵貴 贷貸
+贺賀 赋賦 赌賭 赖賴 赛賽 踪蹤 轨軌 轩軒 较較 辅輔 辈輩 辞辭 迹跡 逊遜 郑鄭 酱醬
+钉釘 钙鈣 钞鈔 钥鑰 钦欽 钩鉤 钮鈕 铃鈴 铜銅 铝鋁 链鏈 锅鍋 锋鋒 锡錫 锦錦 锻鍛
+闭閉 闷悶 闹鬧 闻聞 阁閣 须須 顿頓 颁頒 颇頗 频頻 额額 饱飽 饼餅 馈饋 驳駁 驶駛
+驻駐 骂罵 骄驕 骤驟 鸭鴨 鹤鶴 乌烏 习習 仑侖 仓倉 伪偽 侠俠 侣侶 侧側 俭儉 倾傾
+偿償 储儲 册冊 冯馮 况況 净淨 则則 删刪 别別 剂劑 办辦 勋勳 匀勻 却卻 厕廁 厢廂
+叙敘 吕呂 吴吳 呜嗚 咏詠 哑啞 哗嘩 啸嘯 坝壩 坠墜 垄壟 堑塹 壶壺 娱娛 婴嬰 宠寵
+尧堯 尴尷 尸屍 岗崗 峡峽 帜幟 幂冪 强強 彦彥 径徑 忏懺 悦悅 悬懸 惨慘 惫憊 惭慚
+愤憤 慑懾 扰擾 抚撫 抛拋 拢攏 拧擰 拨撥 挚摯 挠撓 捞撈 捡撿 掷擲 揽攬 搀攙 携攜
+摊攤 旷曠 昙曇 晕暈 枢樞 栈棧 榄欖 歼殲 殴毆 毡氈 泻瀉 涝澇 渔漁 溃潰 溅濺 滨濱
+滩灘 潇瀟 澜瀾 烁爍 烦煩 玛瑪 玺璽 疟瘧 瘫癱 盗盜 砖磚 硕碩 碍礙 秃禿 秽穢 窝窩
+竖豎 筛篩 纤纖 纷紛 绅紳 绒絨 绵綿 缴繳 舱艙 艳豔 芜蕪 苍蒼 茎莖 荡蕩 莱萊 莹瑩
+萤螢 萨薩 蔼藹 蚁蟻`
+
+// tradOnly 只从繁体转换为简体的字
+const tradOnly = `髮发 曆历 彙汇 鍾钟 閑闲 讚赞 糰团 裡里 裏里 乾干 後后 麵面 隻只 臺台
+颱台 檯台 鬆松 醜丑 鬥斗 範范 係系 繫系 衝冲 併并 並并 於于 餘余 捲卷 瀰弥 夥伙
+紮扎 週周 誌志 髒脏 樸朴 嶽岳 纔才 製制 佔占 嚮向 噁恶 臟脏 穀谷 遊游`
+
+var (
+	simpToTrad = make(map[rune]rune)
+	tradToSimp = make(map[rune]rune)
+)
+
+func init() {
+	for _, pair := range strings.Fields(simpTrad) {
+		simp, size := utf8.DecodeRuneInString(pair)
+		trad, _ := utf8.DecodeRuneInString(pair[size:])
+		simpToTrad[simp] = trad
+		tradToSimp[trad] = simp
+	}
+
+	for _, pair := range strings.Fields(tradOnly) {
+		trad, size := utf8.DecodeRuneInString(pair)
+		simp, _ := utf8.DecodeRuneInString(pair[size:])
+		tradToSimp[trad] = simp
+	}
+}
+
+// mapRunes 转换 table 中的字，没有需要转换的字时返回原字符串
+func mapRunes(text string, table map[rune]rune) string {
+	for i, r := range text {
+		if _, ok := table[r]; ok {
+			return text[:i] + strings.Map(func(r rune) rune {
+				if to, ok := table[r]; ok {
+					return to
+				}
+				return r
+			}, text[i:])
+		}
+	}
+	return text
+}
+
+// T2SFilter convert the Traditional Chinese to the Simplified Chinese
+var T2SFilter = CharFilterFunc(func(text string) string {
+	return mapRunes(text, tradToSimp)
+})
+
+// S2TFilter convert the Simplified Chinese to the Traditional Chinese,
+// the characters with several Traditional forms are not converted
+var S2TFilter = CharFilterFunc(func(text string) string {
+	return mapRunes(text, simpToTrad)
+})
+
+// 半角片假名 U+FF61 到 U+FF9F 对应的全角字符
+const halfKana = "。「」、・ヲァィゥェォャュョッーアイウエオカキクケコ" +
+	"サシスセソタチツテトナニヌネノハヒフヘホマミムメモヤユヨラリルレロワン゛゜"
+
+var (
+	halfKanaRunes = []rune(halfKana)
+	// 全角符号 U+FFE0 到 U+FFE6
+	fullSigns = []rune("¢£¬¯¦¥₩")
+)
+
+// WidthFilter fold the full-width ASCII to the half-width and
+// the half-width Katakana to the full-width, like NFKC
+var WidthFilter = CharFilterFunc(func(text string) string {
+	var b strings.Builder
+	for i, r := range text {
+		if r < 0x3000 {
+			continue
+		}
+
+		switch {
+		case r == 0x3000:
+		case r >= 0xff01 && r <= 0xff5e:
+		case r >= 0xff61 && r <= 0xff9f:
+		case r >= 0xffe0 && r <= 0xffe6:
+		default:
+			continue
+		}
+
+		b.Grow(len(text))
+		b.WriteString(text[:i])
+		foldWidth(&b, text[i:])
+		return b.String()
+	}
+
+	return text
+})
+
+func foldWidth(b *strings.Builder, text string) {
+	var last rune
+	for _, r := range text {
+		switch {
+		case r == 0x3000:
+			r = ' '
+		case r >= 0xff01 && r <= 0xff5e:
+			r -= 0xfee0
+		case r >= 0xffe0 && r <= 0xffe6:
+			r = fullSigns[r-0xffe0]
+		case r >= 0xff61 && r <= 0xff9f:
+			r = halfKanaRunes[r-0xff61]
+			// 合并浊音和半浊音
+			if voiced := voiceKana(last, r); voiced != 0 {
+				last = voiced
+				continue
+			}
+		}
+
+		if last != 0 {
+			b.WriteRune(last)
+		}
+		last = r
+	}
+
+	if last != 0 {
+		b.WriteRune(last)
+	}
+}
+
+// voiceKana 片假名加上浊音符号或半浊音符号后的字符，不能合并时返回 0
+func voiceKana(kana, mark rune) rune {
+	switch {
+	case mark == '゛' && kana == 'ウ':
+		return 'ヴ'
+	case mark == '゛' && strings.ContainsRune("カキクケコサシスセソタチツテトハヒフヘホ", kana):
+		return kana + 1
+	case mark == '゜' && strings.ContainsRune("ハヒフヘホ", kana):
+		return kana + 2
+	}
+	return 0
+}
+
+// cnPunct 中文标点对应的半角标点
+var cnPunct = strings.NewReplacer(
+	"，", ",", "。", ".", "、", ",", "；", ";", "：", ":", "？", "?", "！", "!",
+	"“", "\"", "”", "\"", "‘", "'", "’", "'", "「", "\"", "」", "\"",
+	"『", "\"", "』", "\"", "（", "(", "）", ")", "【", "[", "】", "]",
+	"〔", "[", "〕", "]", "《", "<", "》", ">", "〈", "<", "〉", ">",
+	"……", "...", "…", "...", "——", "-", "—", "-", "～", "~", "·", " ", "・", " ",
+)
+
+// PunctFilter normalize the Chinese punctuation to the ASCII punctuation
+var PunctFilter = CharFilterFunc(cnPunct.Replace)
@@ -0,0 +1,50 @@
+package riot
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-ego/riot/types"
+	"github.com/vcaesar/tt"
+)
+
+func TestConvertTable(t *testing.T) {
+	tt.Equal(t, len(simpToTrad), len(strings.Fields(simpTrad)))
+	for simp, trad := range simpToTrad {
+		tt.Equal(t, simp, tradToSimp[trad])
+	}
+
+	tt.Equal(t, "世界有七十亿人口，头发", T2SFilter.Filter("世界有七十億人口，頭髮"))
+	tt.Equal(t, "這個國家", S2TFilter.Filter("这个国家"))
+	tt.Equal(t, "abc", T2SFilter.Filter("abc"))
+}
+
+func TestWidthFilter(t *testing.T) {
+	tt.Equal(t, 63, len(halfKanaRunes))
+	tt.Equal(t, "iPhone 12 (Pro)", WidthFilter.Filter("ｉＰｈｏｎｅ　１２ （Ｐｒｏ）"))
+	tt.Equal(t, "ガパピヴテ", WidthFilter.Filter("ｶﾞﾊﾟﾋﾟｳﾞﾃ"))
+	tt.Equal(t, "¥100", WidthFilter.Filter("￥100"))
+	tt.Equal(t, "中文", WidthFilter.Filter("中文"))
+
+	tt.Equal(t, `"世界",人口... (七十亿)!`,
+		PunctFilter.Filter("“世界”、人口…… （七十亿）！"))
+}
+
+func TestChineseFilters(t *testing.T) {
+	var engine Engine
+	engine.Init(types.EngineOpts{
+		GseDict:     "./testdata/test_dict.txt",
+		IndexerOpts: inxOpts,
+		CharFilters: []string{"width", "t2s", "cn_punct"},
+	})
+	defer engine.Close()
+
+	engine.Index("1", types.DocData{Content: "世界有七十億人口"})
+	engine.Index("2", types.DocData{Content: "型号ＸＹ１２"})
+	engine.Flush()
+
+	tt.Equal(t, []string{"1"}, searchIds(&engine, "七十亿"))
+	tt.Equal(t, []string{"1"}, searchIds(&engine, "七十億"))
+	tt.Equal(t, []string{"2"}, searchIds(&engine, "XY12"))
+	tt.Equal(t, []string{"七十亿", "人口"}, engine.Segment("七十億人口"))
+}
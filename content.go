@@ -0,0 +1,178 @@
+// Copyright 2016 ego authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package riot
+
+import (
+	"bytes"
+	"container/list"
+	"encoding/gob"
+	"sync"
+
+	"github.com/go-ego/murmur"
+	"github.com/go-ego/riot/types"
+)
+
+// storedContent 文档的内容和属性
+type storedContent struct {
+	content string
+	attri   interface{}
+}
+
+type contentEntry struct {
+	docId string
+	value storedContent
+}
+
+// contentCache 文档内容的 LRU 缓存，nil 表示不缓存
+//
+// 每次删除缓存项 epoch 加一，读取存储之前的 epoch 和写入缓存时
+// 不一致说明期间文档可能被修改，不写入缓存，避免缓存旧的内容。
+type contentCache struct {
+	sync.Mutex
+	size  int
+	epoch uint64
+	list  *list.List
+	items map[string]*list.Element
+}
+
+func newContentCache(size int) *contentCache {
+	if size <= 0 {
+		return nil
+	}
+
+	return &contentCache{
+		size:  size,
+		list:  list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+// get 读取缓存，返回当前的 epoch
+func (c *contentCache) get(docId string) (storedContent, uint64, bool) {
+	if c == nil {
+		return storedContent{}, 0, false
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	elem, ok := c.items[docId]
+	if !ok {
+		return storedContent{}, c.epoch, false
+	}
+	c.list.MoveToFront(elem)
+
+	return elem.Value.(*contentEntry).value, c.epoch, true
+}
+
+// put 写入缓存，epoch 已经改变时忽略
+func (c *contentCache) put(docId string, value storedContent, epoch uint64) {
+	if c == nil {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	if epoch != c.epoch {
+		return
+	}
+
+	if elem, ok := c.items[docId]; ok {
+		elem.Value.(*contentEntry).value = value
+		c.list.MoveToFront(elem)
+		return
+	}
+
+	c.items[docId] = c.list.PushFront(&contentEntry{docId: docId, value: value})
+	if c.list.Len() > c.size {
+		last := c.list.Back()
+		c.list.Remove(last)
+		delete(c.items, last.Value.(*contentEntry).docId)
+	}
+}
+
+// remove 删除缓存项，文档写入存储之后调用
+func (c *contentCache) remove(docIds ...string) {
+	if c == nil {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	c.epoch++
+	for _, docId := range docIds {
+		if elem, ok := c.items[docId]; ok {
+			c.list.Remove(elem)
+			delete(c.items, docId)
+		}
+	}
+}
+
+// len 缓存的文档数
+func (c *contentCache) len() int {
+	if c == nil {
+		return 0
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	return c.list.Len()
+}
+
+// storedDoc 从持久化存储读取文档，文档不存在时返回 ErrDocNotFound
+func (engine *Engine) storedDoc(docId string) (types.DocData, error) {
+	var data types.DocData
+
+	shard := murmur.Sum32(docId) % uint32(engine.initOptions.StoreShards)
+	key := []byte(docId)
+	value, err := engine.dbs[shard].Get(key)
+	if err != nil || len(value) == 0 {
+		// 不同的存储引擎对不存在的 key 返回不同的错误
+		if has, _ := engine.dbs[shard].Has(key); !has {
+			return data, ErrDocNotFound
+		}
+		if err != nil {
+			return data, err
+		}
+	}
+
+	err = gob.NewDecoder(bytes.NewReader(value)).Decode(&data)
+	return data, err
+}
+
+// loadContents 从缓存或持久化存储读取文档的内容和属性，
+// 用于 LazyContent，只读取需要返回的文档
+func (engine *Engine) loadContents(docs types.ScoredDocs) {
+	for i := range docs {
+		docId := docs[i].DocId
+
+		value, epoch, ok := engine.contents.get(docId)
+		if !ok {
+			data, err := engine.storedDoc(docId)
+			if err != nil {
+				continue
+			}
+
+			value = storedContent{content: data.Content, attri: data.Attri}
+			engine.contents.put(docId, value, epoch)
+		}
+
+		docs[i].Content = value.content
+		docs[i].Attri = value.attri
+	}
+}
@@ -0,0 +1,78 @@
+package riot
+
+import (
+	"encoding/gob"
+	"os"
+	"testing"
+
+	"github.com/go-ego/riot/types"
+	"github.com/vcaesar/tt"
+)
+
+func TestContentCache(t *testing.T) {
+	cache := newContentCache(2)
+
+	_, epoch, ok := cache.get("1")
+	tt.False(t, ok)
+	cache.put("1", storedContent{content: "one"}, epoch)
+	cache.put("2", storedContent{content: "two"}, epoch)
+
+	value, _, ok := cache.get("1")
+	tt.True(t, ok)
+	tt.Equal(t, "one", value.content)
+
+	// 淘汰最久没有使用的 "2"
+	cache.put("3", storedContent{content: "three"}, epoch)
+	_, _, ok = cache.get("2")
+	tt.False(t, ok)
+
+	// 读取期间文档被修改，不写入缓存
+	_, epoch, _ = cache.get("4")
+	cache.remove("1")
+	cache.put("4", storedContent{content: "four"}, epoch)
+	_, _, ok = cache.get("4")
+	tt.False(t, ok)
+	_, _, ok = cache.get("1")
+	tt.False(t, ok)
+
+	var none *contentCache
+	none.put("1", storedContent{}, 0)
+	_, _, ok = none.get("1")
+	tt.False(t, ok)
+}
+
+func TestLazyContent(t *testing.T) {
+	gob.Register(ScoringFields{})
+	gob.Register(types.Attri{})
+
+	opts := engOpts
+	opts.UseStore = true
+	opts.StoreFolder = "riot.lazy"
+	opts.StoreShards = 2
+	opts.LazyContent = true
+	opts.ContentCacheSize = 2
+
+	var engine Engine
+	engine.Init(opts)
+	defer os.RemoveAll("riot.lazy")
+
+	AddDocs(&engine)
+
+	outputs := engine.Search(Req1)
+	outDocs := outputs.Docs.(types.ScoredDocs)
+	tt.Expect(t, "2", len(outDocs))
+	tt.Expect(t, "1", outDocs[0].DocId)
+	tt.Expect(t, "The world, 有七十亿人口人口", outDocs[0].Content)
+	tt.Expect(t, "5", outDocs[1].DocId)
+	tt.Expect(t, "The world, 七十亿人口", outDocs[1].Content)
+
+	// 更新属性后缓存失效
+	attri := types.Attri{Title: "title"}
+	tt.Nil(t, engine.UpdateAttri("5", attri))
+	outDocs = engine.Search(Req1).Docs.(types.ScoredDocs)
+	tt.Expect(t, "5", outDocs[1].DocId)
+	tt.Equal(t, attri, outDocs[1].Attri)
+	tt.Expect(t, "The world, 七十亿人口", outDocs[1].Content)
+
+	engine.Close()
+}
@@ -0,0 +1,218 @@
+// Copyright 2016 ego authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package core
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// 元素不超过 arrayMaxSize 个的 container 使用有序数组，否则使用位图
+const arrayMaxSize = 4096
+
+// container 保存高 16 位相同的整数的低 16 位
+type container struct {
+	array []uint16
+	// 1024 个字，非 nil 时使用位图
+	bits []uint64
+}
+
+func (c *container) add(x uint16) {
+	if c.bits != nil {
+		c.bits[x>>6] |= 1 << (x & 63)
+		return
+	}
+
+	i := sort.Search(len(c.array), func(i int) bool { return c.array[i] >= x })
+	if i < len(c.array) && c.array[i] == x {
+		return
+	}
+
+	if len(c.array) >= arrayMaxSize {
+		c.toBits()
+		c.bits[x>>6] |= 1 << (x & 63)
+		return
+	}
+
+	c.array = append(c.array, 0)
+	copy(c.array[i+1:], c.array[i:])
+	c.array[i] = x
+}
+
+func (c *container) toBits() {
+	c.bits = make([]uint64, 1024)
+	for _, x := range c.array {
+		c.bits[x>>6] |= 1 << (x & 63)
+	}
+	c.array = nil
+}
+
+func (c *container) contains(x uint16) bool {
+	if c.bits != nil {
+		return c.bits[x>>6]&(1<<(x&63)) != 0
+	}
+
+	i := sort.Search(len(c.array), func(i int) bool { return c.array[i] >= x })
+	return i < len(c.array) && c.array[i] == x
+}
+
+func (c *container) cardinality() int {
+	if c.bits == nil {
+		return len(c.array)
+	}
+
+	n := 0
+	for _, w := range c.bits {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+func (c *container) each(f func(uint16)) {
+	if c.bits == nil {
+		for _, x := range c.array {
+			f(x)
+		}
+		return
+	}
+
+	for i, w := range c.bits {
+		for w != 0 {
+			f(uint16(i<<6 + bits.TrailingZeros64(w)))
+			w &= w - 1
+		}
+	}
+}
+
+// filter 返回 c 中 o 包含（not 为 false）或者不包含（not 为 true）的元素
+func (c *container) filter(o *container, not bool) *container {
+	if c.bits != nil && o.bits != nil {
+		result := &container{bits: make([]uint64, 1024)}
+		for i, w := range c.bits {
+			if not {
+				result.bits[i] = w &^ o.bits[i]
+			} else {
+				result.bits[i] = w & o.bits[i]
+			}
+		}
+		result.shrink()
+		return result
+	}
+
+	result := &container{}
+	c.each(func(x uint16) {
+		if o.contains(x) != not {
+			result.add(x)
+		}
+	})
+	return result
+}
+
+// shrink 元素较少的位图转换为数组
+func (c *container) shrink() {
+	if c.bits == nil || c.cardinality() > arrayMaxSize {
+		return
+	}
+
+	var array []uint16
+	c.each(func(x uint16) { array = append(array, x) })
+	c.array = array
+	c.bits = nil
+}
+
+// bitmap 文档序号的集合，按高 16 位分成多个 container（roaring bitmap）
+//
+// 过滤器缓存中的 bitmap 被多个查找共享，生成之后只读
+type bitmap struct {
+	keys       []uint16
+	containers []*container
+}
+
+func (b *bitmap) index(key uint16) (int, bool) {
+	i := sort.Search(len(b.keys), func(i int) bool { return b.keys[i] >= key })
+	return i, i < len(b.keys) && b.keys[i] == key
+}
+
+func (b *bitmap) add(x uint32) {
+	key := uint16(x >> 16)
+	i, found := b.index(key)
+	if !found {
+		b.keys = append(b.keys, 0)
+		copy(b.keys[i+1:], b.keys[i:])
+		b.keys[i] = key
+
+		b.containers = append(b.containers, nil)
+		copy(b.containers[i+1:], b.containers[i:])
+		b.containers[i] = &container{}
+	}
+
+	b.containers[i].add(uint16(x))
+}
+
+func (b *bitmap) contains(x uint32) bool {
+	i, found := b.index(uint16(x >> 16))
+	return found && b.containers[i].contains(uint16(x))
+}
+
+func (b *bitmap) cardinality() int {
+	n := 0
+	for _, c := range b.containers {
+		n += c.cardinality()
+	}
+	return n
+}
+
+// each 从小到大遍历 bitmap 中的元素
+func (b *bitmap) each(f func(uint32)) {
+	for i, c := range b.containers {
+		high := uint32(b.keys[i]) << 16
+		c.each(func(x uint16) { f(high | uint32(x)) })
+	}
+}
+
+// and 返回交集
+func (b *bitmap) and(o *bitmap) *bitmap {
+	result := &bitmap{}
+	for i, key := range b.keys {
+		j, found := o.index(key)
+		if !found {
+			continue
+		}
+
+		c := b.containers[i].filter(o.containers[j], false)
+		if c.cardinality() > 0 {
+			result.keys = append(result.keys, key)
+			result.containers = append(result.containers, c)
+		}
+	}
+	return result
+}
+
+// andNot 返回差集
+func (b *bitmap) andNot(o *bitmap) *bitmap {
+	result := &bitmap{}
+	for i, key := range b.keys {
+		c := b.containers[i]
+		if j, found := o.index(key); found {
+			c = c.filter(o.containers[j], true)
+		}
+
+		if c.cardinality() > 0 {
+			result.keys = append(result.keys, key)
+			result.containers = append(result.containers, c)
+		}
+	}
+	return result
+}
@@ -0,0 +1,349 @@
+// Copyright 2016 ego authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package core
+
+import (
+	"container/list"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/go-ego/riot/types"
+)
+
+// allDocsKey 全部文档的 bitmap 在缓存中的键
+const allDocsKey = "\x00*"
+
+// docFilter 查找时对候选文档的过滤，docIds 和 bitmap 为 nil 时不过滤
+type docFilter struct {
+	docIds   map[string]bool
+	bitmap   *bitmap
+	ordinals map[string]uint32
+}
+
+// skip 文档不满足过滤条件时返回 true
+func (f docFilter) skip(docId string) bool {
+	if f.docIds != nil {
+		if _, found := f.docIds[docId]; !found {
+			return true
+		}
+	}
+
+	if f.bitmap != nil {
+		ordinal, found := f.ordinals[docId]
+		if !found || !f.bitmap.contains(ordinal) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type filterEntry struct {
+	key        string
+	generation uint64
+	bitmap     *bitmap
+}
+
+// filterCache 过滤条件 bitmap 的 LRU 缓存，nil 表示不缓存
+//
+// bitmap 和生成时索引器的 generation 一起保存，索引改变之后失效。
+type filterCache struct {
+	sync.Mutex
+	size  int
+	list  *list.List
+	items map[string]*list.Element
+
+	hits, misses uint64
+}
+
+func newFilterCache(size int) *filterCache {
+	if size <= 0 {
+		return nil
+	}
+
+	return &filterCache{
+		size:  size,
+		list:  list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+func (c *filterCache) get(key string, generation uint64) *bitmap {
+	if c == nil {
+		return nil
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok || elem.Value.(*filterEntry).generation != generation {
+		atomic.AddUint64(&c.misses, 1)
+		return nil
+	}
+
+	atomic.AddUint64(&c.hits, 1)
+	c.list.MoveToFront(elem)
+	return elem.Value.(*filterEntry).bitmap
+}
+
+func (c *filterCache) put(key string, generation uint64, bm *bitmap) {
+	if c == nil {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	entry := &filterEntry{key: key, generation: generation, bitmap: bm}
+	if elem, ok := c.items[key]; ok {
+		elem.Value = entry
+		c.list.MoveToFront(elem)
+		return
+	}
+
+	c.items[key] = c.list.PushFront(entry)
+	if c.list.Len() > c.size {
+		last := c.list.Back()
+		c.list.Remove(last)
+		delete(c.items, last.Value.(*filterEntry).key)
+	}
+}
+
+// FilterCacheStats get the number of hits, misses and entries
+// of the filter cache
+func (indexer *Indexer) FilterCacheStats() (hits, misses uint64, entries int) {
+	c := indexer.filters
+	if c == nil {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	return atomic.LoadUint64(&c.hits), atomic.LoadUint64(&c.misses), c.list.Len()
+}
+
+// ordinal 文档的序号，加入索引表时分配，优先使用删除的文档回收的序号
+// 调用前需要加写锁
+func (indexer *Indexer) ordinal(docId string) {
+	if _, found := indexer.tableLock.ordinals[docId]; found {
+		return
+	}
+
+	if free := indexer.tableLock.freeOrds; len(free) > 0 {
+		ordinal := free[len(free)-1]
+		indexer.tableLock.freeOrds = free[:len(free)-1]
+		indexer.tableLock.ordinals[docId] = ordinal
+		indexer.tableLock.ordDocIds[ordinal] = docId
+		return
+	}
+
+	indexer.tableLock.ordinals[docId] = uint32(len(indexer.tableLock.ordDocIds))
+	indexer.tableLock.ordDocIds = append(indexer.tableLock.ordDocIds, docId)
+}
+
+// releaseOrdinal 回收删除的文档的序号，调用前需要加写锁并增加 generation，
+// 使缓存中使用旧序号的 bitmap 失效
+func (indexer *Indexer) releaseOrdinal(docId string) {
+	ordinal, found := indexer.tableLock.ordinals[docId]
+	if !found {
+		return
+	}
+
+	delete(indexer.tableLock.ordinals, docId)
+	indexer.tableLock.ordDocIds[ordinal] = ""
+	indexer.tableLock.freeOrds = append(indexer.tableLock.freeOrds, ordinal)
+}
+
+// FilterLookup lookup the docs which match all the filters,
+// the other arguments are the same as Lookup;
+// when there are no tokens, labels and logic expressions,
+// all the docs matching the filters are returned
+func (indexer *Indexer) FilterLookup(filters []types.Filter,
+	tokens, labels []string, docIds map[string]bool, countDocsOnly bool,
+	logic ...types.Logic) (docs []types.IndexedDoc, numDocs int) {
+
+	if indexer.initialized == false {
+		log.Fatal("The Indexer has not been initialized.")
+	}
+
+	indexer.tableLock.RLock()
+	defer indexer.tableLock.RUnlock()
+
+	if indexer.numDocs == 0 {
+		return
+	}
+
+	filter := docFilter{docIds: docIds}
+	if len(filters) > 0 {
+		filter.bitmap = indexer.compileFilters(filters)
+		filter.ordinals = indexer.tableLock.ordinals
+
+		if len(tokens) == 0 && len(labels) == 0 && !useLogic(false, logic) {
+			return indexer.filterDocs(filter, countDocsOnly)
+		}
+	}
+
+	return indexer.lookup(filter, tokens, labels, countDocsOnly, logic...)
+}
+
+// filterDocs 返回 bitmap 中的全部文档
+func (indexer *Indexer) filterDocs(filter docFilter, countDocsOnly bool) (
+	docs []types.IndexedDoc, numDocs int) {
+
+	filter.bitmap.each(func(ordinal uint32) {
+		docId := indexer.tableLock.ordDocIds[ordinal]
+		if _, found := filter.docIds[docId]; filter.docIds != nil && !found {
+			return
+		}
+
+		docState, ok := indexer.tableLock.docsState[docId]
+		if !ok || docState != 0 {
+			return
+		}
+
+		if !countDocsOnly {
+			docs = append(docs, types.IndexedDoc{DocId: docId})
+		}
+		numDocs++
+	})
+
+	return
+}
+
+// compileFilters 求全部过滤条件的 bitmap 的交集，调用前需要加读锁
+func (indexer *Indexer) compileFilters(filters []types.Filter) *bitmap {
+	var (
+		result *bitmap
+		nots   []*bitmap
+	)
+
+	for _, filter := range filters {
+		bm := indexer.clauseBitmap(filter)
+		if filter.Not {
+			nots = append(nots, bm)
+			continue
+		}
+
+		if result == nil {
+			result = bm
+		} else {
+			result = result.and(bm)
+		}
+	}
+
+	if result == nil {
+		// 只有排除条件时从全部文档中排除
+		result = indexer.cachedBitmap(allDocsKey, indexer.allDocsBitmap)
+	}
+
+	for _, bm := range nots {
+		result = result.andNot(bm)
+	}
+
+	return result
+}
+
+// clauseBitmap 一个过滤条件（不考虑 Not）的 bitmap
+func (indexer *Indexer) clauseBitmap(filter types.Filter) *bitmap {
+	labels := append([]string(nil), filter.Labels...)
+	sort.Strings(labels)
+	docIds := append([]string(nil), filter.DocIds...)
+	sort.Strings(docIds)
+
+	key := strings.Join(labels, "\x00") + "\x01" + strings.Join(docIds, "\x00")
+	return indexer.cachedBitmap(key, func() *bitmap {
+		var result *bitmap
+		for _, label := range labels {
+			bm := indexer.labelBitmap(label)
+			if result == nil {
+				result = bm
+			} else {
+				result = result.and(bm)
+			}
+		}
+
+		if len(docIds) > 0 {
+			bm := &bitmap{}
+			for _, docId := range docIds {
+				if ordinal, found := indexer.tableLock.ordinals[docId]; found {
+					bm.add(ordinal)
+				}
+			}
+
+			if result == nil {
+				result = bm
+			} else {
+				result = result.and(bm)
+			}
+		}
+
+		if result == nil {
+			// 空的过滤条件匹配全部文档
+			result = indexer.cachedBitmap(allDocsKey, indexer.allDocsBitmap)
+		}
+
+		return result
+	})
+}
+
+// cachedBitmap 从缓存中读取 bitmap，不存在时调用 build 生成
+func (indexer *Indexer) cachedBitmap(key string, build func() *bitmap) *bitmap {
+	generation := indexer.tableLock.generation
+	if bm := indexer.filters.get(key, generation); bm != nil {
+		return bm
+	}
+
+	bm := build()
+	indexer.filters.put(key, generation, bm)
+	return bm
+}
+
+// labelBitmap 包含搜索键 label 的文档的 bitmap
+func (indexer *Indexer) labelBitmap(label string) *bitmap {
+	bm := &bitmap{}
+	indices, found := indexer.tableLock.table[label]
+	if !found {
+		return bm
+	}
+
+	for _, docId := range indices.docIds {
+		if ordinal, found := indexer.tableLock.ordinals[docId]; found {
+			bm.add(ordinal)
+		}
+	}
+
+	return bm
+}
+
+// allDocsBitmap 索引表中全部文档的 bitmap
+func (indexer *Indexer) allDocsBitmap() *bitmap {
+	bm := &bitmap{}
+	for docId, docState := range indexer.tableLock.docsState {
+		if docState != 0 {
+			continue
+		}
+
+		if ordinal, found := indexer.tableLock.ordinals[docId]; found {
+			bm.add(ordinal)
+		}
+	}
+
+	return bm
+}
@@ -0,0 +1,144 @@
+package core
+
+import (
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/go-ego/riot/types"
+	"github.com/vcaesar/tt"
+)
+
+func TestBitmap(t *testing.T) {
+	a, b := &bitmap{}, &bitmap{}
+	// a 的第一个 container 超过 arrayMaxSize 个元素，使用位图
+	for i := uint32(0); i < 10000; i++ {
+		a.add(i)
+	}
+	a.add(1 << 20)
+	for i := uint32(0); i < 10000; i += 3 {
+		b.add(i)
+	}
+	b.add(1 << 20)
+	b.add(1<<20 + 1)
+
+	tt.Equal(t, 10001, a.cardinality())
+	tt.True(t, a.containers[0].bits != nil)
+	tt.True(t, b.containers[0].bits == nil)
+	tt.True(t, a.contains(1<<20))
+	tt.False(t, a.contains(1<<20+1))
+
+	and := a.and(b)
+	tt.Equal(t, 3335, and.cardinality())
+	tt.True(t, and.contains(9999))
+	tt.False(t, and.contains(1<<20+1))
+
+	andNot := a.andNot(b)
+	tt.Equal(t, 6666, andNot.cardinality())
+	tt.False(t, andNot.contains(3))
+	tt.True(t, andNot.contains(4))
+
+	var last uint32
+	andNot.each(func(x uint32) {
+		tt.True(t, x > last)
+		last = x
+	})
+}
+
+func filterIds(docs []types.IndexedDoc, numDocs int) []string {
+	var ids []string
+	for _, doc := range docs {
+		ids = append(ids, doc.DocId)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+func TestFilterLookup(t *testing.T) {
+	var indexer Indexer
+	indexer.Init(types.IndexerOpts{IndexType: types.DocIdsIndex})
+
+	// doc1: token1 tenant1 news
+	// doc2: token1 tenant1
+	// doc3: token1 tenant2 news
+	keywords := map[string][]string{
+		"1": {"token1", "tenant1", "news"},
+		"2": {"token1", "tenant1"},
+		"3": {"token1", "tenant2", "news"},
+	}
+	for _, docId := range []string{"1", "2", "3"} {
+		var kws []types.KeywordIndex
+		for _, kw := range keywords[docId] {
+			kws = append(kws, types.KeywordIndex{Text: kw})
+		}
+		indexer.AddDocToCache(&types.DocIndex{DocId: docId, Keywords: kws}, false)
+	}
+	indexer.AddDocToCache(nil, true)
+
+	tenant1 := types.Filter{Labels: []string{"tenant1"}}
+	news := types.Filter{Labels: []string{"news"}}
+
+	tt.Equal(t, []string{"1", "2"}, filterIds(indexer.FilterLookup(
+		[]types.Filter{tenant1}, []string{"token1"}, nil, nil, false)))
+	tt.Equal(t, []string{"1"}, filterIds(indexer.FilterLookup(
+		[]types.Filter{tenant1, news}, []string{"token1"}, nil, nil, false)))
+
+	// 只有过滤条件时返回满足条件的全部文档
+	tt.Equal(t, []string{"1", "3"}, filterIds(indexer.FilterLookup(
+		[]types.Filter{news}, nil, nil, nil, false)))
+	_, numDocs := indexer.FilterLookup([]types.Filter{news}, nil, nil, nil, true)
+	tt.Equal(t, 2, numDocs)
+
+	tt.Equal(t, []string{"2"}, filterIds(indexer.FilterLookup(
+		[]types.Filter{{Labels: []string{"news"}, Not: true}}, nil, nil, nil, false)))
+	tt.Equal(t, []string{"3"}, filterIds(indexer.FilterLookup(
+		[]types.Filter{news, {DocIds: []string{"1", "2"}, Not: true}},
+		[]string{"token1"}, nil, nil, false)))
+	tt.Equal(t, []string{"1"}, filterIds(indexer.FilterLookup(
+		[]types.Filter{news}, []string{"token1"}, nil,
+		map[string]bool{"1": true, "2": true}, false)))
+
+	hits, misses, entries := indexer.FilterCacheStats()
+	tt.True(t, hits > 0)
+	tt.Equal(t, uint64(entries), misses)
+
+	// 索引改变之后重新生成
+	indexer.RemoveDocToCache("1", true)
+	tt.Equal(t, []string{"3"}, filterIds(indexer.FilterLookup(
+		[]types.Filter{news}, nil, nil, nil, false)))
+	_, newMisses, _ := indexer.FilterCacheStats()
+	tt.True(t, newMisses > misses)
+
+	indexer.AddDocToCache(&types.DocIndex{DocId: "1",
+		Keywords: []types.KeywordIndex{{Text: "news"}}}, true)
+	tt.Equal(t, []string{"1", "3"}, filterIds(indexer.FilterLookup(
+		[]types.Filter{news}, nil, nil, nil, false)))
+}
+
+func TestOrdinals(t *testing.T) {
+	var indexer Indexer
+	indexer.Init(types.IndexerOpts{IndexType: types.DocIdsIndex})
+
+	news := types.Filter{Labels: []string{"news"}}
+	for i := 1; i <= 3; i++ {
+		// 反复加入和删除不同的文档，序号被回收
+		docId := strconv.Itoa(i)
+		indexer.AddDocToCache(&types.DocIndex{DocId: docId,
+			Keywords: []types.KeywordIndex{{Text: "news"}}}, true)
+		tt.Equal(t, []string{docId}, filterIds(indexer.FilterLookup(
+			[]types.Filter{news}, nil, nil, nil, false)))
+
+		indexer.RemoveDocToCache(docId, true)
+		tt.Equal(t, 0, len(indexer.tableLock.ordinals))
+	}
+	tt.Equal(t, 1, len(indexer.tableLock.ordDocIds))
+
+	indexer.AddDocToCache(&types.DocIndex{DocId: "4",
+		Keywords: []types.KeywordIndex{{Text: "news"}}}, false)
+	indexer.AddDocToCache(&types.DocIndex{DocId: "5",
+		Keywords: []types.KeywordIndex{{Text: "news"}}}, true)
+	tt.Equal(t, []string{"4", "5"}, filterIds(indexer.FilterLookup(
+		[]types.Filter{news}, nil, nil, nil, false)))
+	tt.Equal(t, 2, len(indexer.tableLock.ordDocIds))
+}
@@ -37,8 +37,18 @@ type Indexer struct {
 		sync.RWMutex
 		table     map[string]*KeywordIndices
 		docsState map[string]int // nil: 表示无状态记录，0: 存在于索引中，1: 等待删除，2: 等待加入
+		// 索引表或文档状态每次改变加一
+		generation uint64
+
+		// 文档的序号，用于过滤条件的 bitmap，freeOrds 为回收的序号
+		ordinals  map[string]uint32
+		ordDocIds []string
+		freeOrds  []uint32
 	}
 
+	// 过滤条件的 bitmap 缓存
+	filters *filterCache
+
 	addCacheLock struct {
 		sync.RWMutex
 		addCachePointer int
@@ -84,6 +94,8 @@ func (indexer *Indexer) Init(options types.IndexerOpts) {
 
 	indexer.tableLock.table = make(map[string]*KeywordIndices)
 	indexer.tableLock.docsState = make(map[string]int)
+	indexer.tableLock.ordinals = make(map[string]uint32)
+	indexer.filters = newFilterCache(options.FilterCacheSize)
 	indexer.addCacheLock.addCache = make(
 		[]*types.DocIndex, indexer.initOptions.DocCacheSize)
 
@@ -107,6 +119,31 @@ func (indexer *Indexer) HasDoc(docId string) bool {
 	return false
 }
 
+// NumDocs return the number of the documents in the index
+func (indexer *Indexer) NumDocs() uint64 {
+	indexer.tableLock.RLock()
+	defer indexer.tableLock.RUnlock()
+
+	return indexer.numDocs
+}
+
+// NumTokens return the number of the distinct tokens in the index
+func (indexer *Indexer) NumTokens() int {
+	indexer.tableLock.RLock()
+	defer indexer.tableLock.RUnlock()
+
+	return len(indexer.tableLock.table)
+}
+
+// Generation return the generation of the index, which is
+// increased whenever the table or the document states change
+func (indexer *Indexer) Generation() uint64 {
+	indexer.tableLock.RLock()
+	defer indexer.tableLock.RUnlock()
+
+	return indexer.tableLock.generation
+}
+
 // getIndexLen 得到 KeywordIndices 中文档总数
 func (indexer *Indexer) getIndexLen(ti *KeywordIndices) int {
 	return len(ti.docIds)
@@ -127,6 +164,7 @@ func (indexer *Indexer) AddDocToCache(doc *types.DocIndex, forceUpdate bool) {
 	docSize := indexer.addCacheLock.addCachePointer >= indexer.initOptions.DocCacheSize
 	if docSize || forceUpdate {
 		indexer.tableLock.Lock()
+		indexer.tableLock.generation++
 
 		position := 0
 		for i := 0; i < indexer.addCacheLock.addCachePointer; i++ {
@@ -175,6 +213,24 @@ func (indexer *Indexer) AddDocToCache(doc *types.DocIndex, forceUpdate bool) {
 	}
 }
 
+// Refresh 将 ADDCACHE 和 REMOVECACHE 中等待的文档合并到索引表，
+// 需要和 AddDocToCache 在同一个协程中调用
+func (indexer *Indexer) Refresh() {
+	indexer.addCacheLock.RLock()
+	numAdds := indexer.addCacheLock.addCachePointer
+	indexer.addCacheLock.RUnlock()
+
+	indexer.removeCacheLock.RLock()
+	numRemoves := indexer.removeCacheLock.removeCachePointer
+	indexer.removeCacheLock.RUnlock()
+
+	if numAdds == 0 && numRemoves == 0 {
+		return
+	}
+
+	indexer.AddDocToCache(nil, true)
+}
+
 // AddDocs 向反向索引表中加入 ADDCACHE 中所有文档
 func (indexer *Indexer) AddDocs(docs *types.DocsIndex) {
 	if indexer.initialized == false {
@@ -183,6 +239,7 @@ func (indexer *Indexer) AddDocs(docs *types.DocsIndex) {
 
 	indexer.tableLock.Lock()
 	defer indexer.tableLock.Unlock()
+	indexer.tableLock.generation++
 	indexPointers := make(map[string]int, len(indexer.tableLock.table))
 
 	// DocId 递增顺序遍历插入文档保证索引移动次数最少
@@ -250,6 +307,7 @@ func (indexer *Indexer) AddDocs(docs *types.DocsIndex) {
 		// 更新文章状态和总数
 		if docIdIsNew {
 			indexer.tableLock.docsState[doc.DocId] = 0
+			indexer.ordinal(doc.DocId)
 			indexer.numDocs++
 		}
 	}
@@ -262,9 +320,14 @@ func (indexer *Indexer) RemoveDocToCache(docId string, forceUpdate bool) bool {
 		log.Fatal("The Indexer has not been initialized.")
 	}
 
+	if docId != "0" {
+		indexer.removeAddCache(docId)
+	}
+
 	indexer.removeCacheLock.Lock()
 	if docId != "0" {
 		indexer.tableLock.Lock()
+		indexer.tableLock.generation++
 		docState, ok := indexer.tableLock.docsState[docId]
 		if ok && docState == 0 {
 			indexer.removeCacheLock.removeCache[indexer.removeCacheLock.removeCachePointer] = docId
@@ -295,6 +358,27 @@ func (indexer *Indexer) RemoveDocToCache(docId string, forceUpdate bool) bool {
 	return false
 }
 
+// removeAddCache 从 ADDCACHE 中去掉还未加入索引表的文档，
+// 之前加入的文档不会在删除之后再被加入
+func (indexer *Indexer) removeAddCache(docId string) {
+	indexer.addCacheLock.Lock()
+	defer indexer.addCacheLock.Unlock()
+
+	position := 0
+	for i := 0; i < indexer.addCacheLock.addCachePointer; i++ {
+		doc := indexer.addCacheLock.addCache[i]
+		if doc.DocId != docId {
+			indexer.addCacheLock.addCache[position] = doc
+			position++
+		}
+	}
+
+	for i := position; i < indexer.addCacheLock.addCachePointer; i++ {
+		indexer.addCacheLock.addCache[i] = nil
+	}
+	indexer.addCacheLock.addCachePointer = position
+}
+
 // RemoveDocs 向反向索引表中删除 REMOVECACHE 中所有文档
 func (indexer *Indexer) RemoveDocs(docs *types.DocsId) {
 	if indexer.initialized == false {
@@ -303,12 +387,14 @@ func (indexer *Indexer) RemoveDocs(docs *types.DocsId) {
 
 	indexer.tableLock.Lock()
 	defer indexer.tableLock.Unlock()
+	indexer.tableLock.generation++
 
 	// 更新文档关键词总长度，删除文档状态
 	for _, docId := range *docs {
 		indexer.totalTokenLen -= indexer.docTokenLens[docId]
 		delete(indexer.docTokenLens, docId)
 		delete(indexer.tableLock.docsState, docId)
+		indexer.releaseOrdinal(docId)
 	}
 
 	for keyword, indices := range indexer.tableLock.table {
@@ -383,39 +469,44 @@ func (indexer *Indexer) Lookup(
 		return
 	}
 
-	// 合并关键词和标签为搜索键
-	keywords := make([]string, len(tokens)+len(labels))
-	copy(keywords, tokens)
-	copy(keywords[len(tokens):], labels)
+	return indexer.lookup(docFilter{docIds: docIds},
+		tokens, labels, countDocsOnly, logic...)
+}
 
-	if len(logic) > 0 {
-		loc := logic[0].Must == true ||
-			logic[0].Should == true || logic[0].NotIn == true
+// useLogic 是否使用逻辑检索
+func useLogic(hasKeywords bool, logic []types.Logic) bool {
+	if len(logic) == 0 {
+		return false
+	}
 
-		if logic != nil && len(keywords) > 0 && loc {
-			docs, numDocs = indexer.LogicLookup(
-				docIds, countDocsOnly, keywords, logic[0])
+	loc := logic[0].Must == true ||
+		logic[0].Should == true || logic[0].NotIn == true
+	if hasKeywords && loc {
+		return true
+	}
 
-			return
-		}
+	return len(logic[0].Expr.Must) > 0 || len(logic[0].Expr.Should) > 0
+}
 
-		expr := len(logic[0].Expr.Must) > 0 ||
-			len(logic[0].Expr.Should) > 0
-		not := len(logic[0].Expr.NotIn) >= 0
+// lookup 调用前需要加读锁
+func (indexer *Indexer) lookup(filter docFilter, tokens, labels []string,
+	countDocsOnly bool, logic ...types.Logic) (
+	docs []types.IndexedDoc, numDocs int) {
 
-		if logic != nil && expr && not {
-			docs, numDocs = indexer.LogicLookup(
-				docIds, countDocsOnly, keywords, logic[0])
+	// 合并关键词和标签为搜索键
+	keywords := make([]string, len(tokens)+len(labels))
+	copy(keywords, tokens)
+	copy(keywords[len(tokens):], labels)
 
-			return
-		}
+	if useLogic(len(keywords) > 0, logic) {
+		return indexer.logicLookup(filter, countDocsOnly, keywords, logic[0])
 	}
 
-	return indexer.internalLookup(keywords, tokens, docIds, countDocsOnly)
+	return indexer.internalLookup(keywords, tokens, filter, countDocsOnly)
 }
 
 func (indexer *Indexer) internalLookup(
-	keywords, tokens []string, docIds map[string]bool, countDocsOnly bool) (
+	keywords, tokens []string, filter docFilter, countDocsOnly bool) (
 	docs []types.IndexedDoc, numDocs int) {
 
 	table := make([]*KeywordIndices, len(keywords))
@@ -446,10 +537,8 @@ func (indexer *Indexer) internalLookup(
 	for ; indexPointers[0] >= 0; indexPointers[0]-- {
 		// 以第一个搜索键出现的文档作为基准，并遍历其他搜索键搜索同一文档
 		baseDocId := indexer.getDocId(table[0], indexPointers[0])
-		if docIds != nil {
-			if _, found := docIds[baseDocId]; !found {
-				continue
-			}
+		if filter.skip(baseDocId) {
+			continue
 		}
 
 		iTable := 1
@@ -562,6 +651,14 @@ func (indexer *Indexer) LogicLookup(
 	docIds map[string]bool, countDocsOnly bool, logicExpr []string,
 	logic types.Logic) (docs []types.IndexedDoc, numDocs int) {
 
+	return indexer.logicLookup(docFilter{docIds: docIds},
+		countDocsOnly, logicExpr, logic)
+}
+
+func (indexer *Indexer) logicLookup(
+	filter docFilter, countDocsOnly bool, logicExpr []string,
+	logic types.Logic) (docs []types.IndexedDoc, numDocs int) {
+
 	// // 有效性检查, 不允许只出现逻辑非检索, 也不允许与或非都不存在
 	// if Logic.Must == true && Logic.Should == true && Logic.NotIn == true {
 	// 	return
@@ -629,11 +726,8 @@ func (indexer *Indexer) LogicLookup(
 		// 如果存在逻辑与检索
 		for idx := indexer.getIndexLen(mustTable[0]) - 1; idx >= 0; idx-- {
 			baseDocId := indexer.getDocId(mustTable[0], idx)
-			if docIds != nil {
-				_, found := docIds[baseDocId]
-				if !found {
-					continue
-				}
+			if filter.skip(baseDocId) {
+				continue
 			}
 
 			mustFound := indexer.findInMustTable(mustTable[1:], baseDocId)
@@ -654,14 +748,20 @@ func (indexer *Indexer) LogicLookup(
 	}
 
 	// 不存在逻辑与检索, 则必须存在逻辑或检索
-	// 这时进行求并集操作
+	// 这时进行求并集操作，求并集时不检查 docIds，只使用过滤条件
+	filter.docIds = nil
 	if logic.Should == true || len(logic.Expr.Should) > 0 {
-		docs, numDocs = indexer.unionTable(shouldTable, notInTable, countDocsOnly)
+		docs, numDocs = indexer.unionTable(shouldTable, notInTable,
+			filter, countDocsOnly)
 	} else {
 		uintDocIds := make([]string, 0)
 		// 当前直接返回 Not 逻辑数据
 		for i := 0; i < len(notInTable); i++ {
 			for _, docid := range notInTable[i].docIds {
+				if filter.skip(docid) {
+					continue
+				}
+
 				if indexer.findInNotInTable(notInTable, docid) {
 					uintDocIds = append(uintDocIds, docid)
 				}
@@ -867,12 +967,16 @@ func (indexer *Indexer) findInNotInTable(table []*KeywordIndices, docId string)
 // 先求差集再求并集， 可以减小内存占用
 // docid 要保序
 func (indexer *Indexer) unionTable(table []*KeywordIndices,
-	notInTable []*KeywordIndices, countDocsOnly bool) (
+	notInTable []*KeywordIndices, filter docFilter, countDocsOnly bool) (
 	docs []types.IndexedDoc, numDocs int) {
 	docIds := make([]string, 0)
 	// 求并集
 	for i := 0; i < len(table); i++ {
 		for _, docid := range table[i].docIds {
+			if filter.skip(docid) {
+				continue
+			}
+
 			if !indexer.findInNotInTable(notInTable, docid) {
 				found := false
 				for _, v := range docIds {
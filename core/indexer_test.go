@@ -131,6 +131,46 @@ func TestRemoveDocToCache(t *testing.T) {
 	tt.Expect(t, "1 3 ", indicesToString(&indexer, "token1"))
 	tt.Expect(t, "2 3 ", indicesToString(&indexer, "token2"))
 	tt.Expect(t, "1 2 ", indicesToString(&indexer, "token3"))
+
+	// 删除还在 ADDCACHE 中的文档
+	indexer.AddDocToCache(&types.DocIndex{
+		DocId:    "4",
+		Keywords: []types.KeywordIndex{{"token4", 0, []int{0}}},
+	}, false)
+	indexer.RemoveDocToCache("4", false)
+	indexer.AddDocToCache(nil, true)
+	tt.Expect(t, "", indicesToString(&indexer, "token4"))
+	tt.False(t, indexer.HasDoc("4"))
+}
+
+func TestRefresh(t *testing.T) {
+	var indexer Indexer
+	indexer.Init(types.IndexerOpts{IndexType: types.LocsIndex})
+
+	indexer.AddDocToCache(&types.DocIndex{
+		DocId:    "1",
+		Keywords: []types.KeywordIndex{{"token1", 0, []int{}}},
+	}, false)
+	tt.Expect(t, "", indicesToString(&indexer, "token1"))
+	generation := indexer.Generation()
+
+	indexer.Refresh()
+	tt.Expect(t, "1 ", indicesToString(&indexer, "token1"))
+	tt.True(t, indexer.Generation() > generation)
+	tt.Expect(t, "1", indexer.NumDocs())
+	tt.Expect(t, "1", indexer.NumTokens())
+
+	indexer.RemoveDocToCache("1", false)
+	tt.Expect(t, "1 ", indicesToString(&indexer, "token1"))
+
+	indexer.Refresh()
+	tt.Expect(t, "", indicesToString(&indexer, "token1"))
+	tt.False(t, indexer.HasDoc("1"))
+	tt.Expect(t, "0", indexer.NumDocs())
+
+	// 没有等待的文档
+	indexer.Refresh()
+	tt.Expect(t, "", indicesToString(&indexer, "token1"))
 }
 
 func TestLookupLocsIndex(t *testing.T) {
@@ -34,9 +34,14 @@ type Ranker struct {
 		// new
 		content map[string]string
 		attri   map[string]interface{}
+
+		// 文档、评分字段或属性每次改变加一
+		generation uint64
 	}
 
-	idOnly      bool
+	idOnly bool
+	// 只保存评分字段，不保存文档内容和属性
+	lazy        bool
 	initialized bool
 }
 
@@ -54,13 +59,26 @@ func (ranker *Ranker) Init(onlyID ...bool) {
 	ranker.lock.fields = make(map[string]interface{})
 	ranker.lock.docs = make(map[string]bool)
 
-	if !ranker.idOnly {
+	if ranker.keepContent() {
 		// new
 		ranker.lock.content = make(map[string]string)
 		ranker.lock.attri = make(map[string]interface{})
 	}
 }
 
+// InitLazy init the ranker which only keeps the scoring fields,
+// the Content and Attri of the ranked docs are empty
+// 初始化只保存评分字段的排序器，文档内容和属性由调用者从存储中读取
+func (ranker *Ranker) InitLazy() {
+	ranker.lazy = true
+	ranker.Init()
+}
+
+// keepContent 是否在内存中保存文档内容和属性
+func (ranker *Ranker) keepContent() bool {
+	return !ranker.idOnly && !ranker.lazy
+}
+
 // AddDoc add doc
 // 给某个文档添加评分字段
 func (ranker *Ranker) AddDoc(
@@ -71,10 +89,11 @@ func (ranker *Ranker) AddDoc(
 	}
 
 	ranker.lock.Lock()
+	ranker.lock.generation++
 	ranker.lock.fields[docId] = fields
 	ranker.lock.docs[docId] = true
 
-	if !ranker.idOnly {
+	if ranker.keepContent() {
 		// new
 		if len(content) > 0 {
 			ranker.lock.content[docId] = content[0].(string)
@@ -96,10 +115,11 @@ func (ranker *Ranker) RemoveDoc(docId string) {
 	}
 
 	ranker.lock.Lock()
+	ranker.lock.generation++
 	delete(ranker.lock.fields, docId)
 	delete(ranker.lock.docs, docId)
 
-	if !ranker.idOnly {
+	if ranker.keepContent() {
 		// new
 		delete(ranker.lock.content, docId)
 		delete(ranker.lock.attri, docId)
@@ -108,6 +128,77 @@ func (ranker *Ranker) RemoveDoc(docId string) {
 	ranker.lock.Unlock()
 }
 
+// Generation return the generation of the ranker, which is
+// increased whenever the documents, fields or attributes change
+func (ranker *Ranker) Generation() uint64 {
+	ranker.lock.RLock()
+	defer ranker.lock.RUnlock()
+
+	return ranker.lock.generation
+}
+
+// GetDoc 得到某个文档的评分字段、内容和属性，文档不存在时返回 false
+func (ranker *Ranker) GetDoc(docId string) (
+	fields interface{}, content string, attri interface{}, ok bool) {
+	if ranker.initialized == false {
+		log.Fatal("The Ranker has not been initialized.")
+	}
+
+	ranker.lock.RLock()
+	defer ranker.lock.RUnlock()
+
+	if _, ok = ranker.lock.docs[docId]; !ok {
+		return
+	}
+
+	fields = ranker.lock.fields[docId]
+	if ranker.keepContent() {
+		content = ranker.lock.content[docId]
+		attri = ranker.lock.attri[docId]
+	}
+
+	return
+}
+
+// UpdateFields 更新某个文档的评分字段，文档不存在时返回 false
+func (ranker *Ranker) UpdateFields(docId string, fields interface{}) bool {
+	if ranker.initialized == false {
+		log.Fatal("The Ranker has not been initialized.")
+	}
+
+	ranker.lock.Lock()
+	defer ranker.lock.Unlock()
+
+	if _, ok := ranker.lock.docs[docId]; !ok {
+		return false
+	}
+	ranker.lock.generation++
+	ranker.lock.fields[docId] = fields
+
+	return true
+}
+
+// UpdateAttri 更新某个文档的属性，文档不存在时返回 false
+func (ranker *Ranker) UpdateAttri(docId string, attri interface{}) bool {
+	if ranker.initialized == false {
+		log.Fatal("The Ranker has not been initialized.")
+	}
+
+	ranker.lock.Lock()
+	defer ranker.lock.Unlock()
+
+	if _, ok := ranker.lock.docs[docId]; !ok {
+		return false
+	}
+
+	if ranker.keepContent() {
+		ranker.lock.generation++
+		ranker.lock.attri[docId] = attri
+	}
+
+	return true
+}
+
 func maxOutput(options types.RankOpts, docsLen int) (int, int) {
 	var start, end int
 	if options.MaxOutputs != 0 {
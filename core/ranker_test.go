@@ -68,6 +68,26 @@ func TestRankDocument(t *testing.T) {
 		scoredDocsToString(scoredDocs.(types.ScoredDocs)))
 }
 
+func TestRankLazy(t *testing.T) {
+	var ranker Ranker
+	attri := Attri{Title: "title", Author: "who"}
+
+	ranker.InitLazy()
+	ranker.AddDoc("1", DummyScoringFields{}, "content", attri)
+	ranker.AddDoc("3", DummyScoringFields{}, "content", attri)
+	tt.True(t, ranker.UpdateAttri("1", attri))
+
+	scoredDocs, _ := ranker.Rank([]types.IndexedDoc{
+		{DocId: "1", BM25: 6},
+		{DocId: "3", BM25: 24},
+	}, types.RankOpts{ScoringCriteria: types.RankByBM25{}}, false)
+
+	docs := scoredDocs.(types.ScoredDocs)
+	tt.Expect(t, "[3 [24000 ]] [1 [6000 ]] ", scoredDocsToString(docs))
+	tt.Equal(t, "", docs[0].Content)
+	tt.Nil(t, docs[0].Attri)
+}
+
 func TestRankWithCriteria(t *testing.T) {
 	var ranker Ranker
 	attri := Attri{Title: "title", Author: "who"}
@@ -147,3 +167,40 @@ func TestRemoveDoc(t *testing.T) {
 	tt.Expect(t, "[1 [25300 ]] [2 [3000 ]] ",
 		scoredDocsToString(scoredDocs.(types.ScoredDocs)))
 }
+
+func TestUpdateFields(t *testing.T) {
+	var ranker Ranker
+	attri := Attri{Title: "title", Author: "who"}
+
+	ranker.Init()
+	ranker.AddDoc("1", DummyScoringFields{counter: 3}, "content", attri)
+	ranker.AddDoc("2", DummyScoringFields{counter: 1}, "content", attri)
+
+	tt.Expect(t, "2", ranker.Generation())
+	tt.True(t, ranker.UpdateFields("2", DummyScoringFields{counter: 5}))
+	tt.False(t, ranker.UpdateFields("3", DummyScoringFields{counter: 5}))
+	tt.Expect(t, "3", ranker.Generation())
+
+	newAttri := Attri{Title: "new title", Author: "who"}
+	tt.True(t, ranker.UpdateAttri("1", newAttri))
+	tt.False(t, ranker.UpdateAttri("3", newAttri))
+
+	criteria := DummyScoringCriteria{}
+	scoredDocs, _ := ranker.Rank([]types.IndexedDoc{
+		{DocId: "1"},
+		{DocId: "2"},
+		{DocId: "3"},
+	}, types.RankOpts{ScoringCriteria: criteria}, false)
+	docs := scoredDocs.(types.ScoredDocs)
+	tt.Expect(t, "[2 [5000 ]] [1 [3000 ]] ", scoredDocsToString(docs))
+	tt.Equal(t, newAttri, docs[1].Attri)
+
+	fields, content, docAttri, ok := ranker.GetDoc("2")
+	tt.True(t, ok)
+	tt.Equal(t, DummyScoringFields{counter: 5}, fields)
+	tt.Equal(t, "content", content)
+	tt.Equal(t, attri, docAttri)
+
+	_, _, _, ok = ranker.GetDoc("3")
+	tt.False(t, ok)
+}
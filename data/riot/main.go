@@ -62,6 +62,13 @@ func main() {
 
 	http.HandleFunc("/search", rhttp.Search)
 	http.HandleFunc("/dist", rhttp.WgDist)
+	http.HandleFunc("/index_batch", rhttp.AddIndexBatch)
+	http.HandleFunc("/doc", rhttp.GetDoc)
+	http.HandleFunc("/mget", rhttp.MultiGet)
+	http.HandleFunc("/metrics", rhttp.Metrics)
+	http.HandleFunc("/changes", rhttp.Changes)
+	http.HandleFunc("/dict", rhttp.Dict)
+	http.HandleFunc("/indexes", rhttp.Indexes)
 	log.Println("listen and serve on 8080...")
 	log.Fatal(http.ListenAndServe(":8080", nil))
 }
@@ -0,0 +1,412 @@
+// Copyright 2016 ego authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package riot
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-ego/gse"
+	"github.com/go-ego/riot/types"
+)
+
+var (
+	// ErrNoSegmenter is returned when changing the dictionary
+	// of the engine which does not use the gse segmenter
+	ErrNoSegmenter = errors.New("the engine does not use the gse segmenter")
+
+	// ErrInvalidWord the word is empty or contains the spaces
+	ErrInvalidWord = errors.New("the word is empty or contains spaces")
+)
+
+const (
+	// UserDictFile the file in the StoreFolder which persists
+	// the words added and removed at runtime
+	UserDictFile = "user_dict.json"
+
+	// 没有指定词频时使用的词频
+	defaultWordFreq = 1000
+)
+
+// userDict 运行时加入和删除的词，lock 同时保护分词器的词典，
+// 共享分词器的引擎也共享同一个 userDict
+type userDict struct {
+	lock sync.RWMutex
+	// 同时只有一个修改，只有持有 update 时才修改 words 和 removed
+	update sync.Mutex
+	// 持久化的文件，为空时不持久化
+	path string
+	// 载入分词器的 GseDict，为空表示分词器来自 WithGse
+	source string
+	// 是否已经从文件中恢复
+	loaded  bool
+	words   map[string]types.UserWord
+	removed map[string]bool
+}
+
+func newUserDict(path string) *userDict {
+	return &userDict{
+		path:    path,
+		words:   make(map[string]types.UserWord),
+		removed: make(map[string]bool),
+	}
+}
+
+// userDictFile 持久化的内容
+type userDictFile struct {
+	Words   []types.UserWord `json:"words"`
+	Removed []string         `json:"removed,omitempty"`
+}
+
+func validWord(text string) bool {
+	return text != "" && !strings.ContainsAny(text, " \t\r\n")
+}
+
+// dictPath 不共享分词器时持久化的文件，不使用持久化存储时返回空字符串
+func (engine *Engine) dictPath() string {
+	if !engine.initOptions.UseStore {
+		return ""
+	}
+
+	return filepath.Join(engine.initOptions.StoreFolder, UserDictFile)
+}
+
+// read 读取持久化的文件
+func (d *userDict) read() {
+	if d.path == "" {
+		return
+	}
+
+	data, err := ioutil.ReadFile(d.path)
+	if os.IsNotExist(err) {
+		return
+	}
+	if err != nil {
+		log.Fatalf("Can not read the user dictionary %s: %v", d.path, err)
+	}
+
+	var file userDictFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		log.Fatalf("Can not parse the user dictionary %s: %v", d.path, err)
+	}
+
+	for _, text := range file.Removed {
+		d.removed[text] = true
+	}
+	for _, word := range file.Words {
+		d.words[word.Text] = word
+	}
+}
+
+// build 从词典文件重新载入分词器并加入运行时的词，不需要加锁
+func (d *userDict) build(words map[string]types.UserWord,
+	removed map[string]bool) (gse.Segmenter, error) {
+	var seg gse.Segmenter
+	err := seg.LoadDict(d.source)
+	editDict(&seg, words, removed)
+
+	return seg, err
+}
+
+// editDict 在分词器的词典上删除和加入词。已经存在的词先删除再加入，
+// 旧的词仍然计入总词频，所以只用于刚载入的词典或者 WithGse 的分词器
+func editDict(seg *gse.Segmenter, words map[string]types.UserWord,
+	removed map[string]bool) {
+	for text := range removed {
+		seg.RemoveToken(text)
+	}
+	for _, word := range words {
+		// 已经存在的词不会被更新，先删除
+		seg.RemoveToken(word.Text)
+		seg.AddToken(word.Text, word.Freq, word.Pos)
+	}
+	if len(words) > 0 {
+		seg.CalcToken()
+	}
+}
+
+// loadDict 载入分词器的词典并恢复运行时加入和删除的词，调用前需要加锁；
+// 共享的 userDict 只恢复一次
+func (engine *Engine) loadDict(source string) {
+	d := engine.dict
+	if !d.loaded {
+		d.read()
+	}
+
+	if !engine.loaded {
+		d.source = source
+		// 和之前一样，载入失败时使用已经载入的部分
+		engine.segmenter, _ = d.build(d.words, d.removed)
+		engine.loaded = true
+	} else if !d.loaded {
+		// WithGse 的分词器没有词典文件，在原来的词典上修改
+		editDict(&engine.segmenter, d.words, d.removed)
+	}
+	d.loaded = true
+}
+
+// saveUserDict 写入持久化的文件，调用前需要加锁
+func (engine *Engine) saveUserDict() error {
+	d := engine.dict
+	path := d.path
+	if path == "" {
+		return nil
+	}
+
+	file := userDictFile{Words: make([]types.UserWord, 0, len(d.words))}
+	for _, word := range d.words {
+		file.Words = append(file.Words, word)
+	}
+	for text := range d.removed {
+		file.Removed = append(file.Removed, text)
+	}
+	sort.Slice(file.Words, func(i, j int) bool {
+		return file.Words[i].Text < file.Words[j].Text
+	})
+	sort.Strings(file.Removed)
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// 先写入临时文件再替换，避免写入中断时文件损坏
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// AddWords add the words to the gse dictionary or update the frequency
+// and the part of speech of the words, the words are persisted in the
+// StoreFolder if the engine uses the store; the words which are not
+// changed are skipped.
+//
+// The dictionary is loaded again from the GseDict files with all the
+// words added at runtime and then swapped in, AddWords blocks until
+// then (seconds for the default dictionary) while the searches and the
+// indexing keep using the old dictionary, so add the words in batches.
+// The dictionary of a segmenter set by WithGse is changed in place,
+// the segmentation is blocked while its path values are recalculated.
+//
+// The documents indexed before are not changed, use ReanalyzeDocs to
+// segment the documents containing the words again.
+func (engine *Engine) AddWords(words ...types.UserWord) error {
+	if err := engine.begin(); err != nil {
+		return err
+	}
+	defer engine.end()
+
+	if engine.initOptions.NotUseGse {
+		return ErrNoSegmenter
+	}
+	for _, word := range words {
+		if !validWord(word.Text) {
+			return ErrInvalidWord
+		}
+	}
+
+	d := engine.dict
+	d.update.Lock()
+	defer d.update.Unlock()
+
+	added := make(map[string]types.UserWord)
+	for _, word := range words {
+		if word.Freq <= 0 {
+			word.Freq = defaultWordFreq
+		}
+		if old, ok := d.words[word.Text]; ok && old == word {
+			continue
+		}
+		added[word.Text] = word
+	}
+	if len(added) == 0 {
+		return nil
+	}
+
+	all := make(map[string]types.UserWord, len(d.words)+len(added))
+	for text, word := range d.words {
+		all[text] = word
+	}
+	removed := make(map[string]bool, len(d.removed))
+	for text := range d.removed {
+		removed[text] = true
+	}
+	for text, word := range added {
+		all[text] = word
+		delete(removed, text)
+	}
+
+	if d.source == "" {
+		d.lock.Lock()
+		defer d.lock.Unlock()
+
+		editDict(&engine.segmenter, added, nil)
+		d.words, d.removed = all, removed
+		return engine.saveUserDict()
+	}
+
+	// 不持有锁载入新的词典，期间分词继续使用旧的词典
+	seg, err := d.build(all, removed)
+	if err != nil {
+		return err
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	// 共享分词器的引擎使用同一个 Dictionary
+	*engine.segmenter.Dictionary() = *seg.Dictionary()
+	d.words, d.removed = all, removed
+	return engine.saveUserDict()
+}
+
+// RemoveWords remove the words from the gse dictionary, including
+// the words of the GseDict files
+func (engine *Engine) RemoveWords(texts ...string) error {
+	if err := engine.begin(); err != nil {
+		return err
+	}
+	defer engine.end()
+
+	if engine.initOptions.NotUseGse {
+		return ErrNoSegmenter
+	}
+	for _, text := range texts {
+		if !validWord(text) {
+			return ErrInvalidWord
+		}
+	}
+
+	d := engine.dict
+	d.update.Lock()
+	defer d.update.Unlock()
+
+	var changed []string
+	for _, text := range texts {
+		if _, ok := d.words[text]; ok || !d.removed[text] {
+			changed = append(changed, text)
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	// 只从前缀树中删除，不需要重新载入词典
+	for _, text := range changed {
+		engine.segmenter.RemoveToken(text)
+		delete(d.words, text)
+		d.removed[text] = true
+	}
+
+	return engine.saveUserDict()
+}
+
+// UserWords get the words added at runtime, sorted by the text
+func (engine *Engine) UserWords() []types.UserWord {
+	d := engine.dict
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	words := make([]types.UserWord, 0, len(d.words))
+	for _, word := range d.words {
+		words = append(words, word)
+	}
+	sort.Slice(words, func(i, j int) bool {
+		return words[i].Text < words[j].Text
+	})
+
+	return words
+}
+
+// ReanalyzeDocs index the stored documents whose content contains
+// any of the texts again in a background task, so that they are
+// segmented with the current dictionary; the engine must use the store.
+// The documents keep their versions, with Versioning the ones changed
+// meanwhile are skipped; the subscribers receive a ChangeUpdate with
+// the same version
+// 重新分词包含这些字符串的文档，比如在 AddWords 和 RemoveWords 之后
+func (engine *Engine) ReanalyzeDocs(texts []string,
+	opts ...TaskOpts) (*Task, error) {
+	if err := engine.begin(); err != nil {
+		return nil, err
+	}
+	defer engine.end()
+
+	if !engine.initOptions.UseStore {
+		return nil, ErrNoStore
+	}
+
+	// 和分词前一样转换文本，gse 的词典不区分大小写
+	lower := make([]string, 0, len(texts))
+	for _, text := range texts {
+		if text != "" {
+			lower = append(lower, strings.ToLower(filterText(text, engine.charFilters)))
+		}
+	}
+
+	var ids []string
+	for _, db := range engine.dbs {
+		db.ForEach(func(k, v []byte) error {
+			if isTombstone(k) {
+				return nil
+			}
+
+			var data types.DocData
+			if gob.NewDecoder(bytes.NewReader(v)).Decode(&data) != nil {
+				return nil
+			}
+
+			content := strings.ToLower(filterText(data.Content, engine.charFilters))
+			for _, text := range lower {
+				if strings.Contains(content, text) {
+					ids = append(ids, string(k))
+					break
+				}
+			}
+			return nil
+		})
+	}
+
+	return engine.startTask(TaskReanalyze, ids, engine.reanalyzeDoc, opts...), nil
+}
+
+// reanalyzeDoc 使用存储的版本重新加入索引，不改变文档的版本
+func (engine *Engine) reanalyzeDoc(docId string) error {
+	data, err := engine.GetDoc(docId)
+	if err != nil {
+		return err
+	}
+
+	if err := engine.begin(); err != nil {
+		return err
+	}
+	defer engine.end()
+
+	return engine.index(docId, data, false, true)
+}
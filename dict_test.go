@@ -0,0 +1,105 @@
+package riot
+
+import (
+	"os"
+	"testing"
+
+	"github.com/go-ego/riot/types"
+	"github.com/vcaesar/tt"
+)
+
+func dictOpts() types.EngineOpts {
+	return types.EngineOpts{
+		Using:       1,
+		GseDict:     "./testdata/test_dict.txt",
+		IndexerOpts: inxOpts,
+		UseStore:    true,
+		StoreFolder: "riot.dict",
+	}
+}
+
+func TestUserWords(t *testing.T) {
+	os.RemoveAll("riot.dict")
+	defer os.RemoveAll("riot.dict")
+
+	var engine Engine
+	engine.Init(dictOpts())
+	engine.Index("1", types.DocData{Content: "世界有七十亿人口"})
+	engine.Index("2", types.DocData{Content: "人口"})
+	engine.Flush()
+
+	tt.Equal(t, []string{"世界", "有", "七十亿", "人口"}, engine.Segment("世界有七十亿人口"))
+	tt.Equal(t, ErrInvalidWord, engine.AddWords(types.UserWord{Text: "a b"}))
+
+	word := types.UserWord{Text: "七十亿人口", Freq: 100, Pos: "n"}
+	tt.Nil(t, engine.AddWords(word))
+	tt.Equal(t, []string{"世界", "有", "七十亿人口"}, engine.Segment("世界有七十亿人口"))
+	tt.Equal(t, []types.UserWord{word}, engine.UserWords())
+
+	// 重新载入词典，修改词频不会累加总词频，没有改变的词被跳过
+	dict := engine.segmenter.Dictionary()
+	total, num := dict.TotalFreq(), dict.NumTokens()
+	tt.Nil(t, engine.AddWords(types.UserWord{Text: "七十亿人口", Freq: 200, Pos: "n"}))
+	tt.Nil(t, engine.AddWords(word, word))
+	tt.Equal(t, total, dict.TotalFreq())
+	tt.Equal(t, num, dict.NumTokens())
+	tt.Equal(t, 0, len(searchIds(&engine, "七十亿人口")))
+
+	task, err := engine.ReanalyzeDocs([]string{"七十亿人口"})
+	tt.Nil(t, err)
+	status := task.Wait()
+	tt.Equal(t, TaskReanalyze, status.Kind)
+	tt.Equal(t, 1, status.Done)
+	tt.Equal(t, []string{"1"}, searchIds(&engine, "七十亿人口"))
+
+	tt.Nil(t, engine.RemoveWords("世界"))
+	engine.Close()
+
+	// 重新打开时恢复加入和删除的词
+	var engine1 Engine
+	engine1.Init(dictOpts())
+	defer engine1.Close()
+	engine1.Flush()
+
+	tt.Equal(t, 1, len(engine1.UserWords()))
+	tt.Equal(t, []string{"世", "界", "七十亿人口"}, engine1.Segment("世界七十亿人口"))
+	tt.Equal(t, []string{"1"}, searchIds(&engine1, "七十亿人口"))
+}
+
+func TestReanalyzeVersion(t *testing.T) {
+	os.RemoveAll("riot.dict")
+	defer os.RemoveAll("riot.dict")
+
+	opts := dictOpts()
+	opts.Versioning = true
+
+	var engine Engine
+	engine.Init(opts)
+	defer engine.Close()
+
+	tt.Nil(t, engine.Index("1", types.DocData{Content: "世界有七十亿人口", Version: 3}))
+	engine.Flush()
+	tt.Nil(t, engine.AddWords(types.UserWord{Text: "七十亿人口"}))
+
+	task, err := engine.ReanalyzeDocs([]string{"七十亿人口"})
+	tt.Nil(t, err)
+	tt.Equal(t, 0, task.Wait().Failed)
+	engine.Flush()
+
+	// 重新分词不改变版本
+	version, _ := engine.DocVersion("1")
+	tt.Equal(t, uint64(3), version)
+	tt.Equal(t, []string{"1"}, searchIds(&engine, "七十亿人口"))
+	tt.Equal(t, ErrVersionConflict,
+		engine.Index("1", types.DocData{Content: "人口", Version: 3}))
+}
+
+func TestNoSegmenterWords(t *testing.T) {
+	var engine Engine
+	engine.Init(types.EngineOpts{NotUseGse: true, IndexerOpts: inxOpts})
+	defer engine.Close()
+
+	tt.Equal(t, ErrNoSegmenter, engine.AddWords(types.UserWord{Text: "riot"}))
+	_, err := engine.ReanalyzeDocs([]string{"riot"})
+	tt.Equal(t, ErrNoStore, err)
+}
@@ -20,13 +20,13 @@ Package riot is riot engine
 package riot
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"runtime"
 	"sort"
 	"strconv"
-	"strings"
 	"sync"
 	"time"
 
@@ -55,6 +55,14 @@ const (
 	DefaultPath = "./riot-index"
 )
 
+var (
+	// ErrEngineClosed is returned when calling a closed engine
+	ErrEngineClosed = errors.New("the engine is closed")
+	// ErrInvalidDocId is returned when indexing a document with
+	// the docId "0", which is used to force update the index
+	ErrInvalidDocId = errors.New("invalid docId")
+)
+
 // GetVersion get the riot version
 func GetVersion() string {
 	return Version
@@ -79,6 +87,11 @@ type Engine struct {
 	initOptions types.EngineOpts
 	initialized bool
 
+	// 引擎关闭后拒绝新的请求，并通知所有工作协程退出
+	closed    bool
+	closeChan chan bool
+	wg        sync.WaitGroup
+
 	indexers   []core.Indexer
 	rankers    []core.Ranker
 	segmenter  gse.Segmenter
@@ -86,6 +99,41 @@ type Engine struct {
 	stopTokens StopTokens
 	dbs        []store.Store
 
+	// 文档的当前版本和墓碑
+	versions versions
+	// 自定义路由键的文档
+	routes routes
+	// LazyContent 时文档内容的缓存
+	contents *contentCache
+	// DeleteByQuery 和 UpdateByQuery 的后台任务
+	tasks tasks
+	// 设置了过期时间的文档
+	expiry expiry
+	// 延迟等运行指标
+	metrics metrics
+	// 慢查询日志
+	slowLog slowLog
+	// 文档变更事件
+	changes changeLog
+	// 重建索引期间改变的文档，见 Manager.Reindex
+	dirty dirtyDocs
+	// 注册的分析器，以及索引、查询和 Segment 使用的分析器
+	analyzers       analyzers
+	analyzer        *Analyzer
+	searchAnalyzer  *Analyzer
+	segmentAnalyzer *Analyzer
+	// EngineOpts.CharFilters 和 EngineOpts.TokenFilters
+	charFilters  []CharFilter
+	tokenFilters []TokenFilter
+	// EngineOpts.SubFields
+	subFields map[string]subField
+	// 运行时修改的词典
+	dict *userDict
+	// 每个 shard 的查询结果缓存，nil 表示不缓存
+	queryCaches []*queryCache
+	// 注册的查询
+	percolator percolator
+
 	// 建立索引器使用的通信通道
 	segmenterChan         chan segmenterReq
 	indexerAddDocChans    []chan indexerAddDocReq
@@ -93,9 +141,8 @@ type Engine struct {
 	rankerAddDocChans     []chan rankerAddDocReq
 
 	// 建立排序器使用的通信通道
-	indexerLookupChans   []chan indexerLookupReq
-	rankerRankChans      []chan rankerRankReq
-	rankerRemoveDocChans []chan rankerRemoveDocReq
+	indexerLookupChans []chan indexerLookupReq
+	rankerRankChans    []chan rankerRankReq
 
 	// 建立持久存储使用的通信通道
 	storeIndexDocChans []chan storeIndexDocReq
@@ -133,18 +180,12 @@ func (engine *Engine) Ranker(options types.EngineOpts) {
 	engine.rankerRankChans = make(
 		[]chan rankerRankReq, options.NumShards)
 
-	engine.rankerRemoveDocChans = make(
-		[]chan rankerRemoveDocReq, options.NumShards)
-
 	for shard := 0; shard < options.NumShards; shard++ {
 		engine.rankerAddDocChans[shard] = make(
 			chan rankerAddDocReq, options.RankerBufLen)
 
 		engine.rankerRankChans[shard] = make(
 			chan rankerRankReq, options.RankerBufLen)
-
-		engine.rankerRemoveDocChans[shard] = make(
-			chan rankerRemoveDocReq, options.RankerBufLen)
 	}
 }
 
@@ -190,6 +231,20 @@ func (engine *Engine) Store() {
 			engine.initOptions.StoreFolder, err)
 	}
 
+	// 完成或者回滚中断的 ReshardStore
+	if err := RecoverReshard(engine.initOptions.StoreFolder); err != nil {
+		log.Fatalf("Can not recover the resharding of %s: %v",
+			engine.initOptions.StoreFolder, err)
+	}
+
+	// 存储的 shard 数目改变后文档无法正确路由，需要先重新分片
+	num := StoreShardsOf(engine.initOptions.StoreFolder)
+	if num > 0 && num != engine.initOptions.StoreShards {
+		log.Fatalf("The store %s has %d shards but StoreShards is %d, "+
+			"use ReshardStore or Reshard to migrate.",
+			engine.initOptions.StoreFolder, num, engine.initOptions.StoreShards)
+	}
+
 	// 打开或者创建数据库
 	engine.dbs = make([]store.Store, engine.initOptions.StoreShards)
 	for shard := 0; shard < engine.initOptions.StoreShards; shard++ {
@@ -238,6 +293,7 @@ func (engine *Engine) Store() {
 		engine.dbs[shard] = db
 	}
 
+	engine.wg.Add(engine.initOptions.StoreShards)
 	for shard := 0; shard < engine.initOptions.StoreShards; shard++ {
 		go engine.storeIndexDoc(shard)
 	}
@@ -286,27 +342,66 @@ func (engine *Engine) Init(options types.EngineOpts) {
 	options = engine.initDef(options)
 
 	options.Init()
+	if options.LazyContent && !options.UseStore {
+		log.Fatal("LazyContent needs the persistent store, set UseStore.")
+	}
 	engine.initOptions = options
 	engine.initialized = true
 
+	if engine.dict == nil {
+		engine.dict = newUserDict(engine.dictPath())
+	}
 	if !options.NotUseGse {
-		if !engine.loaded {
-			// 载入分词器词典
-			engine.segmenter.LoadDict(options.GseDict)
-			engine.loaded = true
-		}
+		// 载入分词器词典和运行时加入和删除的词，分词器可能被其他引擎共享
+		engine.dict.lock.Lock()
+		engine.loadDict(options.GseDict)
+		engine.dict.lock.Unlock()
 
 		// 初始化停用词
 		engine.stopTokens.Init(options.StopTokenFile)
 	}
+	engine.initAnalyzers(options)
+
+	engine.openSlowLog(options)
+	engine.openChangeLog(options)
+	engine.start(options)
+}
 
+// start 初始化索引器、排序器和通道，启动工作协程并从持久化存储恢复
+func (engine *Engine) start(options types.EngineOpts) {
 	// 初始化索引器和排序器
 	for shard := 0; shard < options.NumShards; shard++ {
 		engine.indexers = append(engine.indexers, core.Indexer{})
 		engine.indexers[shard].Init(*options.IndexerOpts)
 
 		engine.rankers = append(engine.rankers, core.Ranker{})
-		engine.rankers[shard].Init(options.IDOnly)
+		if options.LazyContent && !options.IDOnly {
+			engine.rankers[shard].InitLazy()
+		} else {
+			engine.rankers[shard].Init(options.IDOnly)
+		}
+	}
+
+	// 初始化关闭通道
+	engine.closeChan = make(chan bool)
+
+	// 初始化文档版本
+	engine.versions.docs = make(map[string]docVersion)
+	engine.routes.docs = make(map[string]string)
+	engine.expiry.reset()
+
+	// 初始化查询缓存
+	engine.queryCaches = nil
+	if options.QueryCacheSize > 0 {
+		for shard := 0; shard < options.NumShards; shard++ {
+			engine.queryCaches = append(engine.queryCaches,
+				newQueryCache(options.QueryCacheSize))
+		}
+	}
+
+	// 初始化文档内容缓存
+	if options.LazyContent {
+		engine.contents = newContentCache(options.ContentCacheSize)
 	}
 
 	// 初始化分词器通道
@@ -328,16 +423,18 @@ func (engine *Engine) Init(options types.EngineOpts) {
 	}
 
 	// 启动分词器
+	engine.wg.Add(options.NumGseThreads)
 	for iThread := 0; iThread < options.NumGseThreads; iThread++ {
 		go engine.segmenterWorker()
 	}
 
 	// 启动索引器和排序器
+	engine.wg.Add(options.NumShards *
+		(3 + options.NumIndexerThreads + options.NumRankerThreads))
 	for shard := 0; shard < options.NumShards; shard++ {
 		go engine.indexerAddDoc(shard)
 		go engine.indexerRemoveDoc(shard)
 		go engine.rankerAddDoc(shard)
-		go engine.rankerRemoveDoc(shard)
 
 		for i := 0; i < options.NumIndexerThreads; i++ {
 			go engine.indexerLookup(shard)
@@ -352,6 +449,9 @@ func (engine *Engine) Init(options types.EngineOpts) {
 		engine.Store()
 	}
 
+	// 启动过期文档的清理协程
+	go engine.sweeper(options.SweepInterval, engine.closeChan)
+
 	atomic.AddUint64(&engine.numDocsStored, engine.numIndexingReqs)
 }
 
@@ -368,25 +468,53 @@ func (engine *Engine) Init(options types.EngineOpts) {
 //      2. 这个函数调用是非同步的，也就是说在函数返回时有可能文档还没有加入索引中，因此
 //         如果立刻调用Search可能无法查询到这个文档。强制刷新索引请调用FlushIndex函数。
 func (engine *Engine) IndexDoc(docId string, data types.DocData,
-	forceUpdate ...bool) {
-	engine.Index(docId, data, forceUpdate...)
+	forceUpdate ...bool) error {
+	return engine.Index(docId, data, forceUpdate...)
 }
 
-// Index add the document to the index
+// Index add the document to the index,
+// return ErrEngineClosed if the engine has been closed,
+// or ErrVersionConflict if the version of the document is stale
 func (engine *Engine) Index(docId string, data types.DocData,
-	forceUpdate ...bool) {
+	forceUpdate ...bool) error {
+	if err := engine.begin(); err != nil {
+		return err
+	}
+	defer engine.end()
 
 	var force bool
 	if len(forceUpdate) > 0 {
 		force = forceUpdate[0]
 	}
 
+	return engine.index(docId, data, force, false)
+}
+
+// index 加入文档，replace 为 true 时不检查版本冲突，调用者需调用 begin
+func (engine *Engine) index(docId string, data types.DocData,
+	force, replace bool) error {
+	if docId != "0" {
+		engine.withTTL(&data)
+	}
+
+	if engine.initOptions.Versioning && docId != "0" {
+		if replace {
+			data.Version = engine.replaceVersion(docId, data.Version)
+		} else {
+			version, err := engine.acceptVersion(docId, data.Version, false)
+			if err != nil {
+				return err
+			}
+			data.Version = version
+		}
+	}
+
 	// if engine.HasDoc(docId) {
 	// 	engine.RemoveDoc(docId)
 	// }
 
 	// data.Tokens
-	engine.internalIndexDoc(docId, data, force)
+	engine.internalIndexDoc(docId, data, force, false)
 
 	hash := murmur.Sum32(docId) % uint32(engine.initOptions.StoreShards)
 
@@ -394,25 +522,42 @@ func (engine *Engine) Index(docId string, data types.DocData,
 		engine.storeIndexDocChans[hash] <- storeIndexDocReq{
 			docId: docId, data: data}
 	}
+
+	// 在发送到存储协程之后记录，见 storeBarrier
+	if docId != "0" {
+		engine.track(docId, false)
+	}
+
+	return nil
 }
 
+// internalIndexDoc 发送到分词协程，recovered 表示从持久化存储恢复的文档，
+// 不产生变更事件
 func (engine *Engine) internalIndexDoc(docId string, data types.DocData,
-	forceUpdate bool) {
+	forceUpdate, recovered bool) {
 
 	if !engine.initialized {
 		log.Fatal("The engine must be initialized first.")
 	}
 
+	var shard int
 	if docId != "0" {
 		atomic.AddUint64(&engine.numIndexingReqs, 1)
+
+		// 路由键改变时从之前的 shard 中删除
+		var old int
+		shard, old = engine.route(docId, data.Routing)
+		if old != shard {
+			engine.moveDoc(docId, old)
+		}
+		engine.setExpiry(docId, data.ExpireAt, data.Version)
 	}
 	if forceUpdate {
 		atomic.AddUint64(&engine.numForceUpdatingReqs, 1)
 	}
 
-	hash := murmur.Sum32(fmt.Sprintf("%s%s", docId, data.Content))
-	engine.segmenterChan <- segmenterReq{
-		docId: docId, hash: hash, data: data, forceUpdate: forceUpdate}
+	engine.segmenterChan <- segmenterReq{docId: docId, shard: shard,
+		data: data, forceUpdate: forceUpdate, recovered: recovered}
 }
 
 // RemoveDoc remove the document from the index
@@ -426,7 +571,15 @@ func (engine *Engine) internalIndexDoc(docId string, data types.DocData,
 //      1. 这个函数是线程安全的，请尽可能并发调用以提高索引速度
 //      2. 这个函数调用是非同步的，也就是说在函数返回时有可能文档还没有加入索引中，因此
 //         如果立刻调用 Search 可能无法查询到这个文档。强制刷新索引请调用 FlushIndex 函数。
-func (engine *Engine) RemoveDoc(docId string, forceUpdate ...bool) {
+func (engine *Engine) RemoveDoc(docId string, forceUpdate ...bool) error {
+	return engine.RemoveDocVersion(docId, 0, forceUpdate...)
+}
+
+// RemoveDocVersion remove the document and leave a tombstone with
+// the version, see DocData.Version
+// 启用 Versioning 时，version 不大于当前版本返回 ErrVersionConflict
+func (engine *Engine) RemoveDocVersion(docId string, version uint64,
+	forceUpdate ...bool) error {
 	var force bool
 	if len(forceUpdate) > 0 {
 		force = forceUpdate[0]
@@ -436,29 +589,92 @@ func (engine *Engine) RemoveDoc(docId string, forceUpdate ...bool) {
 		log.Fatal("The engine must be initialized first.")
 	}
 
-	if docId != "0" {
-		atomic.AddUint64(&engine.numRemovingReqs, 1)
+	if err := engine.begin(); err != nil {
+		return err
+	}
+	defer engine.end()
+
+	if engine.initOptions.Versioning && docId != "0" {
+		v, err := engine.acceptVersion(docId, version, true)
+		if err != nil {
+			return err
+		}
+		version = v
 	}
 
+	engine.removeDoc(docId, version, force, nil)
+	return nil
+}
+
+// removeDoc 删除已经记录了版本的文档，调用者需调用 begin；
+// expired 不为 nil 时只在文档的过期时间仍然是 expired 时删除
+func (engine *Engine) removeDoc(docId string, version uint64,
+	force bool, expired *expireItem) {
 	if force {
 		atomic.AddUint64(&engine.numForceUpdatingReqs, 1)
 	}
 
-	for shard := 0; shard < engine.initOptions.NumShards; shard++ {
-		engine.indexerRemoveDocChans[shard] <- indexerRemoveDocReq{
-			docId: docId, forceUpdate: force}
-
+	tomb := docVersion{version: version, deleted: true}
+	stale := false
+	removed := engine.ifCurrent(docId, tomb, func() {
 		if docId == "0" {
-			continue
+			engine.forceShards(-1, force)
+			return
 		}
-		engine.rankerRemoveDocChans[shard] <- rankerRemoveDocReq{docId: docId}
+
+		// 加入删除队列之后才清除过期时间，期间重新加入的文档
+		// 在设置过期时间之后才进入队列，排在删除之后
+		engine.expiry.Lock()
+		if expired != nil {
+			item, ok := engine.expiry.docs[docId]
+			if !ok || item.expireAt != expired.expireAt {
+				engine.expiry.Unlock()
+				stale = true
+				return
+			}
+		}
+
+		// 只从文档所在的 shard 中删除
+		shard := engine.unroute(docId)
+		engine.queueRemove(shard, docId, force)
+		engine.expiry.remove(docId)
+		engine.expiry.Unlock()
+		engine.removed(docId, version)
+
+		engine.forceShards(shard, force)
+	})
+
+	if !removed || stale {
+		// 已经有更新的版本，只需要强制刷新
+		engine.forceShards(-1, force)
+		return
+	}
+
+	if docId != "0" {
+		engine.track(docId, true)
 	}
 
 	if engine.initOptions.UseStore && docId != "0" {
 		// 从数据库中删除
 		hash := murmur.Sum32(docId) % uint32(engine.initOptions.StoreShards)
 
-		go engine.storeRemoveDoc(docId, hash)
+		engine.wg.Add(1)
+		go engine.storeRemoveDoc(docId, hash, version)
+	}
+}
+
+// forceShards 强制刷新除 skip 以外的全部 shard 的删除 cache
+func (engine *Engine) forceShards(skip int, force bool) {
+	if !force {
+		return
+	}
+
+	for shard := 0; shard < engine.initOptions.NumShards; shard++ {
+		if shard == skip {
+			continue
+		}
+		engine.indexerRemoveDocChans[shard] <- indexerRemoveDocReq{
+			docId: "0", forceUpdate: true}
 	}
 }
 
@@ -475,22 +691,13 @@ func (engine *Engine) RemoveDoc(docId string, forceUpdate ...bool) {
 // }
 
 // Segment get the word segmentation result of the text
-// 获取文本的分词结果, 只分词与过滤弃用词
+// 获取文本的分词结果，使用 gse 的 Cut 或者 CutSearch 和 Hmm，
+// 只分词与过滤弃用词；和索引使用相同的字符过滤器和关键词过滤器，
+// 查询的分词见 Tokens
 func (engine *Engine) Segment(content string) (keywords []string) {
-
-	var segments []string
-	hmm := engine.initOptions.Hmm
-
-	if engine.initOptions.GseMode {
-		segments = engine.segmenter.CutSearch(content, hmm)
-	} else {
-		segments = engine.segmenter.Cut(content, hmm)
-	}
-
-	for _, token := range segments {
-		if !engine.stopTokens.IsStopToken(token) {
-			keywords = append(keywords, token)
-		}
+	tokens, _ := engine.segmentAnalyzer.Analyze(content)
+	for _, token := range tokens {
+		keywords = append(keywords, token.Text)
 	}
 
 	return
@@ -501,13 +708,14 @@ func (engine *Engine) Tokens(request types.SearchReq) (tokens []string) {
 	// 收集关键词
 	// tokens := []string{}
 	if request.Text != "" {
-		reqText := strings.ToLower(request.Text)
-		if engine.initOptions.NotUseGse {
-			tokens = strings.Split(reqText, " ")
+		if request.SubField != "" {
+			tokens = engine.subFieldTokens(request.SubField, request.Text)
 		} else {
-			// querySegments := engine.segmenter.Segment([]byte(reqText))
-			// tokens = engine.Tokens([]byte(reqText))
-			tokens = engine.Segment(reqText)
+			// 使用查询的分析器
+			analyzed, _ := engine.searchAnalyzer.Analyze(request.Text)
+			for _, token := range analyzed {
+				tokens = append(tokens, token.Text)
+			}
 		}
 
 		// 叠加 tokens
@@ -727,6 +935,11 @@ func (engine *Engine) Ranks(request types.SearchReq, rankOpts types.RankOpts,
 
 			output.Docs = rankOutput[start:end]
 		}
+
+		if engine.initOptions.LazyContent {
+			// 只读取需要返回的文档的内容
+			engine.loadContents(output.Docs.(types.ScoredDocs))
+		}
 	}
 
 	output.NumDocs = numDocs
@@ -739,9 +952,10 @@ func (engine *Engine) Ranks(request types.SearchReq, rankOpts types.RankOpts,
 // This function is thread safe, return not IDonly
 func (engine *Engine) SearchDoc(request types.SearchReq) (output types.SearchDoc) {
 	resp := engine.Search(request)
+	docs, _ := resp.Docs.(types.ScoredDocs)
 	return types.SearchDoc{
 		BaseResp: resp.BaseResp,
-		Docs:     resp.Docs.(types.ScoredDocs),
+		Docs:     docs,
 	}
 }
 
@@ -750,9 +964,10 @@ func (engine *Engine) SearchDoc(request types.SearchReq) (output types.SearchDoc
 func (engine *Engine) SearchID(request types.SearchReq) (output types.SearchID) {
 	// return types.SearchID(engine.Search(request))
 	resp := engine.Search(request)
+	docs, _ := resp.Docs.(types.ScoredIDs)
 	return types.SearchID{
 		BaseResp: resp.BaseResp,
-		Docs:     resp.Docs.(types.ScoredIDs),
+		Docs:     docs,
 	}
 }
 
@@ -764,6 +979,15 @@ func (engine *Engine) Search(request types.SearchReq) (output types.SearchResp)
 		log.Fatal("The engine must be initialized first.")
 	}
 
+	if err := engine.begin(); err != nil {
+		output.Err = err
+		return
+	}
+	defer engine.end()
+
+	start := time.Now()
+	defer engine.searched(start, &output)
+
 	tokens := engine.Tokens(request)
 
 	var rankOpts types.RankOpts
@@ -781,16 +1005,26 @@ func (engine *Engine) Search(request types.SearchReq) (output types.SearchResp)
 	rankerReturnChan := make(
 		chan rankerReturnReq, engine.initOptions.NumShards)
 
+	// 需要返回耗时或记录慢查询时收集各 shard 的耗时
+	var prof *profiler
+	if request.Profile || engine.initOptions.SlowQuery > 0 {
+		prof = &profiler{}
+	}
+	defer engine.profiled(request, start, prof, &output)
+
 	// 生成查找请求
 	lookupRequest := indexerLookupReq{
 		countDocsOnly:    request.CountDocsOnly,
 		tokens:           tokens,
 		labels:           request.Labels,
 		docIds:           request.DocIds,
+		filters:          request.Filters,
 		options:          rankOpts,
 		rankerReturnChan: rankerReturnChan,
 		orderless:        request.Orderless,
 		logic:            request.Logic,
+		profiler:         prof,
+		cache:            cacheRef{key: engine.queryKey(request, tokens, rankOpts)},
 	}
 
 	// 向索引器发送查找请求
@@ -810,14 +1044,24 @@ func (engine *Engine) Search(request types.SearchReq) (output types.SearchResp)
 // Flush block wait until all indexes are added
 // 阻塞等待直到所有索引添加完毕
 func (engine *Engine) Flush() {
+	if engine.begin() != nil {
+		return
+	}
+	defer engine.end()
+
+	engine.flush()
+}
+
+func (engine *Engine) flush() {
 	for {
 		runtime.Gosched()
 
-		inxd := engine.numIndexingReqs == atomic.LoadUint64(&engine.numDocsIndexed)
-		numRm := engine.numRemovingReqs * uint64(engine.initOptions.NumShards)
+		numIdx := atomic.LoadUint64(&engine.numIndexingReqs)
+		inxd := numIdx == atomic.LoadUint64(&engine.numDocsIndexed)
+		numRm := atomic.LoadUint64(&engine.numRemovingReqs)
 		rmd := numRm == atomic.LoadUint64(&engine.numDocsRemoved)
 
-		nums := engine.numIndexingReqs == atomic.LoadUint64(&engine.numDocsStored)
+		nums := numIdx == atomic.LoadUint64(&engine.numDocsStored)
 		stored := !engine.initOptions.UseStore || nums
 
 		if inxd && rmd && stored {
@@ -827,11 +1071,12 @@ func (engine *Engine) Flush() {
 	}
 
 	// 强制更新，保证其为最后的请求
-	engine.IndexDoc("0", types.DocData{}, true)
+	engine.internalIndexDoc("0", types.DocData{}, true, false)
 	for {
 		runtime.Gosched()
 
-		numf := engine.numForceUpdatingReqs * uint64(engine.initOptions.NumShards)
+		numf := atomic.LoadUint64(&engine.numForceUpdatingReqs) *
+			uint64(engine.initOptions.NumShards)
 		forced := numf == atomic.LoadUint64(&engine.numDocsForceUpdated)
 
 		if forced {
@@ -847,15 +1092,64 @@ func (engine *Engine) FlushIndex() {
 	engine.Flush()
 }
 
+// begin marks the start of a request, the request holds
+// the read lock until end is called
+func (engine *Engine) begin() error {
+	engine.loc.RLock()
+	if engine.closed {
+		engine.loc.RUnlock()
+		return ErrEngineClosed
+	}
+
+	return nil
+}
+
+func (engine *Engine) end() {
+	engine.loc.RUnlock()
+}
+
 // Close close the engine
 // 关闭引擎
-func (engine *Engine) Close() {
-	engine.Flush()
+//
+// Close 等待正在处理的请求完成并清空所有通道，然后停止全部工作协程，
+// 最后关闭持久化存储。之后的调用将返回 ErrEngineClosed，
+// 同一进程中可以使用相同的 StoreFolder 重新创建引擎。
+func (engine *Engine) Close() (err error) {
+	// 等待正在处理的请求
+	engine.loc.Lock()
+	if engine.closed {
+		engine.loc.Unlock()
+		return ErrEngineClosed
+	}
+
+	if engine.initialized {
+		engine.flush()
+	}
+	engine.closed = true
+	engine.loc.Unlock()
+
+	if !engine.initialized {
+		return
+	}
+
+	// 停止工作协程
+	close(engine.closeChan)
+	engine.wg.Wait()
+
 	if engine.initOptions.UseStore {
 		for _, db := range engine.dbs {
-			db.Close()
+			if e := db.Close(); e != nil && err == nil {
+				err = e
+			}
 		}
 	}
+
+	if e := engine.closeSlowLog(); e != nil && err == nil {
+		err = e
+	}
+	engine.closeChanges()
+
+	return
 }
 
 // 从文本hash得到要分配到的 shard
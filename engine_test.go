@@ -7,8 +7,10 @@ import (
 	"os"
 	"reflect"
 	"runtime"
+	"sort"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/go-ego/gse"
 	"github.com/go-ego/riot/types"
@@ -199,10 +201,10 @@ func TestFrequenciesIndex(t *testing.T) {
 	tt.Expect(t, "2", len(outDocs))
 
 	tt.Expect(t, "1", outDocs[0].DocId)
-	tt.Expect(t, "2374", int(outDocs[0].Scores[0]*1000))
+	tt.Expect(t, "2387", int(outDocs[0].Scores[0]*1000))
 
 	tt.Expect(t, "5", outDocs[1].DocId)
-	tt.Expect(t, "2133", int(outDocs[1].Scores[0]*1000))
+	tt.Expect(t, "2205", int(outDocs[1].Scores[0]*1000))
 
 	engine.Close()
 }
@@ -706,7 +708,7 @@ func TestSearchNotUseGse(t *testing.T) {
 	tt.Expect(t, "2", len(outDocs))
 
 	tt.Expect(t, "8", outDocs[0].DocId)
-	tt.Expect(t, "3736", int(outDocs[0].Scores[0]*1000))
+	tt.Expect(t, "3999", int(outDocs[0].Scores[0]*1000))
 	tt.Expect(t, "[]", outDocs[0].TokenSnippetLocs)
 
 	outputs1 := engine1.Search(types.SearchReq{
@@ -777,6 +779,11 @@ func TestRiotGse(t *testing.T) {
 	tt.Equal(t, "[此次 Google 收购 将 成 世界 互联 联网 互联网 最大 并购]",
 		engine1.Segment("此次Google收购将成世界互联网最大并购"))
 
+	// 索引和查询使用相同的分词
+	text := "此次Google收购将成世界互联网最大并购"
+	tokens, _ := engine1.analyzer.Analyze(text)
+	tt.Equal(t, tokenTexts(tokens), engine1.Tokens(types.SearchReq{Text: text}))
+
 	engine.Close()
 	engine1.Close()
 }
@@ -842,13 +849,94 @@ func TestSearchLogic(t *testing.T) {
 	log.Println("outputs docs...", outDocs)
 	tt.Expect(t, "2", len(outDocs))
 
-	tt.Expect(t, "10", outDocs[0].DocId)
+	// 两个文档分值相同，顺序取决于所在的 shard
+	ids := []string{outDocs[0].DocId, outDocs[1].DocId}
+	sort.Strings(ids)
+	tt.Equal(t, []string{"10", "9"}, ids)
+
 	tt.Expect(t, "1000", int(outDocs[0].Scores[0]*1000))
 	tt.Expect(t, "[]", outDocs[0].TokenSnippetLocs)
 
-	tt.Expect(t, "9", outDocs[1].DocId)
 	tt.Expect(t, "1000", int(outDocs[1].Scores[0]*1000))
 	tt.Expect(t, "[]", outDocs[1].TokenSnippetLocs)
 
 	engine.Close()
 }
+
+func TestEngineClose(t *testing.T) {
+	gob.Register(ScoringFields{})
+
+	var opts = types.EngineOpts{
+		Using:       1,
+		GseDict:     "./testdata/test_dict.txt",
+		DefRankOpts: &rankOptsMax10,
+		IndexerOpts: inxOpts,
+		UseStore:    true,
+		StoreFolder: "riot.close",
+		StoreShards: 2,
+	}
+
+	numGoroutine := runtime.NumGoroutine()
+
+	var engine Engine
+	engine.Init(opts)
+	AddDocs(&engine)
+
+	err := engine.Close()
+	tt.Nil(t, err)
+
+	for i := 0; i < 100 && runtime.NumGoroutine() > numGoroutine; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	tt.Equal(t, true, runtime.NumGoroutine() <= numGoroutine)
+
+	tt.Equal(t, ErrEngineClosed, engine.Close())
+	tt.Equal(t, ErrEngineClosed, engine.Index("7", types.DocData{Content: text1}))
+	tt.Equal(t, ErrEngineClosed, engine.RemoveDoc("1"))
+
+	outputs := engine.Search(Req1)
+	tt.Equal(t, ErrEngineClosed, outputs.Err)
+	tt.Equal(t, 0, len(engine.SearchDoc(Req1).Docs))
+
+	var engine1 Engine
+	engine1.Init(opts)
+	engine1.Flush()
+
+	outDocs := engine1.Search(Req1).Docs.(types.ScoredDocs)
+	tt.Expect(t, "3", len(outDocs))
+
+	engine1.Close()
+	os.RemoveAll("riot.close")
+}
+
+func TestRefreshInterval(t *testing.T) {
+	var engine Engine
+	engine.Init(types.EngineOpts{
+		Using:   1,
+		GseDict: "./testdata/test_dict.txt",
+		IndexerOpts: &types.IndexerOpts{
+			IndexType:       types.LocsIndex,
+			RefreshInterval: 10,
+		},
+	})
+	defer engine.Close()
+
+	numDocs := func() int {
+		return engine.Search(types.SearchReq{Text: "world"}).NumDocs
+	}
+	wait := func(num int) {
+		for i := 0; i < 200 && numDocs() != num; i++ {
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	// 不调用 Flush，定时刷新后可以搜索到
+	engine.Index("1", types.DocData{Content: "The world"})
+	engine.Index("2", types.DocData{Content: "The world, 人口"})
+	wait(2)
+	tt.Expect(t, "2", numDocs())
+
+	engine.RemoveDoc("1")
+	wait(1)
+	tt.Expect(t, "1", numDocs())
+}
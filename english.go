@@ -0,0 +1,411 @@
+// Copyright 2016 ego authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package riot
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// WordTokenizer split the text into the words of the letters and digits,
+// the apostrophes inside the words are kept, such as "john's"
+var WordTokenizer = TokenizerFunc(func(text string) []Token {
+	var tokens []Token
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r) &&
+			!unicode.Is(unicode.Mn, r) && r != '\'' && r != '’'
+	})
+
+	for _, word := range words {
+		word = strings.Trim(word, "'’")
+		if word != "" {
+			tokens = append(tokens, Token{Text: word, Start: len(tokens) + 1})
+		}
+	}
+	return tokens
+})
+
+// mapTokens 对每个关键词调用 fn，去掉变为空的关键词
+func mapTokens(fn func(string) string) TokenFilter {
+	return TokenFilterFunc(func(tokens []Token) []Token {
+		out := tokens[:0]
+		for _, token := range tokens {
+			token.Text = fn(token.Text)
+			if token.Text != "" {
+				out = append(out, token)
+			}
+		}
+		return out
+	})
+}
+
+// PossessiveFilter remove the trailing English possessive 's
+var PossessiveFilter = mapTokens(func(text string) string {
+	for _, suffix := range []string{"'s", "’s", "'S", "’S"} {
+		if strings.HasSuffix(text, suffix) {
+			return strings.TrimSuffix(text, suffix)
+		}
+	}
+	return text
+})
+
+// ASCIIFoldingFilter fold the Latin letters with diacritics and
+// the ligatures to ASCII, such as "café" to "cafe"
+var ASCIIFoldingFilter = mapTokens(foldASCII)
+
+// PorterStemFilter stem the English words with the Porter stemmer,
+// such as "running" and "runs" to "run"
+var PorterStemFilter = mapTokens(PorterStem)
+
+// foldTable 需要转换的字符
+var foldTable = make(map[rune]string)
+
+func init() {
+	for _, fold := range [][2]string{
+		{"ÀÁÂÃÄÅĀĂĄǍ", "A"}, {"àáâãäåāăąǎª", "a"},
+		{"ÇĆĈĊČ", "C"}, {"çćĉċč", "c"},
+		{"ÐĎĐ", "D"}, {"ðďđ", "d"},
+		{"ÈÉÊËĒĔĖĘĚ", "E"}, {"èéêëēĕėęě", "e"},
+		{"ĜĞĠĢ", "G"}, {"ĝğġģ", "g"},
+		{"ĤĦ", "H"}, {"ĥħ", "h"},
+		{"ÌÍÎÏĨĪĬĮİǏ", "I"}, {"ìíîïĩīĭįıǐ", "i"},
+		{"Ĵ", "J"}, {"ĵ", "j"},
+		{"Ķ", "K"}, {"ķĸ", "k"},
+		{"ĹĻĽĿŁ", "L"}, {"ĺļľŀł", "l"},
+		{"ÑŃŅŇŊ", "N"}, {"ñńņňŉŋ", "n"},
+		{"ÒÓÔÕÖØŌŎŐǑ", "O"}, {"òóôõöøōŏőǒº", "o"},
+		{"ŔŖŘ", "R"}, {"ŕŗř", "r"},
+		{"ŚŜŞŠ", "S"}, {"śŝşšſ", "s"},
+		{"ŢŤŦ", "T"}, {"ţťŧ", "t"},
+		{"ÙÚÛÜŨŪŬŮŰŲǓ", "U"}, {"ùúûüũūŭůűųǔ", "u"},
+		{"Ŵ", "W"}, {"ŵ", "w"},
+		{"ÝŶŸ", "Y"}, {"ýÿŷ", "y"},
+		{"ŹŻŽ", "Z"}, {"źżž", "z"},
+		{"Æ", "AE"}, {"æ", "ae"}, {"Œ", "OE"}, {"œ", "oe"},
+		{"Ĳ", "IJ"}, {"ĳ", "ij"}, {"Þ", "TH"}, {"þ", "th"}, {"ß", "ss"},
+	} {
+		for _, r := range fold[0] {
+			foldTable[r] = fold[1]
+		}
+	}
+}
+
+func foldASCII(text string) string {
+	ascii := true
+	for i := 0; i < len(text); i++ {
+		if text[i] >= utf8.RuneSelf {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		return text
+	}
+
+	var b strings.Builder
+	for _, r := range text {
+		if fold, ok := foldTable[r]; ok {
+			b.WriteString(fold)
+		} else if r < 0x300 || r > 0x36f {
+			// 去掉组合附加符号
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// PorterStem stem the lower case English word with the Porter algorithm,
+// the words with other characters are returned unchanged
+func PorterStem(word string) string {
+	if len(word) <= 2 {
+		return word
+	}
+	for i := 0; i < len(word); i++ {
+		if word[i] < 'a' || word[i] > 'z' {
+			return word
+		}
+	}
+
+	p := &porter{b: []byte(word), k: len(word) - 1}
+	p.step1ab()
+	if p.k > 0 {
+		p.step1c()
+		p.step2()
+		p.step3()
+		p.step4()
+		p.step5()
+	}
+
+	return string(p.b[:p.k+1])
+}
+
+// porter 词干提取的状态，b[0..k] 为当前的单词，j 为后缀之前的位置
+type porter struct {
+	b    []byte
+	k, j int
+}
+
+// cons b[i] 是否为辅音
+func (p *porter) cons(i int) bool {
+	switch p.b[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return false
+	case 'y':
+		return i == 0 || !p.cons(i-1)
+	}
+	return true
+}
+
+// m b[0..j] 中元音辅音序列的个数
+func (p *porter) m() int {
+	n, i := 0, 0
+	for {
+		if i > p.j {
+			return n
+		}
+		if !p.cons(i) {
+			break
+		}
+		i++
+	}
+	i++
+
+	for {
+		for {
+			if i > p.j {
+				return n
+			}
+			if p.cons(i) {
+				break
+			}
+			i++
+		}
+		i++
+		n++
+
+		for {
+			if i > p.j {
+				return n
+			}
+			if !p.cons(i) {
+				break
+			}
+			i++
+		}
+		i++
+	}
+}
+
+func (p *porter) vowelInStem() bool {
+	for i := 0; i <= p.j; i++ {
+		if !p.cons(i) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *porter) doublec(i int) bool {
+	return i >= 1 && p.b[i] == p.b[i-1] && p.cons(i)
+}
+
+// cvc b[i-2..i] 是辅音-元音-辅音，并且最后一个辅音不是 w、x 或 y
+func (p *porter) cvc(i int) bool {
+	if i < 2 || !p.cons(i) || p.cons(i-1) || !p.cons(i-2) {
+		return false
+	}
+
+	switch p.b[i] {
+	case 'w', 'x', 'y':
+		return false
+	}
+	return true
+}
+
+// ends b[0..k] 以 s 结尾时设置 j 并返回 true
+func (p *porter) ends(s string) bool {
+	l := len(s)
+	if l > p.k+1 || string(p.b[p.k-l+1:p.k+1]) != s {
+		return false
+	}
+
+	p.j = p.k - l
+	return true
+}
+
+// setTo 将 b[j+1..k] 替换为 s
+func (p *porter) setTo(s string) {
+	p.b = append(p.b[:p.j+1], s...)
+	p.k = p.j + len(s)
+}
+
+func (p *porter) r(s string) {
+	if p.m() > 0 {
+		p.setTo(s)
+	}
+}
+
+// replace 第一个匹配的后缀在 m() > 0 时替换
+func (p *porter) replace(pairs ...string) {
+	for i := 0; i < len(pairs); i += 2 {
+		if p.ends(pairs[i]) {
+			p.r(pairs[i+1])
+			return
+		}
+	}
+}
+
+// step1ab 去掉复数和 -ed、-ing
+func (p *porter) step1ab() {
+	if p.b[p.k] == 's' {
+		if p.ends("sses") {
+			p.k -= 2
+		} else if p.ends("ies") {
+			p.setTo("i")
+		} else if p.b[p.k-1] != 's' {
+			p.k--
+		}
+	}
+
+	if p.ends("eed") {
+		if p.m() > 0 {
+			p.k--
+		}
+	} else if (p.ends("ed") || p.ends("ing")) && p.vowelInStem() {
+		p.k = p.j
+		if p.ends("at") {
+			p.setTo("ate")
+		} else if p.ends("bl") {
+			p.setTo("ble")
+		} else if p.ends("iz") {
+			p.setTo("ize")
+		} else if p.doublec(p.k) {
+			p.k--
+			switch p.b[p.k] {
+			case 'l', 's', 'z':
+				p.k++
+			}
+		} else if p.m() == 1 && p.cvc(p.k) {
+			p.setTo("e")
+		}
+	}
+}
+
+// step1c 词干中有元音时将结尾的 y 变为 i
+func (p *porter) step1c() {
+	if p.ends("y") && p.vowelInStem() {
+		p.b[p.k] = 'i'
+	}
+}
+
+// step2 将双后缀变为单后缀
+func (p *porter) step2() {
+	switch p.b[p.k-1] {
+	case 'a':
+		p.replace("ational", "ate", "tional", "tion")
+	case 'c':
+		p.replace("enci", "ence", "anci", "ance")
+	case 'e':
+		p.replace("izer", "ize")
+	case 'l':
+		p.replace("bli", "ble", "alli", "al", "entli", "ent", "eli", "e", "ousli", "ous")
+	case 'o':
+		p.replace("ization", "ize", "ation", "ate", "ator", "ate")
+	case 's':
+		p.replace("alism", "al", "iveness", "ive", "fulness", "ful", "ousness", "ous")
+	case 't':
+		p.replace("aliti", "al", "iviti", "ive", "biliti", "ble")
+	case 'g':
+		p.replace("logi", "log")
+	}
+}
+
+// step3 处理 -ic-、-full、-ness 等
+func (p *porter) step3() {
+	switch p.b[p.k] {
+	case 'e':
+		p.replace("icate", "ic", "ative", "", "alize", "al")
+	case 'i':
+		p.replace("iciti", "ic")
+	case 'l':
+		p.replace("ical", "ic", "ful", "")
+	case 's':
+		p.replace("ness", "")
+	}
+}
+
+// step4 在 m() > 1 时去掉 -ant、-ence 等
+func (p *porter) step4() {
+	var suffixes []string
+	switch p.b[p.k-1] {
+	case 'a':
+		suffixes = []string{"al"}
+	case 'c':
+		suffixes = []string{"ance", "ence"}
+	case 'e':
+		suffixes = []string{"er"}
+	case 'i':
+		suffixes = []string{"ic"}
+	case 'l':
+		suffixes = []string{"able", "ible"}
+	case 'n':
+		suffixes = []string{"ant", "ement", "ment", "ent"}
+	case 'o':
+		if p.ends("ion") && p.j >= 0 && (p.b[p.j] == 's' || p.b[p.j] == 't') {
+			break
+		}
+		suffixes = []string{"ou"}
+	case 's':
+		suffixes = []string{"ism"}
+	case 't':
+		suffixes = []string{"ate", "iti"}
+	case 'u':
+		suffixes = []string{"ous"}
+	case 'v':
+		suffixes = []string{"ive"}
+	case 'z':
+		suffixes = []string{"ize"}
+	default:
+		return
+	}
+
+	matched := suffixes == nil
+	for _, suffix := range suffixes {
+		if p.ends(suffix) {
+			matched = true
+			break
+		}
+	}
+
+	if matched && p.m() > 1 {
+		p.k = p.j
+	}
+}
+
+// step5 去掉 m() > 1 时结尾的 e，将 -ll 变为 -l
+func (p *porter) step5() {
+	p.j = p.k
+	if p.b[p.k] == 'e' {
+		a := p.m()
+		if a > 1 || a == 1 && !p.cvc(p.k-1) {
+			p.k--
+		}
+	}
+
+	if p.b[p.k] == 'l' && p.doublec(p.k) && p.m() > 1 {
+		p.k--
+	}
+}
@@ -0,0 +1,86 @@
+package riot
+
+import (
+	"testing"
+
+	"github.com/go-ego/riot/types"
+	"github.com/vcaesar/tt"
+)
+
+func TestPorterStem(t *testing.T) {
+	words := map[string]string{
+		"running": "run", "runs": "run", "run": "run",
+		"caresses": "caress", "ponies": "poni", "cats": "cat",
+		"agreed": "agre", "hopping": "hop", "filing": "file",
+		"happy": "happi", "relational": "relat", "conditional": "condit",
+		"generalizations": "gener", "electrical": "electr",
+		"adjustment": "adjust", "controlling": "control",
+		"is": "is", "café": "café", "RUNS": "RUNS",
+	}
+	for word, stem := range words {
+		tt.Equal(t, stem, PorterStem(word), word)
+	}
+}
+
+func TestEnglishFilters(t *testing.T) {
+	tokens := WordTokenizer.Tokenize("John's café, the Users' naïve résumé!")
+	tt.Equal(t, []string{"John's", "café", "the", "Users", "naïve", "résumé"},
+		tokenTexts(tokens))
+
+	tokens = PossessiveFilter.Filter(tokens)
+	tokens = ASCIIFoldingFilter.Filter(tokens)
+	tt.Equal(t, []string{"John", "cafe", "the", "Users", "naive", "resume"},
+		tokenTexts(tokens))
+	tt.Equal(t, 2, tokens[1].Start)
+	tt.Equal(t, "Strasse aeon", foldASCII("Straße æon"))
+}
+
+func TestEnglishAnalyzer(t *testing.T) {
+	var engine Engine
+	engine.Init(types.EngineOpts{
+		GseDict:       "./testdata/test_dict.txt",
+		StopTokenFile: "./testdata/test_stop_dict.txt",
+		IndexerOpts:   inxOpts,
+		Analyzer:      "english",
+	})
+	defer engine.Close()
+
+	engine.Index("1", types.DocData{Content: "The runner's shoes, running fast"})
+	engine.Index("2", types.DocData{Content: "He runs to the Café"})
+	engine.Flush()
+
+	tt.Equal(t, 2, len(searchIds(&engine, "run")))
+	tt.Equal(t, []string{"2"}, searchIds(&engine, "CAFES"))
+	tt.Equal(t, []string{"1"}, searchIds(&engine, "shoe"))
+}
+
+func TestTokenFilters(t *testing.T) {
+	var engine Engine
+	engine.Init(types.EngineOpts{
+		NotUseGse:    true,
+		IndexerOpts:  inxOpts,
+		TokenFilters: []string{"possessive", "ascii_folding", "porter_stem"},
+	})
+	defer engine.Close()
+
+	engine.Index("1", types.DocData{Content: "crème brûlée"})
+	engine.Index("2", types.DocData{Content: "john's connections"})
+	engine.Flush()
+
+	tt.Equal(t, []string{"1"}, searchIds(&engine, "creme"))
+	tt.Equal(t, []string{"2"}, searchIds(&engine, "connected"))
+	tt.Equal(t, []string{"2"}, searchIds(&engine, "john"))
+}
+
+func TestSegmentFilters(t *testing.T) {
+	var engine Engine
+	engine.Init(types.EngineOpts{
+		GseDict:      "./testdata/test_dict.txt",
+		IndexerOpts:  inxOpts,
+		TokenFilters: []string{"porter_stem"},
+	})
+	defer engine.Close()
+
+	tt.Equal(t, []string{"connect"}, engine.Segment("connections"))
+	tt.Equal(t, []string{"connect"}, engine.Tokens(types.SearchReq{Text: "connected"}))
+}
@@ -0,0 +1,51 @@
+// Copyright 2016 ego authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+// Offline migration of the store to a new StoreShards, usage:
+//
+//	go run main.go -folder ./riot-index -engine ldb -to 16
+package main
+
+import (
+	"flag"
+	"log"
+
+	"github.com/go-ego/riot"
+)
+
+var (
+	folder = flag.String("folder", riot.DefaultPath, "store folder")
+	engine = flag.String("engine", "ldb", "store engine, ldb, bg or bolt")
+	from   = flag.Int("from", 0, "current store shards, 0: detect from the folder")
+	to     = flag.Int("to", 8, "new store shards")
+)
+
+func main() {
+	flag.Parse()
+
+	if *from == 0 {
+		*from = riot.StoreShardsOf(*folder)
+	}
+	log.Printf("reshard %s from %d to %d store shards", *folder, *from, *to)
+
+	err := riot.ReshardStore(*folder, *engine, *from, *to,
+		func(p riot.ReshardProgress) {
+			log.Printf("%s: %d/%d", p.Stage, p.Done, p.Total)
+		})
+	if err != nil {
+		log.Fatal("reshard: ", err)
+	}
+
+	log.Println("reshard finished")
+}
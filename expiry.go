@@ -0,0 +1,226 @@
+// Copyright 2016 ego authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package riot
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+
+	"github.com/go-ego/riot/types"
+)
+
+// defaultSweepInterval 默认清理过期文档的间隔（毫秒）
+const defaultSweepInterval = 1000
+
+type expireItem struct {
+	docId    string
+	expireAt int64
+	// 设置过期时间时文档的版本
+	version uint64
+	// 在堆中的位置
+	index int
+}
+
+// expireHeap 按过期时间排序的最小堆
+type expireHeap []*expireItem
+
+func (h expireHeap) Len() int           { return len(h) }
+func (h expireHeap) Less(i, j int) bool { return h[i].expireAt < h[j].expireAt }
+func (h expireHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *expireHeap) Push(x interface{}) {
+	item := x.(*expireItem)
+	item.index = len(*h)
+	*h = append(*h, item)
+}
+
+func (h *expireHeap) Pop() interface{} {
+	old := *h
+	item := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	item.index = -1
+	return item
+}
+
+// expiry 设置了过期时间的文档
+//
+// docs 和 heap 中的项一一对应，过期时间改变时在堆中调整，
+// 文档被删除或者不再过期时从堆中删除。
+type expiry struct {
+	sync.RWMutex
+	docs map[string]*expireItem
+	heap expireHeap
+}
+
+// reset 清空过期时间，关闭之前的 sweeper 可能仍在读取
+func (e *expiry) reset() {
+	e.Lock()
+	e.docs = make(map[string]*expireItem)
+	e.heap = nil
+	e.Unlock()
+}
+
+// remove 删除文档的过期时间，调用前需要加写锁
+func (e *expiry) remove(docId string) {
+	if item, ok := e.docs[docId]; ok {
+		heap.Remove(&e.heap, item.index)
+		delete(e.docs, docId)
+	}
+}
+
+// withTTL 没有设置过期时间的文档使用 EngineOpts.TTL
+func (engine *Engine) withTTL(data *types.DocData) {
+	if data.ExpireAt == 0 && engine.initOptions.TTL > 0 {
+		ttl := time.Duration(engine.initOptions.TTL) * time.Millisecond
+		data.ExpireAt = time.Now().Add(ttl).UnixNano()
+	}
+}
+
+// setExpiry 记录文档的过期时间和版本，0 表示不过期
+func (engine *Engine) setExpiry(docId string, expireAt int64, version uint64) {
+	engine.expiry.Lock()
+	defer engine.expiry.Unlock()
+
+	if expireAt == 0 {
+		engine.expiry.remove(docId)
+		return
+	}
+
+	if item, ok := engine.expiry.docs[docId]; ok {
+		item.expireAt, item.version = expireAt, version
+		heap.Fix(&engine.expiry.heap, item.index)
+		return
+	}
+
+	item := &expireItem{docId: docId, expireAt: expireAt, version: version}
+	engine.expiry.docs[docId] = item
+	heap.Push(&engine.expiry.heap, item)
+}
+
+// unexpired 过滤掉已经过期但还没有被清理的文档
+func (engine *Engine) unexpired(docs []types.IndexedDoc) []types.IndexedDoc {
+	engine.expiry.RLock()
+	defer engine.expiry.RUnlock()
+
+	if len(engine.expiry.docs) == 0 {
+		return docs
+	}
+
+	now := time.Now().UnixNano()
+	outputs := docs[:0:0]
+	for _, doc := range docs {
+		if item, ok := engine.expiry.docs[doc.DocId]; ok && item.expireAt <= now {
+			continue
+		}
+		outputs = append(outputs, doc)
+	}
+
+	return outputs
+}
+
+// hasExpiry 是否有设置了过期时间的文档
+func (engine *Engine) hasExpiry() bool {
+	engine.expiry.RLock()
+	defer engine.expiry.RUnlock()
+
+	return len(engine.expiry.docs) > 0
+}
+
+// expired 全部已经过期的文档，过期时间在删除时才清除，
+// 期间搜索仍然过滤这些文档
+func (engine *Engine) expired() []expireItem {
+	engine.expiry.RLock()
+	defer engine.expiry.RUnlock()
+
+	var (
+		items []expireItem
+		h     = engine.expiry.heap
+		now   = time.Now().UnixNano()
+	)
+
+	// 只遍历堆中过期时间不晚于 now 的部分
+	var walk func(i int)
+	walk = func(i int) {
+		if i >= len(h) || h[i].expireAt > now {
+			return
+		}
+
+		items = append(items, *h[i])
+		walk(2*i + 1)
+		walk(2*i + 2)
+	}
+	walk(0)
+
+	return items
+}
+
+// removeExpired 删除过期的文档，文档在过期之后被重新加入
+// 或者过期时间已经改变时不删除
+func (engine *Engine) removeExpired(item expireItem) error {
+	if err := engine.begin(); err != nil {
+		return err
+	}
+	defer engine.end()
+
+	var version uint64
+	if engine.initOptions.Versioning {
+		// 只删除过期的版本，重新加入的文档版本更大
+		v, err := engine.acceptVersion(item.docId, item.version+1, true)
+		if err != nil {
+			return nil
+		}
+		version = v
+	}
+
+	engine.removeDoc(item.docId, version, false, &item)
+	return nil
+}
+
+// sweeper 定时从索引器、排序器和持久化存储中删除过期的文档
+//
+// sweeper 不计入 wg，Reshard 等待工作协程退出时 sweeper 可能正在等待锁。
+func (engine *Engine) sweeper(interval int, closeChan chan bool) {
+	if interval <= 0 {
+		interval = defaultSweepInterval
+	}
+
+	ticker := time.NewTicker(time.Duration(interval) * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-closeChan:
+			return
+		}
+
+		items := engine.expired()
+		for _, item := range items {
+			if engine.removeExpired(item) == ErrEngineClosed {
+				return
+			}
+		}
+
+		if len(items) > 0 {
+			engine.RemoveDoc("0", true)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package riot
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-ego/riot/types"
+	"github.com/vcaesar/tt"
+)
+
+func expiryOpts(ttl, sweep int) types.EngineOpts {
+	return types.EngineOpts{
+		Using:         1,
+		GseDict:       "./testdata/test_dict.txt",
+		IndexerOpts:   inxOpts,
+		NumShards:     2,
+		UseStore:      true,
+		StoreFolder:   "riot.expiry",
+		StoreShards:   2,
+		TTL:           ttl,
+		SweepInterval: sweep,
+	}
+}
+
+func TestExpireHidden(t *testing.T) {
+	os.RemoveAll("riot.expiry")
+	defer os.RemoveAll("riot.expiry")
+
+	var engine Engine
+	// 不清理过期文档，只在搜索时过滤
+	engine.Init(expiryOpts(0, 60000))
+	defer engine.Close()
+
+	expireAt := time.Now().Add(200 * time.Millisecond).UnixNano()
+	engine.Index("1", types.DocData{Content: "The world", ExpireAt: expireAt})
+	engine.IndexBatch([]types.BatchDoc{
+		{DocId: "2", Data: types.DocData{Content: "The world", ExpireAt: expireAt}},
+		{DocId: "3", Data: types.DocData{Content: "The world"}},
+	})
+	engine.Flush()
+
+	tt.Expect(t, "3", len(searchIds(&engine, "world")))
+
+	time.Sleep(300 * time.Millisecond)
+	tt.Equal(t, []string{"3"}, searchIds(&engine, "world"))
+
+	outputs := engine.Search(types.SearchReq{Text: "world", CountDocsOnly: true})
+	tt.Expect(t, "1", outputs.NumDocs)
+
+	// 重新加入索引后不再过期
+	engine.Index("1", types.DocData{Content: "The world"})
+	engine.Flush()
+	tt.Expect(t, "2", len(searchIds(&engine, "world")))
+	tt.True(t, engine.HasDoc("2"))
+}
+
+func TestExpireSweep(t *testing.T) {
+	os.RemoveAll("riot.expiry")
+	defer os.RemoveAll("riot.expiry")
+
+	var engine Engine
+	engine.Init(expiryOpts(200, 50))
+
+	engine.Index("1", types.DocData{Content: "The world"})
+	// 显式的过期时间优先于默认的 TTL
+	engine.Index("2", types.DocData{Content: "The world",
+		ExpireAt: time.Now().Add(time.Hour).UnixNano()})
+	engine.Flush()
+
+	data, err := engine.GetDoc("1")
+	tt.Nil(t, err)
+	tt.True(t, data.ExpireAt > 0)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, err := engine.GetDoc("1"); err == ErrDocNotFound {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	engine.Flush()
+
+	tt.False(t, engine.HasDoc("1"))
+	tt.True(t, engine.HasDoc("2"))
+	tt.Equal(t, []string{"2"}, searchIds(&engine, "world"))
+	engine.Close()
+
+	// 过期时间随文档持久化
+	var engine1 Engine
+	engine1.Init(expiryOpts(200, 50))
+	engine1.Flush()
+
+	_, err = engine1.GetDoc("1")
+	tt.Equal(t, ErrDocNotFound, err)
+	tt.Equal(t, []string{"2"}, searchIds(&engine1, "world"))
+	engine1.Close()
+}
+
+func TestExpireStale(t *testing.T) {
+	os.RemoveAll("riot.expiry")
+	defer os.RemoveAll("riot.expiry")
+
+	var engine Engine
+	engine.Init(expiryOpts(0, 60000))
+	defer engine.Close()
+
+	expireAt := time.Now().Add(100 * time.Millisecond).UnixNano()
+	engine.Index("1", types.DocData{Content: "The world", ExpireAt: expireAt})
+	engine.Index("2", types.DocData{Content: "The world", ExpireAt: expireAt})
+	engine.Flush()
+
+	// 取消过期时同时从堆中删除
+	engine.Index("2", types.DocData{Content: "The world"})
+	engine.Flush()
+	tt.Equal(t, 1, len(engine.expiry.heap))
+
+	time.Sleep(200 * time.Millisecond)
+	items := engine.expired()
+	tt.Equal(t, 1, len(items))
+
+	// 过期之后重新加入的文档不会被删除
+	engine.Index("1", types.DocData{Content: "The world",
+		ExpireAt: time.Now().Add(time.Hour).UnixNano()})
+	engine.Flush()
+	tt.Nil(t, engine.removeExpired(items[0]))
+	engine.Flush()
+
+	tt.True(t, engine.HasDoc("1"))
+	tt.Equal(t, 2, len(searchIds(&engine, "world")))
+}
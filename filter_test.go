@@ -0,0 +1,74 @@
+package riot
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/go-ego/riot/types"
+	"github.com/vcaesar/tt"
+)
+
+func filterSearch(engine *Engine, request types.SearchReq) []string {
+	var ids []string
+	for _, doc := range engine.Search(request).Docs.(types.ScoredDocs) {
+		ids = append(ids, doc.DocId)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+func TestSearchFilters(t *testing.T) {
+	var engine Engine
+	engine.Init(types.EngineOpts{
+		Using:          1,
+		GseDict:        "./testdata/test_dict.txt",
+		IndexerOpts:    inxOpts,
+		NumShards:      2,
+		QueryCacheSize: 10,
+	})
+	defer engine.Close()
+
+	engine.Index("1", types.DocData{Content: "The world",
+		Labels: []string{"tenant1", "news"}})
+	engine.Index("2", types.DocData{Content: "The world, 人口",
+		Labels: []string{"tenant1"}})
+	engine.Index("3", types.DocData{Content: "The world, 有人口",
+		Labels: []string{"tenant2", "news"}})
+	engine.Flush()
+
+	tenant1 := types.Filter{Labels: []string{"tenant1"}}
+	tt.Equal(t, []string{"1", "2"}, filterSearch(&engine, types.SearchReq{
+		Text: "world", Filters: []types.Filter{tenant1}}))
+
+	// 过滤条件不影响评分
+	outputs := engine.Search(types.SearchReq{Text: "world",
+		Filters: []types.Filter{{DocIds: []string{"1"}}}})
+	scored := engine.Search(types.SearchReq{Text: "world",
+		DocIds: map[string]bool{"1": true}})
+	tt.Equal(t, scored.Docs.(types.ScoredDocs)[0].Scores,
+		outputs.Docs.(types.ScoredDocs)[0].Scores)
+
+	// 只有过滤条件
+	tt.Equal(t, []string{"1", "3"}, filterSearch(&engine, types.SearchReq{
+		Filters: []types.Filter{{Labels: []string{"news"}}}}))
+	tt.Equal(t, []string{"2", "3"}, filterSearch(&engine, types.SearchReq{
+		Text: "world", Filters: []types.Filter{
+			{Labels: []string{"tenant1", "news"}, Not: true}}}))
+
+	outputs = engine.Search(types.SearchReq{CountDocsOnly: true,
+		Filters: []types.Filter{tenant1}})
+	tt.Equal(t, 2, outputs.NumDocs)
+
+	hits, _ := engine.FilterCacheStats()
+	tt.True(t, hits > 0)
+
+	// 查询缓存区分不同的过滤条件
+	tt.Equal(t, []string{"3"}, filterSearch(&engine, types.SearchReq{
+		Text: "world", Filters: []types.Filter{{Labels: []string{"tenant2"}}}}))
+
+	engine.RemoveDoc("1", true)
+	engine.Flush()
+	tt.Equal(t, []string{"2"}, filterSearch(&engine, types.SearchReq{
+		Text: "world", Filters: []types.Filter{tenant1}}))
+}
@@ -17,6 +17,7 @@ package riot
 
 import (
 	"sync/atomic"
+	"time"
 
 	"github.com/go-ego/riot/types"
 )
@@ -24,6 +25,8 @@ import (
 type indexerAddDocReq struct {
 	doc         *types.DocIndex
 	forceUpdate bool
+	// 从该 shard 删除的文档，在之前加入的文档之后执行
+	remove string
 }
 
 type indexerLookupReq struct {
@@ -32,10 +35,13 @@ type indexerLookupReq struct {
 	labels        []string
 
 	docIds           map[string]bool
+	filters          []types.Filter
 	options          types.RankOpts
 	rankerReturnChan chan rankerReturnReq
 	orderless        bool
 	logic            types.Logic
+	profiler         *profiler
+	cache            cacheRef
 }
 
 type indexerRemoveDocReq struct {
@@ -44,8 +50,37 @@ type indexerRemoveDocReq struct {
 }
 
 func (engine *Engine) indexerAddDoc(shard int) {
+	defer engine.wg.Done()
+
+	// 定时刷新，nil 通道表示不自动刷新
+	var refresh <-chan time.Time
+	if interval := engine.initOptions.IndexerOpts.RefreshInterval; interval > 0 {
+		ticker := time.NewTicker(time.Duration(interval) * time.Millisecond)
+		defer ticker.Stop()
+		refresh = ticker.C
+	}
+
 	for {
-		request := <-engine.indexerAddDocChans[shard]
+		var request indexerAddDocReq
+		select {
+		case request = <-engine.indexerAddDocChans[shard]:
+		case <-refresh:
+			engine.indexers[shard].Refresh()
+			continue
+		case <-engine.closeChan:
+			return
+		}
+
+		if request.remove != "" {
+			engine.indexers[shard].RemoveDocToCache(request.remove,
+				request.forceUpdate)
+			atomic.AddUint64(&engine.numDocsRemoved, 1)
+			if request.forceUpdate {
+				atomic.AddUint64(&engine.numDocsForceUpdated, 1)
+			}
+			continue
+		}
+
 		engine.indexers[shard].AddDocToCache(request.doc, request.forceUpdate)
 		if request.doc != nil {
 			atomic.AddUint64(&engine.numTokenIndexAdded,
@@ -60,8 +95,16 @@ func (engine *Engine) indexerAddDoc(shard int) {
 }
 
 func (engine *Engine) indexerRemoveDoc(shard int) {
+	defer engine.wg.Done()
+
 	for {
-		request := <-engine.indexerRemoveDocChans[shard]
+		var request indexerRemoveDocReq
+		select {
+		case request = <-engine.indexerRemoveDocChans[shard]:
+		case <-engine.closeChan:
+			return
+		}
+
 		engine.indexers[shard].RemoveDocToCache(request.docId, request.forceUpdate)
 		if request.docId != "0" {
 			atomic.AddUint64(&engine.numDocsRemoved, 1)
@@ -72,8 +115,9 @@ func (engine *Engine) indexerRemoveDoc(shard int) {
 	}
 }
 
-func (engine *Engine) orderLess(
-	request indexerLookupReq, docs []types.IndexedDoc) {
+func (engine *Engine) orderLess(shard int, request indexerLookupReq,
+	docs []types.IndexedDoc, profile types.ShardProfile) {
+	request.profiler.add(profile)
 
 	if engine.initOptions.IDOnly {
 		var outputDocs types.ScoredIDs
@@ -85,10 +129,9 @@ func (engine *Engine) orderLess(
 			})
 		}
 
-		request.rankerReturnChan <- rankerReturnReq{
-			docs:    outputDocs,
-			numDocs: len(outputDocs),
-		}
+		output := rankerReturnReq{docs: outputDocs, numDocs: len(outputDocs)}
+		engine.cacheResult(shard, request.cache, output)
+		request.rankerReturnChan <- output
 
 		return
 	}
@@ -106,33 +149,65 @@ func (engine *Engine) orderLess(
 		})
 	}
 
-	request.rankerReturnChan <- rankerReturnReq{
-		docs:    outputDocs,
-		numDocs: len(outputDocs),
-	}
+	output := rankerReturnReq{docs: outputDocs, numDocs: len(outputDocs)}
+	engine.cacheResult(shard, request.cache, output)
+	request.rankerReturnChan <- output
 }
 
 func (engine *Engine) indexerLookup(shard int) {
+	defer engine.wg.Done()
+
 	for {
-		request := <-engine.indexerLookupChans[shard]
+		var request indexerLookupReq
+		select {
+		case request = <-engine.indexerLookupChans[shard]:
+		case <-engine.closeChan:
+			return
+		}
+
+		start := time.Now()
+		if request.cache.key != nil {
+			// 查找之前的 generation，查找期间索引改变时缓存的结果不会被使用
+			request.cache.generation = engine.generation(shard)
+			if output, ok := engine.cachedResult(shard, request.cache); ok {
+				request.profiler.add(types.ShardProfile{Shard: shard,
+					Lookup: time.Since(start), NumDocs: output.numDocs, Cached: true})
+				request.rankerReturnChan <- output
+				continue
+			}
+		}
 
-		docs, numDocs := engine.indexers[shard].Lookup(
+		// 有设置了过期时间的文档时需要返回文档，过滤掉已过期的文档
+		expiry := engine.hasExpiry()
+		docs, numDocs := engine.indexers[shard].FilterLookup(request.filters,
 			request.tokens, request.labels,
-			request.docIds, request.countDocsOnly, request.logic)
+			request.docIds, request.countDocsOnly && !expiry, request.logic)
+		if expiry {
+			docs = engine.unexpired(docs)
+			numDocs = len(docs)
+		}
+
+		profile := types.ShardProfile{
+			Shard: shard, Lookup: time.Since(start), NumDocs: numDocs}
 
 		if request.countDocsOnly {
-			request.rankerReturnChan <- rankerReturnReq{numDocs: numDocs}
+			request.profiler.add(profile)
+			output := rankerReturnReq{numDocs: numDocs}
+			engine.cacheResult(shard, request.cache, output)
+			request.rankerReturnChan <- output
 			continue
 		}
 
 		if len(docs) == 0 {
+			request.profiler.add(profile)
+			engine.cacheResult(shard, request.cache, rankerReturnReq{})
 			request.rankerReturnChan <- rankerReturnReq{}
 			continue
 		}
 
 		if request.orderless {
 			// var outputDocs interface{}
-			engine.orderLess(request, docs)
+			engine.orderLess(shard, request, docs, profile)
 
 			continue
 		}
@@ -142,6 +217,9 @@ func (engine *Engine) indexerLookup(shard int) {
 			docs:             docs,
 			options:          request.options,
 			rankerReturnChan: request.rankerReturnChan,
+			profiler:         request.profiler,
+			profile:          profile,
+			cache:            request.cache,
 		}
 		engine.rankerRankChans[shard] <- rankerRequest
 	}
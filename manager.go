@@ -0,0 +1,669 @@
+// Copyright 2016 ego authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package riot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-ego/riot/types"
+
+	"github.com/go-ego/gse"
+	"github.com/go-ego/murmur"
+)
+
+var (
+	// ErrIndexExists is returned when creating an index which already exists
+	ErrIndexExists = errors.New("index already exists")
+	// ErrIndexNotFound is returned when the index does not exist
+	// or has not been opened
+	ErrIndexNotFound = errors.New("index not found")
+	// ErrInvalidIndexName is returned when the index name
+	// can not be used as a directory name
+	ErrInvalidIndexName = errors.New("invalid index name")
+	// ErrAliasNotFound is returned when the alias does not exist
+	ErrAliasNotFound = errors.New("alias not found")
+	// ErrNoStore is returned when reindexing from an index
+	// which does not use the persistent store
+	ErrNoStore = errors.New("the index does not use the store")
+	// ErrReindexing is returned when reindexing from an index
+	// which is being reindexed
+	ErrReindexing = errors.New("the index is being reindexed")
+)
+
+const (
+	// aliasFile 别名保存在数据目录下的文件
+	aliasFile = "aliases.json"
+	// reindexBatchSize 重建索引时每批加入的文档数
+	reindexBatchSize = 1000
+	// reindexRounds 切换别名之前最多重放的轮数
+	reindexRounds = 3
+)
+
+// Manager manage the named indexes under one data root
+// 索引管理器，在同一个数据目录下管理多个命名索引
+//
+// 每个索引是一个独立的 Engine，拥有自己的 EngineOpts，
+// 数据保存在 root/name 目录中。别名指向一个具体的索引，
+// 可以原子地切换，见 SetAlias 和 Reindex。
+// 使用相同 GseDict 的索引共享同一个 gse 分词器，词典只载入一次，
+// AddWords 和 RemoveWords 对这些索引同时生效，加入和删除的词保存在
+// 数据目录下，而不是每个索引的目录中。Manager 是线程安全的。
+type Manager struct {
+	root string
+
+	lock    sync.RWMutex
+	engines map[string]*Engine
+	// 别名到索引名
+	aliases map[string]string
+	// 词典到共享的分词器
+	segmenters map[string]sharedGse
+}
+
+// sharedGse 共享的分词器和运行时加入和删除的词
+type sharedGse struct {
+	segmenter gse.Segmenter
+	dict      *userDict
+}
+
+// NewManager create a new index manager with the data root
+func NewManager(root string) *Manager {
+	err := os.MkdirAll(root, 0700)
+	if err != nil {
+		log.Fatalf("Can not create directory: %s ; %v", root, err)
+	}
+
+	m := &Manager{
+		root:       root,
+		engines:    make(map[string]*Engine),
+		aliases:    make(map[string]string),
+		segmenters: make(map[string]sharedGse),
+	}
+
+	// 恢复别名
+	buf, err := ioutil.ReadFile(filepath.Join(root, aliasFile))
+	if err == nil {
+		err = json.Unmarshal(buf, &m.aliases)
+	}
+	if err != nil && !os.IsNotExist(err) {
+		log.Fatalf("Can not load the aliases: %v", err)
+	}
+
+	return m
+}
+
+// Root return the data root of the manager
+func (m *Manager) Root() string {
+	return m.root
+}
+
+func (m *Manager) path(name string) (string, error) {
+	if name == "" || name == "." || name == ".." ||
+		strings.ContainsAny(name, `/\`) {
+		return "", ErrInvalidIndexName
+	}
+
+	return filepath.Join(m.root, name), nil
+}
+
+func (m *Manager) exists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// dictPath 共享分词器的索引运行时加入和删除的词保存在数据目录下，
+// 每个词典一个文件
+func (m *Manager) dictPath(dict string) string {
+	name := fmt.Sprintf("user_dict_%08x.json", murmur.Sum32(dict))
+	return filepath.Join(m.root, name)
+}
+
+func (m *Manager) open(name, path string, opts types.EngineOpts) *Engine {
+	opts.StoreFolder = path
+
+	// 没有指定词典时使用默认的词典，见 initDef
+	dict := opts.GseDict
+	if dict == "" {
+		dict = "zh"
+	}
+
+	engine := &Engine{}
+	shared, ok := m.segmenters[dict]
+	if !opts.NotUseGse {
+		if ok {
+			engine.WithGse(shared.segmenter)
+		} else {
+			shared.dict = newUserDict(m.dictPath(dict))
+		}
+		engine.dict = shared.dict
+	}
+	engine.Init(opts)
+	if !ok && !opts.NotUseGse {
+		shared.segmenter = engine.segmenter
+		m.segmenters[dict] = shared
+	}
+	m.engines[name] = engine
+
+	return engine
+}
+
+// Create create a new index with the options,
+// opts.StoreFolder is set to root/name
+// 创建索引，索引已经存在时返回 ErrIndexExists
+func (m *Manager) Create(name string, opts types.EngineOpts) (*Engine, error) {
+	path, err := m.path(name)
+	if err != nil {
+		return nil, err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	_, aliased := m.aliases[name]
+	if _, ok := m.engines[name]; ok || aliased || m.exists(path) {
+		return nil, ErrIndexExists
+	}
+
+	if err := os.MkdirAll(path, 0700); err != nil {
+		return nil, err
+	}
+
+	return m.open(name, path, opts), nil
+}
+
+// Open open an existing index with the options,
+// return the engine directly if the index has been opened;
+// opts.StoreShards is replaced by the shard number of the existing store
+// 打开已经存在的索引，索引不存在时返回 ErrIndexNotFound
+func (m *Manager) Open(name string, opts types.EngineOpts) (*Engine, error) {
+	path, err := m.path(name)
+	if err != nil {
+		return nil, err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if engine, ok := m.engines[name]; ok {
+		return engine, nil
+	}
+
+	if !m.exists(path) {
+		return nil, ErrIndexNotFound
+	}
+
+	if err := RecoverReshard(path); err != nil {
+		return nil, err
+	}
+	if num := StoreShardsOf(path); num > 0 {
+		opts.StoreShards = num
+	}
+
+	return m.open(name, path, opts), nil
+}
+
+// Get get the opened index by the index name or alias
+func (m *Manager) Get(name string) (*Engine, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	if index, ok := m.aliases[name]; ok {
+		name = index
+	}
+
+	engine, ok := m.engines[name]
+	if !ok {
+		return nil, ErrIndexNotFound
+	}
+
+	return engine, nil
+}
+
+// List list the name of all the indexes under the root, sorted by name
+// 列出数据目录下的全部索引，包括没有打开的索引
+func (m *Manager) List() ([]string, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	infos, err := ioutil.ReadDir(m.root)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(infos))
+	for _, info := range infos {
+		if info.IsDir() {
+			names = append(names, info.Name())
+		}
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// Opened list the name of the opened indexes, sorted by name
+func (m *Manager) Opened() []string {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	names := make([]string, 0, len(m.engines))
+	for name := range m.engines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Close close the index, the data is kept and can be opened again
+func (m *Manager) Close(name string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	engine, ok := m.engines[name]
+	if !ok {
+		return ErrIndexNotFound
+	}
+	delete(m.engines, name)
+
+	return engine.Close()
+}
+
+// Drop close the index if opened and remove all its data
+// 删除索引及其全部数据
+func (m *Manager) Drop(name string) error {
+	path, err := m.path(name)
+	if err != nil {
+		return err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	engine, ok := m.engines[name]
+	if !ok && !m.exists(path) {
+		return ErrIndexNotFound
+	}
+
+	if ok {
+		delete(m.engines, name)
+		if err := engine.Close(); err != nil {
+			return err
+		}
+	}
+
+	// 删除指向该索引的别名
+	aliases := make(map[string]string, len(m.aliases))
+	for alias, index := range m.aliases {
+		if index != name {
+			aliases[alias] = index
+		}
+	}
+	if len(aliases) != len(m.aliases) {
+		if err := m.saveAliases(aliases); err != nil {
+			return err
+		}
+	}
+
+	return os.RemoveAll(path)
+}
+
+// CloseAll close all the opened indexes
+func (m *Manager) CloseAll() (err error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for name, engine := range m.engines {
+		delete(m.engines, name)
+		if e := engine.Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+
+	return
+}
+
+// saveAliases 持久化并替换别名，调用者需持有写锁
+func (m *Manager) saveAliases(aliases map[string]string) error {
+	buf, err := json.Marshal(aliases)
+	if err != nil {
+		return err
+	}
+
+	// 先写临时文件再重命名，保证别名文件完整
+	path := filepath.Join(m.root, aliasFile)
+	if err := ioutil.WriteFile(path+".tmp", buf, 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return err
+	}
+
+	m.aliases = aliases
+	return nil
+}
+
+// SetAlias point the alias at the index atomically,
+// the alias is created if it does not exist
+// 设置别名，已有的别名原子地切换到新的索引
+func (m *Manager) SetAlias(alias, index string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.setAlias(alias, index)
+}
+
+// setAlias 调用者需持有写锁
+func (m *Manager) setAlias(alias, index string) error {
+	path, err := m.path(alias)
+	if err != nil {
+		return err
+	}
+
+	indexPath, err := m.path(index)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := m.engines[alias]; ok || m.exists(path) {
+		// 别名不能和索引重名
+		return ErrIndexExists
+	}
+
+	if _, ok := m.engines[index]; !ok && !m.exists(indexPath) {
+		return ErrIndexNotFound
+	}
+
+	aliases := make(map[string]string, len(m.aliases)+1)
+	for k, v := range m.aliases {
+		aliases[k] = v
+	}
+	aliases[alias] = index
+
+	return m.saveAliases(aliases)
+}
+
+// RemoveAlias remove the alias, the index is kept
+func (m *Manager) RemoveAlias(alias string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.aliases[alias]; !ok {
+		return ErrAliasNotFound
+	}
+
+	aliases := make(map[string]string, len(m.aliases))
+	for k, v := range m.aliases {
+		if k != alias {
+			aliases[k] = v
+		}
+	}
+
+	return m.saveAliases(aliases)
+}
+
+// Alias get the index which the alias points at
+func (m *Manager) Alias(alias string) (string, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	index, ok := m.aliases[alias]
+	if !ok {
+		return "", ErrAliasNotFound
+	}
+
+	return index, nil
+}
+
+// Aliases get all the aliases, map from the alias to the index
+func (m *Manager) Aliases() map[string]string {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	aliases := make(map[string]string, len(m.aliases))
+	for k, v := range m.aliases {
+		aliases[k] = v
+	}
+
+	return aliases
+}
+
+// dirtyDocs 重建索引期间源索引中改变的文档，docId 到文档是否已删除，
+// docs 为 nil 时不记录
+type dirtyDocs struct {
+	sync.Mutex
+	docs map[string]bool
+}
+
+// track 记录改变的文档，在文档加入、更新和删除时调用
+func (engine *Engine) track(docId string, removed bool) {
+	d := &engine.dirty
+	d.Lock()
+	if d.docs != nil {
+		d.docs[docId] = removed
+	}
+	d.Unlock()
+}
+
+// startTracking 开始记录改变的文档，已经在记录时返回 false
+func (engine *Engine) startTracking() bool {
+	d := &engine.dirty
+	d.Lock()
+	defer d.Unlock()
+
+	if d.docs != nil {
+		return false
+	}
+
+	d.docs = make(map[string]bool)
+	return true
+}
+
+// takeDirty 取出记录的文档并继续记录
+func (engine *Engine) takeDirty() map[string]bool {
+	d := &engine.dirty
+	d.Lock()
+	defer d.Unlock()
+
+	docs := d.docs
+	d.docs = make(map[string]bool)
+	return docs
+}
+
+func (engine *Engine) stopTracking() {
+	engine.dirty.Lock()
+	engine.dirty.docs = nil
+	engine.dirty.Unlock()
+}
+
+// replay 把源索引中改变的文档写入新索引，调用者需保证
+// 这些文档已经写入源索引的存储，并且源索引没有关闭
+func replay(src, dst *Engine, docs map[string]bool) error {
+	for docId, removed := range docs {
+		if !removed {
+			data, err := src.storedDoc(docId)
+			if err == nil {
+				if err := dst.begin(); err != nil {
+					return err
+				}
+				err = dst.index(docId, data, false, true)
+				dst.end()
+				if err != nil {
+					return err
+				}
+				continue
+			}
+
+			if err != ErrDocNotFound {
+				return err
+			}
+		}
+
+		if err := dst.RemoveDoc(docId); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Reindex rebuild the index which the alias points at into the new
+// index dst with the options, then point the alias at dst
+// 重建索引
+//
+// 从旧索引的持久化存储中读取全部文档，按新的 opts（词典、停用词、
+// Using 等）批量加入新索引，期间旧索引照常提供服务。
+// 期间写入旧索引的文档被记录下来，复制完成后重放到新索引，
+// 最后一轮重放时短暂阻塞旧索引的请求并原子地切换别名，
+// 切换时被阻塞的写入在完成后也会重放。
+// 在切换之前通过别名取得旧索引、在重放结束之后才写入的请求
+// 只会写入旧索引，调用者需要每次写入时重新通过 Get 解析别名。
+// 旧索引保持打开，确认后可以 Drop。
+func (m *Manager) Reindex(alias, dst string, opts types.EngineOpts) (*Engine, error) {
+	index, err := m.Alias(alias)
+	if err != nil {
+		return nil, err
+	}
+
+	src, err := m.Get(index)
+	if err != nil {
+		return nil, err
+	}
+
+	if !src.initOptions.UseStore {
+		return nil, ErrNoStore
+	}
+
+	if !src.startTracking() {
+		return nil, ErrReindexing
+	}
+	defer src.stopTracking()
+
+	engine, err := m.Create(dst, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.copyDocs(src, engine)
+	if err == nil {
+		err = m.swap(alias, dst, src, engine)
+	}
+
+	if err != nil {
+		m.Drop(dst)
+		return nil, err
+	}
+
+	return engine, drain(src, engine)
+}
+
+// copyDocs 复制旧索引存储中的文档，然后重放期间改变的文档，
+// 剩下的文档不多或者重放 reindexRounds 轮后返回
+func (m *Manager) copyDocs(src, dst *Engine) error {
+	if err := src.begin(); err != nil {
+		return err
+	}
+	defer src.end()
+
+	// 开始记录之前的写入需要先写入存储
+	err := src.storeBarrier()
+	if err != nil {
+		return err
+	}
+
+	docs := make([]types.BatchDoc, 0, reindexBatchSize)
+	add := func() error {
+		for _, err := range dst.IndexBatch(docs) {
+			if err != nil {
+				return err
+			}
+		}
+		docs = docs[:0]
+		return nil
+	}
+
+	err = src.forEachDBDoc(func(docId string, data types.DocData) error {
+		docs = append(docs, types.BatchDoc{DocId: docId, Data: data})
+		if len(docs) < reindexBatchSize {
+			return nil
+		}
+		return add()
+	})
+	if err == nil {
+		err = add()
+	}
+
+	// 记录的文档已经发送到存储协程，等待写入后读取
+	for round := 0; err == nil && round < reindexRounds; round++ {
+		dirty := src.takeDirty()
+		if err = src.storeBarrier(); err == nil {
+			err = replay(src, dst, dirty)
+		}
+
+		if len(dirty) < reindexBatchSize {
+			break
+		}
+	}
+
+	return err
+}
+
+// swap 阻塞旧索引的请求，重放剩下的文档并切换别名
+func (m *Manager) swap(alias, dst string, src, engine *Engine) error {
+	// 和 Close 相同的加锁顺序
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	src.loc.Lock()
+	defer src.loc.Unlock()
+
+	if src.closed {
+		return ErrEngineClosed
+	}
+
+	src.flush()
+	if err := replay(src, engine, src.takeDirty()); err != nil {
+		return err
+	}
+
+	engine.Flush()
+	return m.setAlias(alias, dst)
+}
+
+// drain 切换别名时被阻塞的请求在切换之前取得了旧索引，
+// 等待它们完成并重放它们的写入
+func drain(src, dst *Engine) error {
+	// 被阻塞的请求在解锁后持有读锁，加写锁会等待它们完成
+	src.loc.Lock()
+	defer src.loc.Unlock()
+
+	if src.closed {
+		return nil
+	}
+
+	src.flush()
+	err := replay(src, dst, src.takeDirty())
+	dst.Flush()
+
+	return err
+}
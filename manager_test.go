@@ -0,0 +1,187 @@
+package riot
+
+import (
+	"encoding/gob"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-ego/riot/types"
+	"github.com/vcaesar/tt"
+)
+
+func managerOpts() types.EngineOpts {
+	return types.EngineOpts{
+		Using:       1,
+		GseDict:     "./testdata/test_dict.txt",
+		IndexerOpts: inxOpts,
+		UseStore:    true,
+		StoreShards: 2,
+	}
+}
+
+func TestManager(t *testing.T) {
+	root := "riot.manager"
+	m := NewManager(root)
+
+	books, err := m.Create("books", managerOpts())
+	tt.Nil(t, err)
+	news, err := m.Create("news", managerOpts())
+	tt.Nil(t, err)
+
+	_, err = m.Create("books", managerOpts())
+	tt.Equal(t, ErrIndexExists, err)
+	_, err = m.Create("../books", managerOpts())
+	tt.Equal(t, ErrInvalidIndexName, err)
+
+	books.Index("1", types.DocData{Content: "The world, 人口"})
+	books.Flush()
+	news.Index("1", types.DocData{Content: "有人口"})
+	news.Index("2", types.DocData{Content: "有七十亿人口"})
+	news.Flush()
+
+	tt.Expect(t, "1", books.NumDocsIndexed())
+	tt.Expect(t, "2", news.NumDocsIndexed())
+
+	// 相同词典的索引共享分词器
+	tt.True(t, books.dict == news.dict)
+	tt.Nil(t, books.AddWords(types.UserWord{Text: "七十亿人口"}))
+	tt.Expect(t, "[有 七十亿人口]", news.Segment("有七十亿人口"))
+	// 加入的词只在数据目录下保存一次
+	_, err = os.Stat(m.dictPath(managerOpts().GseDict))
+	tt.Nil(t, err)
+	_, err = os.Stat(filepath.Join(root, "news", UserDictFile))
+	tt.True(t, os.IsNotExist(err))
+	tt.Nil(t, books.RemoveWords("七十亿人口"))
+
+	names, err := m.List()
+	tt.Nil(t, err)
+	tt.Expect(t, "[books news]", names)
+	tt.Expect(t, "[books news]", m.Opened())
+
+	// 关闭后数据保留
+	tt.Nil(t, m.Close("books"))
+	tt.Expect(t, "[news]", m.Opened())
+	_, err = m.Get("books")
+	tt.Equal(t, ErrIndexNotFound, err)
+
+	// 使用已有存储的 shard 数目
+	opts := managerOpts()
+	opts.StoreShards = 3
+	books, err = m.Open("books", opts)
+	tt.Nil(t, err)
+	books.Flush()
+	tt.Expect(t, "1", books.NumDocsIndexed())
+	tt.Expect(t, "2", StoreShardsOf(books.initOptions.StoreFolder))
+
+	engine, err := m.Get("books")
+	tt.Nil(t, err)
+	tt.True(t, books == engine)
+
+	// 删除索引
+	tt.Nil(t, m.Drop("news"))
+	names, _ = m.List()
+	tt.Expect(t, "[books]", names)
+	_, err = m.Open("news", managerOpts())
+	tt.Equal(t, ErrIndexNotFound, err)
+
+	tt.Nil(t, m.CloseAll())
+	tt.Expect(t, "[]", m.Opened())
+	os.RemoveAll(root)
+}
+
+func TestReindex(t *testing.T) {
+	gob.Register(ScoringFields{})
+
+	root := "riot.reindex"
+	m := NewManager(root)
+
+	v1, err := m.Create("books_v1", managerOpts())
+	tt.Nil(t, err)
+	AddDocs(v1)
+
+	tt.Equal(t, ErrIndexNotFound, m.SetAlias("books", "books_v0"))
+	tt.Nil(t, m.SetAlias("books", "books_v1"))
+	tt.Equal(t, ErrIndexExists, m.SetAlias("books_v1", "books_v1"))
+	_, err = m.Create("books", managerOpts())
+	tt.Equal(t, ErrIndexExists, err)
+
+	engine, err := m.Get("books")
+	tt.Nil(t, err)
+	tt.True(t, engine == v1)
+
+	opts := managerOpts()
+	opts.NumShards = 2
+	v2, err := m.Reindex("books", "books_v2", opts)
+	tt.Nil(t, err)
+	tt.Expect(t, "6", v2.NumDocsIndexed())
+
+	index, err := m.Alias("books")
+	tt.Nil(t, err)
+	tt.Expect(t, "books_v2", index)
+
+	engine, _ = m.Get("books")
+	tt.True(t, engine == v2)
+
+	outputs := engine.Search(Req1)
+	tt.Expect(t, "3", outputs.NumDocs)
+
+	// 旧索引继续提供服务
+	outputs = v1.Search(Req1)
+	tt.Expect(t, "3", outputs.NumDocs)
+
+	tt.Nil(t, m.Drop("books_v1"))
+	tt.Nil(t, m.CloseAll())
+
+	// 别名持久化
+	m = NewManager(root)
+	tt.Expect(t, "map[books:books_v2]", m.Aliases())
+	tt.Nil(t, m.RemoveAlias("books"))
+	tt.Equal(t, ErrAliasNotFound, m.RemoveAlias("books"))
+
+	os.RemoveAll(root)
+}
+
+func TestReindexWrites(t *testing.T) {
+	gob.Register(ScoringFields{})
+
+	root := "riot.reindex.writes"
+	m := NewManager(root)
+
+	v1, err := m.Create("books_v1", managerOpts())
+	tt.Nil(t, err)
+	AddDocs(v1)
+	tt.Nil(t, m.SetAlias("books", "books_v1"))
+
+	tt.True(t, v1.startTracking())
+	_, err = m.Reindex("books", "books_v2", managerOpts())
+	tt.Equal(t, ErrReindexing, err)
+
+	v2, err := m.Create("books_v2", managerOpts())
+	tt.Nil(t, err)
+	tt.Nil(t, m.copyDocs(v1, v2))
+
+	// 复制之后写入旧索引的文档在切换别名时重放
+	tt.Nil(t, v1.Index("7", types.DocData{Content: "The world, 人口"}))
+	tt.Nil(t, v1.Index("2", types.DocData{Content: "有人口"}))
+	tt.Nil(t, v1.RemoveDoc("1"))
+
+	tt.Nil(t, m.swap("books", "books_v2", v1, v2))
+	tt.Nil(t, drain(v1, v2))
+	v1.stopTracking()
+
+	engine, _ := m.Get("books")
+	tt.True(t, engine == v2)
+
+	outputs := v2.Search(Req1)
+	tt.Expect(t, "2", outputs.NumDocs)
+	docs := outputs.Docs.(types.ScoredDocs)
+	tt.Expect(t, "7", docs[0].DocId)
+	tt.Expect(t, "5", docs[1].DocId)
+
+	_, err = v2.GetDoc("1")
+	tt.Equal(t, ErrDocNotFound, err)
+
+	tt.Nil(t, m.CloseAll())
+	os.RemoveAll(root)
+}
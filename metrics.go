@@ -0,0 +1,276 @@
+// Copyright 2016 ego authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package riot
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-ego/riot/types"
+)
+
+// latencyBuckets 延迟直方图的上界（秒）
+var latencyBuckets = [...]float64{
+	0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025,
+	0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10,
+}
+
+// buckets 直方图每个上界的计数、总数和总和
+type buckets struct {
+	counts [len(latencyBuckets)]uint64
+	count  uint64
+	sum    float64
+}
+
+// histogram 延迟直方图，零值可用
+type histogram struct {
+	sync.Mutex
+	buckets
+}
+
+func (h *histogram) observe(d time.Duration) {
+	v := d.Seconds()
+
+	h.Lock()
+	defer h.Unlock()
+
+	for i, le := range latencyBuckets {
+		if v <= le {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += v
+}
+
+func (h *histogram) snapshot() buckets {
+	h.Lock()
+	defer h.Unlock()
+
+	return h.buckets
+}
+
+// metrics 引擎的运行指标，计数器之外的部分
+type metrics struct {
+	search     histogram
+	storeWrite histogram
+
+	searchErrors   uint64
+	searchTimeouts uint64
+
+	queryHits   uint64
+	queryMisses uint64
+}
+
+// searched 记录一次搜索的延迟和结果
+func (engine *Engine) searched(start time.Time, output *types.SearchResp) {
+	engine.metrics.search.observe(time.Since(start))
+
+	if output.Err != nil {
+		atomic.AddUint64(&engine.metrics.searchErrors, 1)
+	}
+	if output.Timeout {
+		atomic.AddUint64(&engine.metrics.searchTimeouts, 1)
+	}
+}
+
+// metricWriter 按 Prometheus 文本格式输出指标
+type metricWriter struct {
+	w *bytes.Buffer
+}
+
+func (m metricWriter) head(name, kind, help string) {
+	fmt.Fprintf(m.w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func (m metricWriter) value(name, labels string, v float64) {
+	if labels != "" {
+		labels = "{" + labels + "}"
+	}
+	fmt.Fprintf(m.w, "%s%s %s\n", name, labels,
+		strconv.FormatFloat(v, 'g', -1, 64))
+}
+
+func (m metricWriter) single(name, kind, help string, v float64) {
+	m.head(name, kind, help)
+	m.value(name, "", v)
+}
+
+func (m metricWriter) histogram(name, help string, h buckets) {
+	m.head(name, "histogram", help)
+	for i, le := range latencyBuckets {
+		m.value(name+"_bucket", `le="`+
+			strconv.FormatFloat(le, 'g', -1, 64)+`"`, float64(h.counts[i]))
+	}
+	m.value(name+"_bucket", `le="+Inf"`, float64(h.count))
+	m.value(name+"_sum", "", h.sum)
+	m.value(name+"_count", "", float64(h.count))
+}
+
+func shardLabel(shard int) string {
+	return `shard="` + strconv.Itoa(shard) + `"`
+}
+
+// WriteMetrics write the metrics of the engine in the
+// Prometheus text exposition format
+// 输出引擎的运行指标：文档计数、搜索和存储写入的延迟直方图、
+// 各通道的积压和每个 shard 的文档数和关键词数
+func (engine *Engine) WriteMetrics(w io.Writer) error {
+	buf, err := engine.renderMetrics()
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(buf)
+	return err
+}
+
+// renderMetrics 在 begin 和 end 之间输出到内存中，
+// 写入调用者的 io.Writer 时不阻塞 Close
+func (engine *Engine) renderMetrics() ([]byte, error) {
+	if err := engine.begin(); err != nil {
+		return nil, err
+	}
+	defer engine.end()
+
+	m := metricWriter{w: &bytes.Buffer{}}
+
+	m.single("riot_docs_indexed_total", "counter",
+		"Number of the documents indexed.",
+		float64(atomic.LoadUint64(&engine.numDocsIndexed)))
+	m.single("riot_docs_removed_total", "counter",
+		"Number of the documents removed.",
+		float64(atomic.LoadUint64(&engine.numDocsRemoved)))
+	m.single("riot_docs_stored_total", "counter",
+		"Number of the documents written to the store.",
+		float64(atomic.LoadUint64(&engine.numDocsStored)))
+	m.single("riot_tokens_added_total", "counter",
+		"Number of the token indexes added.",
+		float64(atomic.LoadUint64(&engine.numTokenIndexAdded)))
+	m.single("riot_index_requests_total", "counter",
+		"Number of the index requests.",
+		float64(atomic.LoadUint64(&engine.numIndexingReqs)))
+	m.single("riot_remove_requests_total", "counter",
+		"Number of the remove requests.",
+		float64(atomic.LoadUint64(&engine.numRemovingReqs)))
+
+	m.histogram("riot_search_duration_seconds",
+		"Latency of the searches.", engine.metrics.search.snapshot())
+	m.single("riot_search_errors_total", "counter",
+		"Number of the failed searches.",
+		float64(atomic.LoadUint64(&engine.metrics.searchErrors)))
+	m.single("riot_search_timeouts_total", "counter",
+		"Number of the searches which timed out.",
+		float64(atomic.LoadUint64(&engine.metrics.searchTimeouts)))
+	m.histogram("riot_store_write_duration_seconds",
+		"Latency of the store writes.", engine.metrics.storeWrite.snapshot())
+
+	// 通道积压
+	m.head("riot_queue_length", "gauge",
+		"Number of the requests waiting in the channel.")
+	m.value("riot_queue_length", `queue="segmenter"`,
+		float64(len(engine.segmenterChan)))
+	queues := []struct {
+		name string
+		len  func(shard int) int
+	}{
+		{"indexer_add", func(s int) int { return len(engine.indexerAddDocChans[s]) }},
+		{"indexer_remove", func(s int) int { return len(engine.indexerRemoveDocChans[s]) }},
+		{"indexer_lookup", func(s int) int { return len(engine.indexerLookupChans[s]) }},
+		{"ranker_add", func(s int) int { return len(engine.rankerAddDocChans[s]) }},
+		{"ranker_rank", func(s int) int { return len(engine.rankerRankChans[s]) }},
+	}
+	for _, queue := range queues {
+		for shard := 0; shard < engine.initOptions.NumShards; shard++ {
+			m.value("riot_queue_length", `queue="`+queue.name+`",`+
+				shardLabel(shard), float64(queue.len(shard)))
+		}
+	}
+	if engine.initOptions.UseStore {
+		for shard := range engine.storeIndexDocChans {
+			m.value("riot_queue_length", `queue="store",`+shardLabel(shard),
+				float64(len(engine.storeIndexDocChans[shard])))
+		}
+	}
+
+	// 每个 shard 的文档数和关键词数
+	m.head("riot_shard_docs", "gauge", "Number of the documents in the shard.")
+	for shard := range engine.indexers {
+		m.value("riot_shard_docs", shardLabel(shard),
+			float64(engine.indexers[shard].NumDocs()))
+	}
+	m.head("riot_shard_tokens", "gauge",
+		"Number of the distinct tokens in the shard.")
+	for shard := range engine.indexers {
+		m.value("riot_shard_tokens", shardLabel(shard),
+			float64(engine.indexers[shard].NumTokens()))
+	}
+
+	hits, misses := engine.QueryCacheStats()
+	m.single("riot_query_cache_hits_total", "counter",
+		"Number of the shard results served from the query cache.", float64(hits))
+	m.single("riot_query_cache_misses_total", "counter",
+		"Number of the shard results not found in the query cache.", float64(misses))
+	m.head("riot_query_cache_entries", "gauge",
+		"Number of the results in the query cache of the shard.")
+	for shard, cache := range engine.queryCaches {
+		m.value("riot_query_cache_entries", shardLabel(shard), float64(cache.len()))
+	}
+
+	hits, misses = engine.FilterCacheStats()
+	m.single("riot_filter_cache_hits_total", "counter",
+		"Number of the filter bitmaps served from the filter cache.", float64(hits))
+	m.single("riot_filter_cache_misses_total", "counter",
+		"Number of the filter bitmaps not found in the filter cache.", float64(misses))
+	m.head("riot_filter_cache_entries", "gauge",
+		"Number of the bitmaps in the filter cache of the shard.")
+	for shard := range engine.indexers {
+		_, _, entries := engine.indexers[shard].FilterCacheStats()
+		m.value("riot_filter_cache_entries", shardLabel(shard), float64(entries))
+	}
+
+	m.single("riot_content_cache_docs", "gauge",
+		"Number of the documents in the content cache.",
+		float64(engine.contents.len()))
+	engine.expiry.RLock()
+	numExpiry := len(engine.expiry.docs)
+	engine.expiry.RUnlock()
+	m.single("riot_expiring_docs", "gauge",
+		"Number of the documents with an expiry time.", float64(numExpiry))
+
+	return m.w.Bytes(), nil
+}
+
+// MetricsHandler return the http handler which serves the metrics
+// of the engine in the Prometheus text exposition format
+func (engine *Engine) MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		// 全部输出之后再写入，出错时还没有写入任何内容
+		buf, err := engine.renderMetrics()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		w.Write(buf)
+	})
+}
@@ -0,0 +1,72 @@
+package riot
+
+import (
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/go-ego/riot/types"
+	"github.com/vcaesar/tt"
+)
+
+func TestMetrics(t *testing.T) {
+	os.RemoveAll("riot.metrics")
+	defer os.RemoveAll("riot.metrics")
+
+	var engine Engine
+	engine.Init(types.EngineOpts{
+		Using:       1,
+		GseDict:     "./testdata/test_dict.txt",
+		IndexerOpts: inxOpts,
+		NumShards:   2,
+		UseStore:    true,
+		StoreFolder: "riot.metrics",
+		StoreShards: 2,
+	})
+
+	engine.Index("1", types.DocData{Content: "The world"})
+	engine.Index("2", types.DocData{Content: "The world, 人口"})
+	engine.Flush()
+	engine.Search(types.SearchReq{Text: "world"})
+	engine.Search(types.SearchReq{Text: "人口"})
+
+	rec := httptest.NewRecorder()
+	engine.MetricsHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	tt.Equal(t, 200, rec.Code)
+	tt.True(t, strings.HasPrefix(rec.Header().Get("Content-Type"), "text/plain"))
+
+	body := rec.Body.String()
+	lines := []string{
+		"# TYPE riot_docs_indexed_total counter",
+		"riot_docs_indexed_total 2",
+		"# TYPE riot_search_duration_seconds histogram",
+		`riot_search_duration_seconds_bucket{le="+Inf"} 2`,
+		"riot_search_duration_seconds_count 2",
+		"riot_store_write_duration_seconds_count 2",
+		`riot_queue_length{queue="segmenter"} 0`,
+		`riot_queue_length{queue="indexer_add",shard="1"} 0`,
+		`riot_queue_length{queue="store",shard="0"} 0`,
+		"riot_search_errors_total 0",
+	}
+	for _, line := range lines {
+		tt.True(t, strings.Contains(body, line+"\n"), line)
+	}
+
+	var docs, tokens int
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(line, "riot_shard_docs{") {
+			docs += int(line[len(line)-1] - '0')
+		}
+		if strings.HasPrefix(line, "riot_shard_tokens{") {
+			tokens++
+		}
+	}
+	tt.Equal(t, 2, docs)
+	tt.Equal(t, 2, tokens)
+
+	engine.Close()
+	rec = httptest.NewRecorder()
+	engine.MetricsHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	tt.Equal(t, 503, rec.Code)
+}
@@ -14,6 +14,8 @@
 
 package com
 
+import "github.com/go-ego/riot/types"
+
 // Config search config options
 type Config struct {
 	Engine Engine
@@ -28,14 +30,37 @@ type Config struct {
 type Engine struct {
 	Mode  string
 	Using int
+	// 索引和查询使用的分析器，为空时由 Using 决定
+	Analyzer       string `toml:"analyzer"`
+	SearchAnalyzer string `toml:"search_analyzer"`
+	// 索引和查询都使用的字符过滤器和关键词过滤器
+	CharFilters  []string `toml:"char_filters"`
+	TokenFilters []string `toml:"token_filters"`
+	// 子字段，比如使用 ngram 分析器的子字段
+	SubFields []types.SubField `toml:"sub_fields"`
 
 	StoreShards int    `toml:"store_shards"`
 	StoreEngine string `toml:"store_engine"`
 	StoreFolder string `toml:"store_folder"`
+	// 命名索引的数据目录，为空时只使用默认索引；
+	// 启动时打开目录下的全部索引，命名索引使用和默认索引相同的选项
+	IndexRoot string `toml:"index_root"`
 
 	NumShards    int `toml:"num_shards"`
 	OutputOffset int `toml:"output_offset"`
 	MaxOutputs   int `toml:"max_outputs"`
+	// 索引自动刷新间隔（毫秒），0 表示不自动刷新
+	RefreshInterval int `toml:"refresh_interval"`
+	// 文档默认的存活时间和过期文档的清理间隔（毫秒）
+	TTL           int `toml:"ttl"`
+	SweepInterval int `toml:"sweep_interval"`
+	// 慢查询的阈值（毫秒）和日志文件
+	SlowQuery     int    `toml:"slow_query"`
+	SlowQueryFile string `toml:"slow_query_file"`
+	// 每个 shard 缓存的查询结果数
+	QueryCacheSize int `toml:"query_cache_size"`
+	// 保留的文档变更事件数，0 表示不记录
+	ChangeLogSize int `toml:"change_log_size"`
 
 	GseDict       string `toml:"gse_dict"`
 	GseMode       string `toml:"gse_mode"`
@@ -0,0 +1,152 @@
+// Copyright 2016 ego authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package com
+
+import (
+	"errors"
+	"log"
+
+	"github.com/go-ego/riot"
+	"github.com/go-ego/riot/types"
+)
+
+var (
+	// ErrNoIndexRoot is returned when managing the named indexes
+	// without the index_root config
+	ErrNoIndexRoot = errors.New("the index_root is not configured")
+
+	// IndexOpts the options of the named indexes,
+	// the StoreFolder is set by the index manager
+	IndexOpts types.EngineOpts
+)
+
+// IndexInfo the named index
+type IndexInfo struct {
+	Name           string `json:"name"`
+	Opened         bool   `json:"opened"`
+	NumDocsIndexed uint64 `json:"num_docs_indexed"`
+}
+
+// InitIndexes init the index manager with the data root
+// and open all the indexes under the root with the options
+func InitIndexes(root string, opts types.EngineOpts) {
+	IndexOpts = opts
+	Indexes = riot.NewManager(root)
+
+	names, err := Indexes.List()
+	if err != nil {
+		log.Fatalf("Can not list the indexes in %s: %v", root, err)
+	}
+
+	for _, name := range names {
+		engine, err := Indexes.Open(name, indexOpts())
+		if err != nil {
+			log.Fatalf("Can not open the index %s: %v", name, err)
+		}
+
+		engine.Flush()
+		log.Println("recover index ", name, " number: ", engine.NumDocsIndexed())
+	}
+}
+
+// indexOpts 每个索引使用单独的 IndexerOpts
+func indexOpts() types.EngineOpts {
+	opts := IndexOpts
+	if opts.IndexerOpts != nil {
+		indexer := *opts.IndexerOpts
+		opts.IndexerOpts = &indexer
+	}
+
+	return opts
+}
+
+func manager() (*riot.Manager, error) {
+	if Indexes == nil {
+		return nil, ErrNoIndexRoot
+	}
+
+	return Indexes, nil
+}
+
+// CreateIndex create the named index with IndexOpts
+func CreateIndex(name string) error {
+	m, err := manager()
+	if err != nil {
+		return err
+	}
+
+	_, err = m.Create(name, indexOpts())
+	return err
+}
+
+// OpenIndex open the named index which has been closed
+func OpenIndex(name string) error {
+	m, err := manager()
+	if err != nil {
+		return err
+	}
+
+	engine, err := m.Open(name, indexOpts())
+	if err != nil {
+		return err
+	}
+
+	engine.Flush()
+	return nil
+}
+
+// CloseIndex close the named index, the data is kept
+func CloseIndex(name string) error {
+	m, err := manager()
+	if err != nil {
+		return err
+	}
+
+	return m.Close(name)
+}
+
+// DropIndex close the named index and remove all its data
+func DropIndex(name string) error {
+	m, err := manager()
+	if err != nil {
+		return err
+	}
+
+	return m.Drop(name)
+}
+
+// ListIndexes list all the named indexes and the aliases
+func ListIndexes() ([]IndexInfo, map[string]string, error) {
+	m, err := manager()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	names, err := m.List()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	infos := make([]IndexInfo, len(names))
+	for i, name := range names {
+		infos[i].Name = name
+		if engine, err := m.Get(name); err == nil {
+			infos[i].Opened = true
+			infos[i].NumDocsIndexed = engine.NumDocsIndexed()
+		}
+	}
+
+	return infos, m.Aliases(), nil
+}
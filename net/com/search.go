@@ -15,6 +15,7 @@
 package com
 
 import (
+	"errors"
 	"log"
 	"os"
 
@@ -23,13 +24,32 @@ import (
 	// "github.com/go-vgo/gt/zlog"
 )
 
+// ErrTaskNotFound is returned when the task does not exist
+var ErrTaskNotFound = errors.New("task not found")
+
 var (
 	// Searcher is coroutine safe
 	Searcher = riot.Engine{}
+	// Indexes manage the named indexes, nil if not initialized
+	Indexes *riot.Manager
 	// Conf is config
 	Conf Config
 )
 
+// GetEngine get the engine by the index name,
+// the empty name is the default Searcher
+func GetEngine(index string) (*riot.Engine, error) {
+	if index == "" {
+		return &Searcher, nil
+	}
+
+	if Indexes == nil {
+		return nil, riot.ErrIndexNotFound
+	}
+
+	return Indexes.Get(index)
+}
+
 // InitEngine init engine
 func InitEngine(conf Config) {
 	// os.RemoveAll("./riot-index")
@@ -66,23 +86,42 @@ func InitEngine(conf Config) {
 	storageEngine := conf.Engine.StoreEngine
 	stopTokenFile := conf.Engine.StopTokenFile
 
-	Searcher.Init(types.EngineOpts{
-		Using:       using,
-		StoreShards: storageShards,
-		NumShards:   numShards,
+	opts := types.EngineOpts{
+		Using:          using,
+		Analyzer:       conf.Engine.Analyzer,
+		SearchAnalyzer: conf.Engine.SearchAnalyzer,
+		CharFilters:    conf.Engine.CharFilters,
+		TokenFilters:   conf.Engine.TokenFilters,
+		SubFields:      conf.Engine.SubFields,
+		StoreShards:    storageShards,
+		NumShards:      numShards,
 		IndexerOpts: &types.IndexerOpts{
-			IndexType: types.DocIdsIndex,
+			IndexType:       types.DocIdsIndex,
+			RefreshInterval: conf.Engine.RefreshInterval,
 		},
 		UseStore:      true,
 		StoreFolder:   path,
 		StoreEngine:   storageEngine,
 		GseDict:       segmentDict,
 		StopTokenFile: stopTokenFile,
-	})
+		TTL:           conf.Engine.TTL,
+		SweepInterval: conf.Engine.SweepInterval,
+		SlowQuery:     conf.Engine.SlowQuery,
+		SlowQueryFile: conf.Engine.SlowQueryFile,
+
+		QueryCacheSize: conf.Engine.QueryCacheSize,
+		ChangeLogSize:  conf.Engine.ChangeLogSize,
+	}
+	Searcher.Init(opts)
 
 	// defer Searcher.Close()
 	os.MkdirAll(path, 0777)
 
+	if conf.Engine.IndexRoot != "" {
+		// 命名索引使用和默认索引相同的选项
+		InitIndexes(conf.Engine.IndexRoot, opts)
+	}
+
 	// 等待索引刷新完毕
 	Searcher.Flush()
 
@@ -90,36 +129,73 @@ func InitEngine(conf Config) {
 
 }
 
-// AddDocInx add index document
-func AddDocInx(docId string, data types.DocData, forceUpdate bool) {
-	Searcher.Index(docId, data, forceUpdate)
+// AddDocInx add index document to the index
+func AddDocInx(index, docId string, data types.DocData, forceUpdate bool) error {
+	engine, err := GetEngine(index)
+	if err != nil {
+		return err
+	}
+
+	return engine.Index(docId, data, forceUpdate)
 	// Searcher.Flush()
 }
 
-// Flush flsuh the Searcher engine
-func Flush() {
-	Searcher.Flush()
+// AddDocsInx add index documents to the index in batch
+func AddDocsInx(index string, docs []types.BatchDoc, forceUpdate bool) []error {
+	engine, err := GetEngine(index)
+	if err != nil {
+		errs := make([]error, len(docs))
+		for i := range errs {
+			errs[i] = err
+		}
+		return errs
+	}
+
+	return engine.IndexBatch(docs, forceUpdate)
+}
+
+// Flush flsuh the engine of the index
+func Flush(index string) error {
+	engine, err := GetEngine(index)
+	if err != nil {
+		return err
+	}
+
+	engine.Flush()
+	return nil
 }
 
 // SearchArgs search args
 type SearchArgs struct {
+	Index                    string
 	Id, Query, Time          string
 	OutputOffset, MaxOutputs int
 	DocIds                   map[string]bool
+	Filters                  []types.Filter
+	SubField                 string
 	Logic                    types.Logic
 	// fn                       func(*SearchArgs)
 }
 
-// Search search
+// Search search the index, the Err of the response is
+// riot.ErrIndexNotFound if the index does not exist
 func Search(sea SearchArgs) types.SearchResp {
 
 	var docs types.SearchResp
 
-	docs = Searcher.Search(types.SearchReq{
+	engine, err := GetEngine(sea.Index)
+	if err != nil {
+		docs.Err = err
+		return docs
+	}
+
+	docs = engine.Search(types.SearchReq{
 		Text: sea.Query,
 		// NotUseGse: true,
-		DocIds: sea.DocIds,
-		Logic:  sea.Logic,
+		DocIds:   sea.DocIds,
+		Filters:  sea.Filters,
+		SubField: sea.SubField,
+		Logic:    sea.Logic,
 		RankOpts: &types.RankOpts{
 			OutputOffset: sea.OutputOffset,
 			MaxOutputs:   sea.MaxOutputs,
@@ -128,7 +204,145 @@ func Search(sea SearchArgs) types.SearchResp {
 	return docs
 }
 
-// Delete delete document
-func Delete(docid string, forceUpdate bool) {
-	Searcher.RemoveDoc(docid, forceUpdate)
+// Delete delete document from the index
+func Delete(index, docid string, forceUpdate bool) error {
+	return DeleteVersion(index, docid, 0, forceUpdate)
+}
+
+// DeleteVersion delete the doc with the version from the index
+func DeleteVersion(index, docid string, version uint64, forceUpdate bool) error {
+	engine, err := GetEngine(index)
+	if err != nil {
+		return err
+	}
+
+	return engine.RemoveDocVersion(docid, version, forceUpdate)
+}
+
+// GetDoc get the document from the index
+func GetDoc(index, docid string) (types.DocData, error) {
+	engine, err := GetEngine(index)
+	if err != nil {
+		return types.DocData{}, err
+	}
+
+	return engine.GetDoc(docid)
+}
+
+// MultiGet get the documents from the index
+func MultiGet(index string, docids []string) ([]types.DocData, []error) {
+	engine, err := GetEngine(index)
+	if err != nil {
+		errs := make([]error, len(docids))
+		for i := range errs {
+			errs[i] = err
+		}
+		return make([]types.DocData, len(docids)), errs
+	}
+
+	return engine.MultiGet(docids)
+}
+
+// AddWords add the words to the dictionary of the index and block until
+// the dictionary is loaded again, the documents containing the words are
+// segmented again in a background task if reanalyze is true, the id of
+// the task is returned
+func AddWords(index string, words []types.UserWord, reanalyze bool) (string, error) {
+	engine, err := GetEngine(index)
+	if err != nil {
+		return "", err
+	}
+
+	if err := engine.AddWords(words...); err != nil {
+		return "", err
+	}
+
+	texts := make([]string, len(words))
+	for i, word := range words {
+		texts[i] = word.Text
+	}
+	return reanalyzeDocs(engine, texts, reanalyze)
+}
+
+// RemoveWords remove the words from the dictionary of the index,
+// see AddWords
+func RemoveWords(index string, texts []string, reanalyze bool) (string, error) {
+	engine, err := GetEngine(index)
+	if err != nil {
+		return "", err
+	}
+
+	if err := engine.RemoveWords(texts...); err != nil {
+		return "", err
+	}
+
+	return reanalyzeDocs(engine, texts, reanalyze)
+}
+
+func reanalyzeDocs(engine *riot.Engine, texts []string, reanalyze bool) (string, error) {
+	if !reanalyze {
+		return "", nil
+	}
+
+	task, err := engine.ReanalyzeDocs(texts)
+	if err != nil {
+		return "", err
+	}
+	return task.Id(), nil
+}
+
+// UserWords get the words added to the dictionary of the index
+func UserWords(index string) ([]types.UserWord, error) {
+	engine, err := GetEngine(index)
+	if err != nil {
+		return nil, err
+	}
+
+	return engine.UserWords(), nil
+}
+
+// TaskFailure a document which the task failed to process
+type TaskFailure struct {
+	DocId string `json:"doc_id"`
+	Err   string `json:"err"`
+}
+
+// TaskInfo the status of the background task
+type TaskInfo struct {
+	Id       string        `json:"id"`
+	Kind     string        `json:"kind"`
+	Total    int           `json:"total"`
+	Done     int           `json:"done"`
+	Failed   int           `json:"failed"`
+	Failures []TaskFailure `json:"failures,omitempty"`
+	Canceled bool          `json:"canceled"`
+	Finished bool          `json:"finished"`
+}
+
+// Task get the status of the background task of the index, such as
+// the task returned by AddWords, and cancel the task if cancel is true
+func Task(index, id string, cancel bool) (TaskInfo, error) {
+	engine, err := GetEngine(index)
+	if err != nil {
+		return TaskInfo{}, err
+	}
+
+	task, ok := engine.Task(id)
+	if !ok {
+		return TaskInfo{}, ErrTaskNotFound
+	}
+	if cancel {
+		task.Cancel()
+	}
+
+	status := task.Status()
+	info := TaskInfo{Id: status.Id, Kind: status.Kind, Total: status.Total,
+		Done: status.Done, Failed: status.Failed,
+		Canceled: status.Canceled, Finished: status.Finished}
+	for _, failure := range status.Failures {
+		info.Failures = append(info.Failures,
+			TaskFailure{DocId: failure.DocId, Err: failure.Err.Error()})
+	}
+
+	return info, nil
 }
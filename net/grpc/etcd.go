@@ -76,14 +76,60 @@ func (s *eserver) HeartBeat(ctx context.Context, in *pb.HeartReq) (*pb.Reply, er
 }
 
 func (s *eserver) DocInx(ctx context.Context, in *pb.DocReq) (*pb.Reply, error) {
-	addDoc(in)
-	return &pb.Reply{Result: 0}, nil
+	return reply(addDoc(in)), nil
+}
+
+func (s *eserver) DocsInx(ctx context.Context, in *pb.DocsReq) (*pb.DocsReply, error) {
+	return addDocs(in), nil
 }
 
 func (s *eserver) Delete(ctx context.Context, in *pb.DeleteReq) (*pb.Reply, error) {
 
-	DelDoc(in)
-	return &pb.Reply{Result: 0}, nil
+	return reply(DelDoc(in)), nil
+}
+
+func (s *eserver) GetDoc(ctx context.Context, in *pb.GetReq) (*pb.StoredDoc, error) {
+	return GetDoc(in), nil
+}
+
+func (s *eserver) MultiGet(ctx context.Context, in *pb.MultiGetReq) (*pb.MultiGetReply, error) {
+	return MultiGet(in), nil
+}
+
+func (s *eserver) CreateIndex(ctx context.Context, in *pb.IndexReq) (*pb.Reply, error) {
+	return reply(com.CreateIndex(in.Index)), nil
+}
+
+func (s *eserver) OpenIndex(ctx context.Context, in *pb.IndexReq) (*pb.Reply, error) {
+	return reply(com.OpenIndex(in.Index)), nil
+}
+
+func (s *eserver) CloseIndex(ctx context.Context, in *pb.IndexReq) (*pb.Reply, error) {
+	return reply(com.CloseIndex(in.Index)), nil
+}
+
+func (s *eserver) DropIndex(ctx context.Context, in *pb.IndexReq) (*pb.Reply, error) {
+	return reply(com.DropIndex(in.Index)), nil
+}
+
+func (s *eserver) ListIndexes(ctx context.Context, in *pb.IndexReq) (*pb.IndexesReply, error) {
+	return ListIndexes(in), nil
+}
+
+func (s *eserver) AddWords(ctx context.Context, in *pb.WordsReq) (*pb.WordsReply, error) {
+	return AddWords(in), nil
+}
+
+func (s *eserver) RemoveWords(ctx context.Context, in *pb.WordsReq) (*pb.WordsReply, error) {
+	return RemoveWords(in), nil
+}
+
+func (s *eserver) UserWords(ctx context.Context, in *pb.WordsReq) (*pb.WordsReply, error) {
+	return UserWords(in), nil
+}
+
+func (s *eserver) Task(ctx context.Context, in *pb.TaskReq) (*pb.TaskReply, error) {
+	return Task(in), nil
 }
 
 func (s *eserver) Search(ctx context.Context, in *pb.SearchReq) (*pb.SearchReply, error) {
@@ -115,10 +161,13 @@ func (s *eserver) Search(ctx context.Context, in *pb.SearchReq) (*pb.SearchReply
 	}
 
 	sea := com.SearchArgs{
+		Index:        in.Index,
 		Id:           in.Id,
 		Query:        in.Query,
 		Time:         in.Time,
 		DocIds:       in.DocIds,
+		Filters:      filters(in.Filters),
+		SubField:     in.SubField,
 		OutputOffset: outputOffset,
 		MaxOutputs:   maxOutputs,
 		Logic:        logic,
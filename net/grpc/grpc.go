@@ -17,6 +17,7 @@ package grpc
 import (
 	"log"
 	"net"
+	"sort"
 
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
@@ -32,7 +33,7 @@ import (
 // server is used to implement msg.GreeterServer.
 type server struct{}
 
-func addDoc(in *pb.DocReq) {
+func docData(in *pb.DocReq) types.DocData {
 	tokens := []types.TokenData{}
 	for i := 0; i < len(in.Tokens); i++ {
 		var loc []int
@@ -60,20 +61,193 @@ func addDoc(in *pb.DocReq) {
 		Ts:   req.Ts,
 	}
 
-	data := types.DocData{
-		Content: in.Content,
-		Attri:   attri,
-		Tokens:  tokens,
+	return types.DocData{
+		Content:  in.Content,
+		Attri:    attri,
+		Tokens:   tokens,
+		Version:  in.Version,
+		Routing:  in.Routing,
+		ExpireAt: in.ExpireAt,
 		// Labels: in.Labels,
 		// Fields: in.Fields,
 	}
+}
 
-	com.AddDocInx(in.DocId, data, in.ForceUpdate)
+func addDoc(in *pb.DocReq) error {
+	return com.AddDocInx(in.Index, in.DocId, docData(in), in.ForceUpdate)
+}
+
+func addDocs(in *pb.DocsReq) *pb.DocsReply {
+	docs := make([]types.BatchDoc, len(in.Docs))
+	for i, doc := range in.Docs {
+		docs[i] = types.BatchDoc{DocId: doc.DocId, Data: docData(doc)}
+	}
+
+	errs := com.AddDocsInx(in.Index, docs, in.ForceUpdate)
+	rep := &pb.DocsReply{Results: make([]*pb.DocResult, len(docs))}
+	for i, err := range errs {
+		rep.Results[i] = &pb.DocResult{DocId: docs[i].DocId}
+		if err != nil {
+			rep.Results[i].Result = 1
+			rep.Results[i].Msg = err.Error()
+		}
+	}
+
+	return rep
 }
 
 // DelDoc delete doc
-func DelDoc(in *pb.DeleteReq) {
-	searcher.RemoveDoc(in.DocId, false)
+func DelDoc(in *pb.DeleteReq) error {
+	return com.DeleteVersion(in.Index, in.DocId, in.Version, false)
+}
+
+func storedDoc(docId string, data types.DocData, err error) *pb.StoredDoc {
+	if err != nil {
+		return &pb.StoredDoc{DocId: docId, Result: 1, Msg: err.Error()}
+	}
+
+	doc := &pb.StoredDoc{
+		DocId:    docId,
+		Content:  data.Content,
+		Labels:   data.Labels,
+		Version:  data.Version,
+		Routing:  data.Routing,
+		ExpireAt: data.ExpireAt,
+	}
+
+	if attri, ok := data.Attri.(types.Attri); ok {
+		doc.Attri = &pb.Attri{
+			Title:  attri.Title,
+			Author: attri.Author,
+			Time:   attri.Time,
+			Ts:     attri.Ts,
+		}
+	}
+
+	return doc
+}
+
+// GetDoc get the stored document
+func GetDoc(in *pb.GetReq) *pb.StoredDoc {
+	data, err := com.GetDoc(in.Index, in.DocId)
+	return storedDoc(in.DocId, data, err)
+}
+
+// MultiGet get the stored documents
+func MultiGet(in *pb.MultiGetReq) *pb.MultiGetReply {
+	datas, errs := com.MultiGet(in.Index, in.DocIds)
+
+	rep := &pb.MultiGetReply{Docs: make([]*pb.StoredDoc, len(in.DocIds))}
+	for i, docId := range in.DocIds {
+		rep.Docs[i] = storedDoc(docId, datas[i], errs[i])
+	}
+
+	return rep
+}
+
+// wordsReply 0 succeed, 1 fail
+func wordsReply(taskId string, err error) *pb.WordsReply {
+	if err != nil {
+		return &pb.WordsReply{Result: 1, Msg: err.Error()}
+	}
+
+	return &pb.WordsReply{TaskId: taskId}
+}
+
+// AddWords add the words to the gse dictionary, the reply is sent after
+// the dictionary is loaded again, see riot.Engine.AddWords
+func AddWords(in *pb.WordsReq) *pb.WordsReply {
+	words := make([]types.UserWord, len(in.Words))
+	for i, word := range in.Words {
+		words[i] = types.UserWord{
+			Text: word.Text, Freq: int(word.Freq), Pos: word.Pos}
+	}
+
+	return wordsReply(com.AddWords(in.Index, words, in.Reanalyze))
+}
+
+// RemoveWords remove the words from the gse dictionary
+func RemoveWords(in *pb.WordsReq) *pb.WordsReply {
+	texts := make([]string, len(in.Words))
+	for i, word := range in.Words {
+		texts[i] = word.Text
+	}
+
+	return wordsReply(com.RemoveWords(in.Index, texts, in.Reanalyze))
+}
+
+// Task get the status of the background task, such as the task
+// returned by AddWords, and cancel it if in.Cancel is true
+func Task(in *pb.TaskReq) *pb.TaskReply {
+	info, err := com.Task(in.Index, in.Id, in.Cancel)
+	if err != nil {
+		return &pb.TaskReply{Result: 1, Msg: err.Error()}
+	}
+
+	rep := &pb.TaskReply{Id: info.Id, Kind: info.Kind,
+		Total: int32(info.Total), Done: int32(info.Done),
+		Failed: int32(info.Failed), Canceled: info.Canceled,
+		Finished: info.Finished}
+	for _, failure := range info.Failures {
+		rep.Failures = append(rep.Failures,
+			&pb.TaskFailure{DocId: failure.DocId, Err: failure.Err})
+	}
+
+	return rep
+}
+
+// ListIndexes list the named indexes and the aliases
+func ListIndexes(in *pb.IndexReq) *pb.IndexesReply {
+	infos, aliases, err := com.ListIndexes()
+	if err != nil {
+		return &pb.IndexesReply{Result: 1, Msg: err.Error()}
+	}
+
+	rep := &pb.IndexesReply{}
+	for _, info := range infos {
+		rep.Indexes = append(rep.Indexes, &pb.IndexInfo{Name: info.Name,
+			Opened: info.Opened, NumDocsIndexed: info.NumDocsIndexed})
+	}
+	for alias, index := range aliases {
+		rep.Aliases = append(rep.Aliases, &pb.Alias{Alias: alias, Index: index})
+	}
+	sort.Slice(rep.Aliases, func(i, j int) bool {
+		return rep.Aliases[i].Alias < rep.Aliases[j].Alias
+	})
+
+	return rep
+}
+
+// UserWords get the words added to the gse dictionary
+func UserWords(in *pb.WordsReq) *pb.WordsReply {
+	words, err := com.UserWords(in.Index)
+	rep := wordsReply("", err)
+	for _, word := range words {
+		rep.Words = append(rep.Words, &pb.Word{
+			Text: word.Text, Freq: int32(word.Freq), Pos: word.Pos})
+	}
+
+	return rep
+}
+
+// reply 0 succeed, 1 fail
+func reply(err error) *pb.Reply {
+	if err != nil {
+		return &pb.Reply{Result: 1, Msg: err.Error()}
+	}
+
+	return &pb.Reply{Result: 0}
+}
+
+// filters convert the filter clauses of the search request
+func filters(in []*pb.Filter) []types.Filter {
+	var out []types.Filter
+	for _, f := range in {
+		out = append(out, types.Filter{
+			Labels: f.Labels, DocIds: f.DocIds, Not: f.Not})
+	}
+
+	return out
 }
 
 func (s *server) HeartBeat(ctx context.Context, in *pb.HeartReq) (*pb.Reply, error) {
@@ -83,14 +257,72 @@ func (s *server) HeartBeat(ctx context.Context, in *pb.HeartReq) (*pb.Reply, err
 
 func (s *server) DocInx(ctx context.Context, in *pb.DocReq) (*pb.Reply, error) {
 
-	addDoc(in)
-	return &pb.Reply{Result: 0}, nil
+	return reply(addDoc(in)), nil
+}
+
+func (s *server) DocsInx(ctx context.Context, in *pb.DocsReq) (*pb.DocsReply, error) {
+
+	return addDocs(in), nil
 }
 
 func (s *server) Delete(ctx context.Context, in *pb.DeleteReq) (*pb.Reply, error) {
 
-	DelDoc(in)
-	return &pb.Reply{Result: 0}, nil
+	return reply(DelDoc(in)), nil
+}
+
+func (s *server) GetDoc(ctx context.Context, in *pb.GetReq) (*pb.StoredDoc, error) {
+
+	return GetDoc(in), nil
+}
+
+func (s *server) MultiGet(ctx context.Context, in *pb.MultiGetReq) (*pb.MultiGetReply, error) {
+
+	return MultiGet(in), nil
+}
+
+func (s *server) CreateIndex(ctx context.Context, in *pb.IndexReq) (*pb.Reply, error) {
+
+	return reply(com.CreateIndex(in.Index)), nil
+}
+
+func (s *server) OpenIndex(ctx context.Context, in *pb.IndexReq) (*pb.Reply, error) {
+
+	return reply(com.OpenIndex(in.Index)), nil
+}
+
+func (s *server) CloseIndex(ctx context.Context, in *pb.IndexReq) (*pb.Reply, error) {
+
+	return reply(com.CloseIndex(in.Index)), nil
+}
+
+func (s *server) DropIndex(ctx context.Context, in *pb.IndexReq) (*pb.Reply, error) {
+
+	return reply(com.DropIndex(in.Index)), nil
+}
+
+func (s *server) ListIndexes(ctx context.Context, in *pb.IndexReq) (*pb.IndexesReply, error) {
+
+	return ListIndexes(in), nil
+}
+
+func (s *server) AddWords(ctx context.Context, in *pb.WordsReq) (*pb.WordsReply, error) {
+
+	return AddWords(in), nil
+}
+
+func (s *server) RemoveWords(ctx context.Context, in *pb.WordsReq) (*pb.WordsReply, error) {
+
+	return RemoveWords(in), nil
+}
+
+func (s *server) UserWords(ctx context.Context, in *pb.WordsReq) (*pb.WordsReply, error) {
+
+	return UserWords(in), nil
+}
+
+func (s *server) Task(ctx context.Context, in *pb.TaskReq) (*pb.TaskReply, error) {
+
+	return Task(in), nil
 }
 
 func (s *server) Search(ctx context.Context, in *pb.SearchReq) (*pb.SearchReply, error) {
@@ -120,10 +352,13 @@ func (s *server) Search(ctx context.Context, in *pb.SearchReq) (*pb.SearchReply,
 	}
 
 	sea := com.SearchArgs{
+		Index:        in.Index,
 		Id:           in.Id,
 		Query:        in.Query,
 		Time:         in.Time,
 		DocIds:       in.DocIds,
+		Filters:      filters(in.Filters),
+		SubField:     in.SubField,
 		OutputOffset: outputOffset,
 		MaxOutputs:   maxOutputs,
 		Logic:        logic,
@@ -99,6 +99,7 @@ func InitSearchRpc(address string, sea com.SearchArgs) (*pb.SearchReply, error)
 		Time:         sea.Time,
 		DocIds:       sea.DocIds,
 		Logic:        logic,
+		Index:        sea.Index,
 	})
 
 	if err != nil {
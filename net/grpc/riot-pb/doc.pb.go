@@ -7,10 +7,8 @@ import proto "github.com/gogo/protobuf/proto"
 import fmt "fmt"
 import math "math"
 
-import (
-	context "golang.org/x/net/context"
-	grpc "google.golang.org/grpc"
-)
+import context "golang.org/x/net/context"
+import grpc "google.golang.org/grpc"
 
 import io "io"
 
@@ -36,7 +34,7 @@ func (m *HeartReq) Reset()         { *m = HeartReq{} }
 func (m *HeartReq) String() string { return proto.CompactTextString(m) }
 func (*HeartReq) ProtoMessage()    {}
 func (*HeartReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_doc_5a5b8862ec63a264, []int{0}
+	return fileDescriptor_doc_884361a2818b9d4a, []int{0}
 }
 func (m *HeartReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -82,6 +80,10 @@ type DocReq struct {
 	Labels               []string     `protobuf:"bytes,5,rep,name=labels" json:"labels,omitempty"`
 	Fields               []byte       `protobuf:"bytes,6,opt,name=fields,proto3" json:"fields,omitempty"`
 	ForceUpdate          bool         `protobuf:"varint,7,opt,name=forceUpdate,proto3" json:"forceUpdate,omitempty"`
+	Version              uint64       `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty"`
+	Index                string       `protobuf:"bytes,9,opt,name=index,proto3" json:"index,omitempty"`
+	Routing              string       `protobuf:"bytes,10,opt,name=routing,proto3" json:"routing,omitempty"`
+	ExpireAt             int64        `protobuf:"varint,11,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
 	XXX_unrecognized     []byte       `json:"-"`
 	XXX_sizecache        int32        `json:"-"`
@@ -91,7 +93,7 @@ func (m *DocReq) Reset()         { *m = DocReq{} }
 func (m *DocReq) String() string { return proto.CompactTextString(m) }
 func (*DocReq) ProtoMessage()    {}
 func (*DocReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_doc_5a5b8862ec63a264, []int{1}
+	return fileDescriptor_doc_884361a2818b9d4a, []int{1}
 }
 func (m *DocReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -169,6 +171,210 @@ func (m *DocReq) GetForceUpdate() bool {
 	return false
 }
 
+func (m *DocReq) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *DocReq) GetIndex() string {
+	if m != nil {
+		return m.Index
+	}
+	return ""
+}
+
+func (m *DocReq) GetRouting() string {
+	if m != nil {
+		return m.Routing
+	}
+	return ""
+}
+
+func (m *DocReq) GetExpireAt() int64 {
+	if m != nil {
+		return m.ExpireAt
+	}
+	return 0
+}
+
+// Index the documents in batch
+type DocsReq struct {
+	Docs                 []*DocReq `protobuf:"bytes,1,rep,name=docs" json:"docs,omitempty"`
+	ForceUpdate          bool      `protobuf:"varint,2,opt,name=forceUpdate,proto3" json:"forceUpdate,omitempty"`
+	Index                string    `protobuf:"bytes,3,opt,name=index,proto3" json:"index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *DocsReq) Reset()         { *m = DocsReq{} }
+func (m *DocsReq) String() string { return proto.CompactTextString(m) }
+func (*DocsReq) ProtoMessage()    {}
+func (*DocsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_doc_884361a2818b9d4a, []int{2}
+}
+func (m *DocsReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DocsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DocsReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *DocsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DocsReq.Merge(dst, src)
+}
+func (m *DocsReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *DocsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_DocsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DocsReq proto.InternalMessageInfo
+
+func (m *DocsReq) GetDocs() []*DocReq {
+	if m != nil {
+		return m.Docs
+	}
+	return nil
+}
+
+func (m *DocsReq) GetForceUpdate() bool {
+	if m != nil {
+		return m.ForceUpdate
+	}
+	return false
+}
+
+func (m *DocsReq) GetIndex() string {
+	if m != nil {
+		return m.Index
+	}
+	return ""
+}
+
+// The index result of each document
+type DocsReply struct {
+	Results              []*DocResult `protobuf:"bytes,1,rep,name=results" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *DocsReply) Reset()         { *m = DocsReply{} }
+func (m *DocsReply) String() string { return proto.CompactTextString(m) }
+func (*DocsReply) ProtoMessage()    {}
+func (*DocsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_doc_884361a2818b9d4a, []int{3}
+}
+func (m *DocsReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DocsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DocsReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *DocsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DocsReply.Merge(dst, src)
+}
+func (m *DocsReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *DocsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_DocsReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DocsReply proto.InternalMessageInfo
+
+func (m *DocsReply) GetResults() []*DocResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+// 0 succeed, 1 fail
+type DocResult struct {
+	DocId                string   `protobuf:"bytes,1,opt,name=doc_id,json=docId,proto3" json:"doc_id,omitempty"`
+	Result               int32    `protobuf:"varint,2,opt,name=result,proto3" json:"result,omitempty"`
+	Msg                  string   `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DocResult) Reset()         { *m = DocResult{} }
+func (m *DocResult) String() string { return proto.CompactTextString(m) }
+func (*DocResult) ProtoMessage()    {}
+func (*DocResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_doc_884361a2818b9d4a, []int{4}
+}
+func (m *DocResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DocResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DocResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *DocResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DocResult.Merge(dst, src)
+}
+func (m *DocResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *DocResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_DocResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DocResult proto.InternalMessageInfo
+
+func (m *DocResult) GetDocId() string {
+	if m != nil {
+		return m.DocId
+	}
+	return ""
+}
+
+func (m *DocResult) GetResult() int32 {
+	if m != nil {
+		return m.Result
+	}
+	return 0
+}
+
+func (m *DocResult) GetMsg() string {
+	if m != nil {
+		return m.Msg
+	}
+	return ""
+}
+
 type TokenData struct {
 	Text                 string   `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
 	Locations            []int32  `protobuf:"varint,2,rep,packed,name=locations" json:"locations,omitempty"`
@@ -181,7 +387,7 @@ func (m *TokenData) Reset()         { *m = TokenData{} }
 func (m *TokenData) String() string { return proto.CompactTextString(m) }
 func (*TokenData) ProtoMessage()    {}
 func (*TokenData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_doc_5a5b8862ec63a264, []int{2}
+	return fileDescriptor_doc_884361a2818b9d4a, []int{5}
 }
 func (m *TokenData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -226,6 +432,8 @@ func (m *TokenData) GetLocations() []int32 {
 
 type DeleteReq struct {
 	DocId                string   `protobuf:"bytes,1,opt,name=doc_id,json=docId,proto3" json:"doc_id,omitempty"`
+	Version              uint64   `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Index                string   `protobuf:"bytes,3,opt,name=index,proto3" json:"index,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -235,7 +443,7 @@ func (m *DeleteReq) Reset()         { *m = DeleteReq{} }
 func (m *DeleteReq) String() string { return proto.CompactTextString(m) }
 func (*DeleteReq) ProtoMessage()    {}
 func (*DeleteReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_doc_5a5b8862ec63a264, []int{3}
+	return fileDescriptor_doc_884361a2818b9d4a, []int{6}
 }
 func (m *DeleteReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -271,9 +479,24 @@ func (m *DeleteReq) GetDocId() string {
 	return ""
 }
 
+func (m *DeleteReq) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *DeleteReq) GetIndex() string {
+	if m != nil {
+		return m.Index
+	}
+	return ""
+}
+
 // 0 succeed, 1 fail
 type Reply struct {
 	Result               int32    `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	Msg                  string   `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -283,7 +506,7 @@ func (m *Reply) Reset()         { *m = Reply{} }
 func (m *Reply) String() string { return proto.CompactTextString(m) }
 func (*Reply) ProtoMessage()    {}
 func (*Reply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_doc_5a5b8862ec63a264, []int{4}
+	return fileDescriptor_doc_884361a2818b9d4a, []int{7}
 }
 func (m *Reply) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -319,6 +542,13 @@ func (m *Reply) GetResult() int32 {
 	return 0
 }
 
+func (m *Reply) GetMsg() string {
+	if m != nil {
+		return m.Msg
+	}
+	return ""
+}
+
 type SearchReq struct {
 	Id                   string          `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Query                string          `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
@@ -327,6 +557,9 @@ type SearchReq struct {
 	Time                 string          `protobuf:"bytes,5,opt,name=time,proto3" json:"time,omitempty"`
 	DocIds               map[string]bool `protobuf:"bytes,6,rep,name=docIds" json:"docIds,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
 	Logic                *Logic          `protobuf:"bytes,7,opt,name=logic" json:"logic,omitempty"`
+	Index                string          `protobuf:"bytes,8,opt,name=index,proto3" json:"index,omitempty"`
+	Filters              []*Filter       `protobuf:"bytes,9,rep,name=filters" json:"filters,omitempty"`
+	SubField             string          `protobuf:"bytes,10,opt,name=sub_field,json=subField,proto3" json:"sub_field,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
@@ -336,7 +569,7 @@ func (m *SearchReq) Reset()         { *m = SearchReq{} }
 func (m *SearchReq) String() string { return proto.CompactTextString(m) }
 func (*SearchReq) ProtoMessage()    {}
 func (*SearchReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_doc_5a5b8862ec63a264, []int{5}
+	return fileDescriptor_doc_884361a2818b9d4a, []int{8}
 }
 func (m *SearchReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -414,6 +647,92 @@ func (m *SearchReq) GetLogic() *Logic {
 	return nil
 }
 
+func (m *SearchReq) GetIndex() string {
+	if m != nil {
+		return m.Index
+	}
+	return ""
+}
+
+func (m *SearchReq) GetFilters() []*Filter {
+	if m != nil {
+		return m.Filters
+	}
+	return nil
+}
+
+func (m *SearchReq) GetSubField() string {
+	if m != nil {
+		return m.SubField
+	}
+	return ""
+}
+
+// Filter clause, the docs must have all the labels and be one of
+// the doc_ids if it is not empty; not excludes the matched docs
+type Filter struct {
+	Labels               []string `protobuf:"bytes,1,rep,name=labels" json:"labels,omitempty"`
+	DocIds               []string `protobuf:"bytes,2,rep,name=doc_ids,json=docIds" json:"doc_ids,omitempty"`
+	Not                  bool     `protobuf:"varint,3,opt,name=not,proto3" json:"not,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Filter) Reset()         { *m = Filter{} }
+func (m *Filter) String() string { return proto.CompactTextString(m) }
+func (*Filter) ProtoMessage()    {}
+func (*Filter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_doc_884361a2818b9d4a, []int{9}
+}
+func (m *Filter) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Filter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Filter.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *Filter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Filter.Merge(dst, src)
+}
+func (m *Filter) XXX_Size() int {
+	return m.Size()
+}
+func (m *Filter) XXX_DiscardUnknown() {
+	xxx_messageInfo_Filter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Filter proto.InternalMessageInfo
+
+func (m *Filter) GetLabels() []string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *Filter) GetDocIds() []string {
+	if m != nil {
+		return m.DocIds
+	}
+	return nil
+}
+
+func (m *Filter) GetNot() bool {
+	if m != nil {
+		return m.Not
+	}
+	return false
+}
+
 type SearchReply struct {
 	Code                 int32    `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
 	Len                  int32    `protobuf:"varint,2,opt,name=len,proto3" json:"len,omitempty"`
@@ -428,7 +747,7 @@ func (m *SearchReply) Reset()         { *m = SearchReply{} }
 func (m *SearchReply) String() string { return proto.CompactTextString(m) }
 func (*SearchReply) ProtoMessage()    {}
 func (*SearchReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_doc_5a5b8862ec63a264, []int{6}
+	return fileDescriptor_doc_884361a2818b9d4a, []int{10}
 }
 func (m *SearchReply) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -485,28 +804,27 @@ func (m *SearchReply) GetDocs() []*Text {
 	return nil
 }
 
-type Text struct {
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	// uint64 id = 1;
-	Content              string   `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
-	Attri                *Attri   `protobuf:"bytes,3,opt,name=attri" json:"attri,omitempty"`
+// Get the stored document
+type GetReq struct {
+	DocId                string   `protobuf:"bytes,1,opt,name=doc_id,json=docId,proto3" json:"doc_id,omitempty"`
+	Index                string   `protobuf:"bytes,2,opt,name=index,proto3" json:"index,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Text) Reset()         { *m = Text{} }
-func (m *Text) String() string { return proto.CompactTextString(m) }
-func (*Text) ProtoMessage()    {}
-func (*Text) Descriptor() ([]byte, []int) {
-	return fileDescriptor_doc_5a5b8862ec63a264, []int{7}
+func (m *GetReq) Reset()         { *m = GetReq{} }
+func (m *GetReq) String() string { return proto.CompactTextString(m) }
+func (*GetReq) ProtoMessage()    {}
+func (*GetReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_doc_884361a2818b9d4a, []int{11}
 }
-func (m *Text) XXX_Unmarshal(b []byte) error {
+func (m *GetReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Text) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *GetReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Text.Marshal(b, m, deterministic)
+		return xxx_messageInfo_GetReq.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -516,61 +834,52 @@ func (m *Text) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (dst *Text) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Text.Merge(dst, src)
+func (dst *GetReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetReq.Merge(dst, src)
 }
-func (m *Text) XXX_Size() int {
+func (m *GetReq) XXX_Size() int {
 	return m.Size()
 }
-func (m *Text) XXX_DiscardUnknown() {
-	xxx_messageInfo_Text.DiscardUnknown(m)
+func (m *GetReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetReq.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Text proto.InternalMessageInfo
+var xxx_messageInfo_GetReq proto.InternalMessageInfo
 
-func (m *Text) GetId() string {
+func (m *GetReq) GetDocId() string {
 	if m != nil {
-		return m.Id
+		return m.DocId
 	}
 	return ""
 }
 
-func (m *Text) GetContent() string {
+func (m *GetReq) GetIndex() string {
 	if m != nil {
-		return m.Content
+		return m.Index
 	}
 	return ""
 }
 
-func (m *Text) GetAttri() *Attri {
-	if m != nil {
-		return m.Attri
-	}
-	return nil
-}
-
-type Attri struct {
-	Title                string   `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
-	Author               string   `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
-	Time                 string   `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
-	Ts                   int64    `protobuf:"varint,4,opt,name=ts,proto3" json:"ts,omitempty"`
+type MultiGetReq struct {
+	DocIds               []string `protobuf:"bytes,1,rep,name=doc_ids,json=docIds" json:"doc_ids,omitempty"`
+	Index                string   `protobuf:"bytes,2,opt,name=index,proto3" json:"index,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Attri) Reset()         { *m = Attri{} }
-func (m *Attri) String() string { return proto.CompactTextString(m) }
-func (*Attri) ProtoMessage()    {}
-func (*Attri) Descriptor() ([]byte, []int) {
-	return fileDescriptor_doc_5a5b8862ec63a264, []int{8}
+func (m *MultiGetReq) Reset()         { *m = MultiGetReq{} }
+func (m *MultiGetReq) String() string { return proto.CompactTextString(m) }
+func (*MultiGetReq) ProtoMessage()    {}
+func (*MultiGetReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_doc_884361a2818b9d4a, []int{12}
 }
-func (m *Attri) XXX_Unmarshal(b []byte) error {
+func (m *MultiGetReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Attri) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *MultiGetReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Attri.Marshal(b, m, deterministic)
+		return xxx_messageInfo_MultiGetReq.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -580,21 +889,300 @@ func (m *Attri) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (dst *Attri) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Attri.Merge(dst, src)
+func (dst *MultiGetReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MultiGetReq.Merge(dst, src)
 }
-func (m *Attri) XXX_Size() int {
+func (m *MultiGetReq) XXX_Size() int {
 	return m.Size()
 }
-func (m *Attri) XXX_DiscardUnknown() {
-	xxx_messageInfo_Attri.DiscardUnknown(m)
+func (m *MultiGetReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_MultiGetReq.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Attri proto.InternalMessageInfo
+var xxx_messageInfo_MultiGetReq proto.InternalMessageInfo
 
-func (m *Attri) GetTitle() string {
+func (m *MultiGetReq) GetDocIds() []string {
 	if m != nil {
-		return m.Title
+		return m.DocIds
+	}
+	return nil
+}
+
+func (m *MultiGetReq) GetIndex() string {
+	if m != nil {
+		return m.Index
+	}
+	return ""
+}
+
+type StoredDoc struct {
+	DocId                string   `protobuf:"bytes,1,opt,name=doc_id,json=docId,proto3" json:"doc_id,omitempty"`
+	Result               int32    `protobuf:"varint,2,opt,name=result,proto3" json:"result,omitempty"`
+	Msg                  string   `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+	Content              string   `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	Attri                *Attri   `protobuf:"bytes,5,opt,name=attri" json:"attri,omitempty"`
+	Labels               []string `protobuf:"bytes,6,rep,name=labels" json:"labels,omitempty"`
+	Version              uint64   `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
+	Routing              string   `protobuf:"bytes,8,opt,name=routing,proto3" json:"routing,omitempty"`
+	ExpireAt             int64    `protobuf:"varint,9,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StoredDoc) Reset()         { *m = StoredDoc{} }
+func (m *StoredDoc) String() string { return proto.CompactTextString(m) }
+func (*StoredDoc) ProtoMessage()    {}
+func (*StoredDoc) Descriptor() ([]byte, []int) {
+	return fileDescriptor_doc_884361a2818b9d4a, []int{13}
+}
+func (m *StoredDoc) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StoredDoc) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StoredDoc.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *StoredDoc) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StoredDoc.Merge(dst, src)
+}
+func (m *StoredDoc) XXX_Size() int {
+	return m.Size()
+}
+func (m *StoredDoc) XXX_DiscardUnknown() {
+	xxx_messageInfo_StoredDoc.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StoredDoc proto.InternalMessageInfo
+
+func (m *StoredDoc) GetDocId() string {
+	if m != nil {
+		return m.DocId
+	}
+	return ""
+}
+
+func (m *StoredDoc) GetResult() int32 {
+	if m != nil {
+		return m.Result
+	}
+	return 0
+}
+
+func (m *StoredDoc) GetMsg() string {
+	if m != nil {
+		return m.Msg
+	}
+	return ""
+}
+
+func (m *StoredDoc) GetContent() string {
+	if m != nil {
+		return m.Content
+	}
+	return ""
+}
+
+func (m *StoredDoc) GetAttri() *Attri {
+	if m != nil {
+		return m.Attri
+	}
+	return nil
+}
+
+func (m *StoredDoc) GetLabels() []string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *StoredDoc) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *StoredDoc) GetRouting() string {
+	if m != nil {
+		return m.Routing
+	}
+	return ""
+}
+
+func (m *StoredDoc) GetExpireAt() int64 {
+	if m != nil {
+		return m.ExpireAt
+	}
+	return 0
+}
+
+type MultiGetReply struct {
+	Docs                 []*StoredDoc `protobuf:"bytes,1,rep,name=docs" json:"docs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *MultiGetReply) Reset()         { *m = MultiGetReply{} }
+func (m *MultiGetReply) String() string { return proto.CompactTextString(m) }
+func (*MultiGetReply) ProtoMessage()    {}
+func (*MultiGetReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_doc_884361a2818b9d4a, []int{14}
+}
+func (m *MultiGetReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MultiGetReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MultiGetReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *MultiGetReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MultiGetReply.Merge(dst, src)
+}
+func (m *MultiGetReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *MultiGetReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_MultiGetReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MultiGetReply proto.InternalMessageInfo
+
+func (m *MultiGetReply) GetDocs() []*StoredDoc {
+	if m != nil {
+		return m.Docs
+	}
+	return nil
+}
+
+type Text struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// uint64 id = 1;
+	Content              string   `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Attri                *Attri   `protobuf:"bytes,3,opt,name=attri" json:"attri,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Text) Reset()         { *m = Text{} }
+func (m *Text) String() string { return proto.CompactTextString(m) }
+func (*Text) ProtoMessage()    {}
+func (*Text) Descriptor() ([]byte, []int) {
+	return fileDescriptor_doc_884361a2818b9d4a, []int{15}
+}
+func (m *Text) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Text) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Text.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *Text) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Text.Merge(dst, src)
+}
+func (m *Text) XXX_Size() int {
+	return m.Size()
+}
+func (m *Text) XXX_DiscardUnknown() {
+	xxx_messageInfo_Text.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Text proto.InternalMessageInfo
+
+func (m *Text) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Text) GetContent() string {
+	if m != nil {
+		return m.Content
+	}
+	return ""
+}
+
+func (m *Text) GetAttri() *Attri {
+	if m != nil {
+		return m.Attri
+	}
+	return nil
+}
+
+type Attri struct {
+	Title                string   `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Author               string   `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
+	Time                 string   `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+	Ts                   int64    `protobuf:"varint,4,opt,name=ts,proto3" json:"ts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Attri) Reset()         { *m = Attri{} }
+func (m *Attri) String() string { return proto.CompactTextString(m) }
+func (*Attri) ProtoMessage()    {}
+func (*Attri) Descriptor() ([]byte, []int) {
+	return fileDescriptor_doc_884361a2818b9d4a, []int{16}
+}
+func (m *Attri) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Attri) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Attri.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *Attri) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Attri.Merge(dst, src)
+}
+func (m *Attri) XXX_Size() int {
+	return m.Size()
+}
+func (m *Attri) XXX_DiscardUnknown() {
+	xxx_messageInfo_Attri.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Attri proto.InternalMessageInfo
+
+func (m *Attri) GetTitle() string {
+	if m != nil {
+		return m.Title
 	}
 	return ""
 }
@@ -637,7 +1225,7 @@ func (m *Logic) Reset()         { *m = Logic{} }
 func (m *Logic) String() string { return proto.CompactTextString(m) }
 func (*Logic) ProtoMessage()    {}
 func (*Logic) Descriptor() ([]byte, []int) {
-	return fileDescriptor_doc_5a5b8862ec63a264, []int{9}
+	return fileDescriptor_doc_884361a2818b9d4a, []int{17}
 }
 func (m *Logic) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -711,7 +1299,7 @@ func (m *Expr) Reset()         { *m = Expr{} }
 func (m *Expr) String() string { return proto.CompactTextString(m) }
 func (*Expr) ProtoMessage()    {}
 func (*Expr) Descriptor() ([]byte, []int) {
-	return fileDescriptor_doc_5a5b8862ec63a264, []int{10}
+	return fileDescriptor_doc_884361a2818b9d4a, []int{18}
 }
 func (m *Expr) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -761,1052 +1349,6283 @@ func (m *Expr) GetNotIn() []string {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*HeartReq)(nil), "doc.HeartReq")
-	proto.RegisterType((*DocReq)(nil), "doc.DocReq")
-	proto.RegisterType((*TokenData)(nil), "doc.TokenData")
-	proto.RegisterType((*DeleteReq)(nil), "doc.DeleteReq")
-	proto.RegisterType((*Reply)(nil), "doc.Reply")
-	proto.RegisterType((*SearchReq)(nil), "doc.SearchReq")
-	proto.RegisterMapType((map[string]bool)(nil), "doc.SearchReq.DocIdsEntry")
-	proto.RegisterType((*SearchReply)(nil), "doc.SearchReply")
-	proto.RegisterType((*Text)(nil), "doc.Text")
-	proto.RegisterType((*Attri)(nil), "doc.Attri")
-	proto.RegisterType((*Logic)(nil), "doc.Logic")
-	proto.RegisterType((*Expr)(nil), "doc.Expr")
+type Word struct {
+	Text                 string   `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Freq                 int32    `protobuf:"varint,2,opt,name=freq,proto3" json:"freq,omitempty"`
+	Pos                  string   `protobuf:"bytes,3,opt,name=pos,proto3" json:"pos,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// GreeterClient is the client API for Greeter service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type GreeterClient interface {
-	// Sends a greeting
-	HeartBeat(ctx context.Context, in *HeartReq, opts ...grpc.CallOption) (*Reply, error)
-	DocInx(ctx context.Context, in *DocReq, opts ...grpc.CallOption) (*Reply, error)
-	Delete(ctx context.Context, in *DeleteReq, opts ...grpc.CallOption) (*Reply, error)
-	Search(ctx context.Context, in *SearchReq, opts ...grpc.CallOption) (*SearchReply, error)
+func (m *Word) Reset()         { *m = Word{} }
+func (m *Word) String() string { return proto.CompactTextString(m) }
+func (*Word) ProtoMessage()    {}
+func (*Word) Descriptor() ([]byte, []int) {
+	return fileDescriptor_doc_884361a2818b9d4a, []int{19}
 }
-
-type greeterClient struct {
-	cc *grpc.ClientConn
-}
-
-func NewGreeterClient(cc *grpc.ClientConn) GreeterClient {
-	return &greeterClient{cc}
+func (m *Word) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func (c *greeterClient) HeartBeat(ctx context.Context, in *HeartReq, opts ...grpc.CallOption) (*Reply, error) {
-	out := new(Reply)
-	err := c.cc.Invoke(ctx, "/doc.Greeter/HeartBeat", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *Word) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Word.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return out, nil
+}
+func (dst *Word) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Word.Merge(dst, src)
+}
+func (m *Word) XXX_Size() int {
+	return m.Size()
+}
+func (m *Word) XXX_DiscardUnknown() {
+	xxx_messageInfo_Word.DiscardUnknown(m)
 }
 
-func (c *greeterClient) DocInx(ctx context.Context, in *DocReq, opts ...grpc.CallOption) (*Reply, error) {
-	out := new(Reply)
-	err := c.cc.Invoke(ctx, "/doc.Greeter/DocInx", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_Word proto.InternalMessageInfo
+
+func (m *Word) GetText() string {
+	if m != nil {
+		return m.Text
 	}
-	return out, nil
+	return ""
 }
 
-func (c *greeterClient) Delete(ctx context.Context, in *DeleteReq, opts ...grpc.CallOption) (*Reply, error) {
-	out := new(Reply)
-	err := c.cc.Invoke(ctx, "/doc.Greeter/Delete", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *Word) GetFreq() int32 {
+	if m != nil {
+		return m.Freq
 	}
-	return out, nil
+	return 0
 }
 
-func (c *greeterClient) Search(ctx context.Context, in *SearchReq, opts ...grpc.CallOption) (*SearchReply, error) {
-	out := new(SearchReply)
-	err := c.cc.Invoke(ctx, "/doc.Greeter/Search", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *Word) GetPos() string {
+	if m != nil {
+		return m.Pos
 	}
-	return out, nil
+	return ""
 }
 
-// GreeterServer is the server API for Greeter service.
-type GreeterServer interface {
-	// Sends a greeting
-	HeartBeat(context.Context, *HeartReq) (*Reply, error)
-	DocInx(context.Context, *DocReq) (*Reply, error)
-	Delete(context.Context, *DeleteReq) (*Reply, error)
-	Search(context.Context, *SearchReq) (*SearchReply, error)
+// Only the text of the words is used by RemoveWords; reanalyze segments
+// the stored docs containing the words again in a background task
+type WordsReq struct {
+	Words                []*Word  `protobuf:"bytes,1,rep,name=words" json:"words,omitempty"`
+	Index                string   `protobuf:"bytes,2,opt,name=index,proto3" json:"index,omitempty"`
+	Reanalyze            bool     `protobuf:"varint,3,opt,name=reanalyze,proto3" json:"reanalyze,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func RegisterGreeterServer(s *grpc.Server, srv GreeterServer) {
-	s.RegisterService(&_Greeter_serviceDesc, srv)
+func (m *WordsReq) Reset()         { *m = WordsReq{} }
+func (m *WordsReq) String() string { return proto.CompactTextString(m) }
+func (*WordsReq) ProtoMessage()    {}
+func (*WordsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_doc_884361a2818b9d4a, []int{20}
 }
-
-func _Greeter_HeartBeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(HeartReq)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(GreeterServer).HeartBeat(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/doc.Greeter/HeartBeat",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GreeterServer).HeartBeat(ctx, req.(*HeartReq))
+func (m *WordsReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WordsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WordsReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return interceptor(ctx, in, info, handler)
+}
+func (dst *WordsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WordsReq.Merge(dst, src)
+}
+func (m *WordsReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *WordsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_WordsReq.DiscardUnknown(m)
 }
 
-func _Greeter_DocInx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DocReq)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(GreeterServer).DocInx(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/doc.Greeter/DocInx",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GreeterServer).DocInx(ctx, req.(*DocReq))
+var xxx_messageInfo_WordsReq proto.InternalMessageInfo
+
+func (m *WordsReq) GetWords() []*Word {
+	if m != nil {
+		return m.Words
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _Greeter_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteReq)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(GreeterServer).Delete(ctx, in)
+func (m *WordsReq) GetIndex() string {
+	if m != nil {
+		return m.Index
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/doc.Greeter/Delete",
+	return ""
+}
+
+func (m *WordsReq) GetReanalyze() bool {
+	if m != nil {
+		return m.Reanalyze
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GreeterServer).Delete(ctx, req.(*DeleteReq))
+	return false
+}
+
+type WordsReply struct {
+	Result               int32    `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	Msg                  string   `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	TaskId               string   `protobuf:"bytes,3,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Words                []*Word  `protobuf:"bytes,4,rep,name=words" json:"words,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WordsReply) Reset()         { *m = WordsReply{} }
+func (m *WordsReply) String() string { return proto.CompactTextString(m) }
+func (*WordsReply) ProtoMessage()    {}
+func (*WordsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_doc_884361a2818b9d4a, []int{21}
+}
+func (m *WordsReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WordsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WordsReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return interceptor(ctx, in, info, handler)
+}
+func (dst *WordsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WordsReply.Merge(dst, src)
+}
+func (m *WordsReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *WordsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_WordsReply.DiscardUnknown(m)
 }
 
-func _Greeter_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SearchReq)
-	if err := dec(in); err != nil {
-		return nil, err
+var xxx_messageInfo_WordsReply proto.InternalMessageInfo
+
+func (m *WordsReply) GetResult() int32 {
+	if m != nil {
+		return m.Result
 	}
-	if interceptor == nil {
-		return srv.(GreeterServer).Search(ctx, in)
+	return 0
+}
+
+func (m *WordsReply) GetMsg() string {
+	if m != nil {
+		return m.Msg
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/doc.Greeter/Search",
+	return ""
+}
+
+func (m *WordsReply) GetTaskId() string {
+	if m != nil {
+		return m.TaskId
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GreeterServer).Search(ctx, req.(*SearchReq))
+	return ""
+}
+
+func (m *WordsReply) GetWords() []*Word {
+	if m != nil {
+		return m.Words
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-var _Greeter_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "doc.Greeter",
-	HandlerType: (*GreeterServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "HeartBeat",
-			Handler:    _Greeter_HeartBeat_Handler,
-		},
-		{
-			MethodName: "DocInx",
-			Handler:    _Greeter_DocInx_Handler,
-		},
-		{
-			MethodName: "Delete",
-			Handler:    _Greeter_Delete_Handler,
-		},
-		{
-			MethodName: "Search",
-			Handler:    _Greeter_Search_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "doc.proto",
+type TaskReq struct {
+	Index                string   `protobuf:"bytes,1,opt,name=index,proto3" json:"index,omitempty"`
+	Id                   string   `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Cancel               bool     `protobuf:"varint,3,opt,name=cancel,proto3" json:"cancel,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *HeartReq) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *TaskReq) Reset()         { *m = TaskReq{} }
+func (m *TaskReq) String() string { return proto.CompactTextString(m) }
+func (*TaskReq) ProtoMessage()    {}
+func (*TaskReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_doc_884361a2818b9d4a, []int{22}
+}
+func (m *TaskReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TaskReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TaskReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
+}
+func (dst *TaskReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TaskReq.Merge(dst, src)
+}
+func (m *TaskReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *TaskReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_TaskReq.DiscardUnknown(m)
 }
 
-func (m *HeartReq) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Msg != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintDoc(dAtA, i, uint64(m.Msg))
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+var xxx_messageInfo_TaskReq proto.InternalMessageInfo
+
+func (m *TaskReq) GetIndex() string {
+	if m != nil {
+		return m.Index
 	}
-	return i, nil
+	return ""
 }
 
-func (m *DocReq) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *TaskReq) GetId() string {
+	if m != nil {
+		return m.Id
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *DocReq) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.DocId) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintDoc(dAtA, i, uint64(len(m.DocId)))
-		i += copy(dAtA[i:], m.DocId)
-	}
-	if len(m.Content) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintDoc(dAtA, i, uint64(len(m.Content)))
-		i += copy(dAtA[i:], m.Content)
-	}
-	if len(m.Attri) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintDoc(dAtA, i, uint64(len(m.Attri)))
-		i += copy(dAtA[i:], m.Attri)
+func (m *TaskReq) GetCancel() bool {
+	if m != nil {
+		return m.Cancel
 	}
-	if len(m.Tokens) > 0 {
-		for _, msg := range m.Tokens {
-			dAtA[i] = 0x22
-			i++
-			i = encodeVarintDoc(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
+	return false
+}
+
+type TaskFailure struct {
+	DocId                string   `protobuf:"bytes,1,opt,name=doc_id,json=docId,proto3" json:"doc_id,omitempty"`
+	Err                  string   `protobuf:"bytes,2,opt,name=err,proto3" json:"err,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TaskFailure) Reset()         { *m = TaskFailure{} }
+func (m *TaskFailure) String() string { return proto.CompactTextString(m) }
+func (*TaskFailure) ProtoMessage()    {}
+func (*TaskFailure) Descriptor() ([]byte, []int) {
+	return fileDescriptor_doc_884361a2818b9d4a, []int{23}
+}
+func (m *TaskFailure) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TaskFailure) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TaskFailure.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if len(m.Labels) > 0 {
-		for _, s := range m.Labels {
-			dAtA[i] = 0x2a
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
+}
+func (dst *TaskFailure) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TaskFailure.Merge(dst, src)
+}
+func (m *TaskFailure) XXX_Size() int {
+	return m.Size()
+}
+func (m *TaskFailure) XXX_DiscardUnknown() {
+	xxx_messageInfo_TaskFailure.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TaskFailure proto.InternalMessageInfo
+
+func (m *TaskFailure) GetDocId() string {
+	if m != nil {
+		return m.DocId
 	}
-	if len(m.Fields) > 0 {
-		dAtA[i] = 0x32
-		i++
-		i = encodeVarintDoc(dAtA, i, uint64(len(m.Fields)))
-		i += copy(dAtA[i:], m.Fields)
+	return ""
+}
+
+func (m *TaskFailure) GetErr() string {
+	if m != nil {
+		return m.Err
 	}
-	if m.ForceUpdate {
-		dAtA[i] = 0x38
-		i++
-		if m.ForceUpdate {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
+	return ""
+}
+
+type TaskReply struct {
+	Result               int32          `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	Msg                  string         `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	Id                   string         `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	Kind                 string         `protobuf:"bytes,4,opt,name=kind,proto3" json:"kind,omitempty"`
+	Total                int32          `protobuf:"varint,5,opt,name=total,proto3" json:"total,omitempty"`
+	Done                 int32          `protobuf:"varint,6,opt,name=done,proto3" json:"done,omitempty"`
+	Failed               int32          `protobuf:"varint,7,opt,name=failed,proto3" json:"failed,omitempty"`
+	Failures             []*TaskFailure `protobuf:"bytes,8,rep,name=failures" json:"failures,omitempty"`
+	Canceled             bool           `protobuf:"varint,9,opt,name=canceled,proto3" json:"canceled,omitempty"`
+	Finished             bool           `protobuf:"varint,10,opt,name=finished,proto3" json:"finished,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *TaskReply) Reset()         { *m = TaskReply{} }
+func (m *TaskReply) String() string { return proto.CompactTextString(m) }
+func (*TaskReply) ProtoMessage()    {}
+func (*TaskReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_doc_884361a2818b9d4a, []int{24}
+}
+func (m *TaskReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TaskReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TaskReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
-		i++
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+}
+func (dst *TaskReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TaskReply.Merge(dst, src)
+}
+func (m *TaskReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *TaskReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_TaskReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TaskReply proto.InternalMessageInfo
+
+func (m *TaskReply) GetResult() int32 {
+	if m != nil {
+		return m.Result
 	}
-	return i, nil
+	return 0
 }
 
-func (m *TokenData) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *TaskReply) GetMsg() string {
+	if m != nil {
+		return m.Msg
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *TokenData) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Text) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintDoc(dAtA, i, uint64(len(m.Text)))
-		i += copy(dAtA[i:], m.Text)
+func (m *TaskReply) GetId() string {
+	if m != nil {
+		return m.Id
 	}
-	if len(m.Locations) > 0 {
-		dAtA2 := make([]byte, len(m.Locations)*10)
-		var j1 int
-		for _, num1 := range m.Locations {
-			num := uint64(num1)
-			for num >= 1<<7 {
-				dAtA2[j1] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j1++
-			}
-			dAtA2[j1] = uint8(num)
-			j1++
-		}
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintDoc(dAtA, i, uint64(j1))
-		i += copy(dAtA[i:], dAtA2[:j1])
+	return ""
+}
+
+func (m *TaskReply) GetKind() string {
+	if m != nil {
+		return m.Kind
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	return ""
+}
+
+func (m *TaskReply) GetTotal() int32 {
+	if m != nil {
+		return m.Total
 	}
-	return i, nil
+	return 0
 }
 
-func (m *DeleteReq) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *TaskReply) GetDone() int32 {
+	if m != nil {
+		return m.Done
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *DeleteReq) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.DocId) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintDoc(dAtA, i, uint64(len(m.DocId)))
-		i += copy(dAtA[i:], m.DocId)
+func (m *TaskReply) GetFailed() int32 {
+	if m != nil {
+		return m.Failed
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	return 0
+}
+
+func (m *TaskReply) GetFailures() []*TaskFailure {
+	if m != nil {
+		return m.Failures
 	}
-	return i, nil
+	return nil
 }
 
-func (m *Reply) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *TaskReply) GetCanceled() bool {
+	if m != nil {
+		return m.Canceled
 	}
-	return dAtA[:n], nil
+	return false
 }
 
-func (m *Reply) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Result != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintDoc(dAtA, i, uint64(m.Result))
+func (m *TaskReply) GetFinished() bool {
+	if m != nil {
+		return m.Finished
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	return false
+}
+
+type IndexReq struct {
+	Index                string   `protobuf:"bytes,1,opt,name=index,proto3" json:"index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IndexReq) Reset()         { *m = IndexReq{} }
+func (m *IndexReq) String() string { return proto.CompactTextString(m) }
+func (*IndexReq) ProtoMessage()    {}
+func (*IndexReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_doc_884361a2818b9d4a, []int{25}
+}
+func (m *IndexReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *IndexReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_IndexReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return i, nil
+}
+func (dst *IndexReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IndexReq.Merge(dst, src)
+}
+func (m *IndexReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *IndexReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_IndexReq.DiscardUnknown(m)
 }
 
-func (m *SearchReq) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_IndexReq proto.InternalMessageInfo
+
+func (m *IndexReq) GetIndex() string {
+	if m != nil {
+		return m.Index
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *SearchReq) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Id) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintDoc(dAtA, i, uint64(len(m.Id)))
-		i += copy(dAtA[i:], m.Id)
-	}
-	if len(m.Query) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintDoc(dAtA, i, uint64(len(m.Query)))
-		i += copy(dAtA[i:], m.Query)
-	}
-	if m.OutputOffset != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintDoc(dAtA, i, uint64(m.OutputOffset))
-	}
-	if m.MaxOutputs != 0 {
-		dAtA[i] = 0x20
-		i++
-		i = encodeVarintDoc(dAtA, i, uint64(m.MaxOutputs))
-	}
-	if len(m.Time) > 0 {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintDoc(dAtA, i, uint64(len(m.Time)))
-		i += copy(dAtA[i:], m.Time)
-	}
-	if len(m.DocIds) > 0 {
-		for k, _ := range m.DocIds {
-			dAtA[i] = 0x32
-			i++
-			v := m.DocIds[k]
-			mapSize := 1 + len(k) + sovDoc(uint64(len(k))) + 1 + 1
-			i = encodeVarintDoc(dAtA, i, uint64(mapSize))
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintDoc(dAtA, i, uint64(len(k)))
-			i += copy(dAtA[i:], k)
-			dAtA[i] = 0x10
-			i++
-			if v {
-				dAtA[i] = 1
-			} else {
-				dAtA[i] = 0
-			}
-			i++
-		}
-	}
-	if m.Logic != nil {
-		dAtA[i] = 0x3a
-		i++
-		i = encodeVarintDoc(dAtA, i, uint64(m.Logic.Size()))
-		n3, err := m.Logic.MarshalTo(dAtA[i:])
+type IndexInfo struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Opened               bool     `protobuf:"varint,2,opt,name=opened,proto3" json:"opened,omitempty"`
+	NumDocsIndexed       uint64   `protobuf:"varint,3,opt,name=num_docs_indexed,json=numDocsIndexed,proto3" json:"num_docs_indexed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IndexInfo) Reset()         { *m = IndexInfo{} }
+func (m *IndexInfo) String() string { return proto.CompactTextString(m) }
+func (*IndexInfo) ProtoMessage()    {}
+func (*IndexInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_doc_884361a2818b9d4a, []int{26}
+}
+func (m *IndexInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *IndexInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_IndexInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i += n3
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+		return b[:n], nil
 	}
-	return i, nil
+}
+func (dst *IndexInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IndexInfo.Merge(dst, src)
+}
+func (m *IndexInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *IndexInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_IndexInfo.DiscardUnknown(m)
 }
 
-func (m *SearchReply) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_IndexInfo proto.InternalMessageInfo
+
+func (m *IndexInfo) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *SearchReply) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Code != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintDoc(dAtA, i, uint64(m.Code))
-	}
-	if m.Len != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintDoc(dAtA, i, uint64(m.Len))
+func (m *IndexInfo) GetOpened() bool {
+	if m != nil {
+		return m.Opened
 	}
-	if m.Timestamp != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintDoc(dAtA, i, uint64(m.Timestamp))
+	return false
+}
+
+func (m *IndexInfo) GetNumDocsIndexed() uint64 {
+	if m != nil {
+		return m.NumDocsIndexed
 	}
-	if len(m.Docs) > 0 {
-		for _, msg := range m.Docs {
-			dAtA[i] = 0x22
-			i++
-			i = encodeVarintDoc(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
+	return 0
+}
+
+type Alias struct {
+	Alias                string   `protobuf:"bytes,1,opt,name=alias,proto3" json:"alias,omitempty"`
+	Index                string   `protobuf:"bytes,2,opt,name=index,proto3" json:"index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Alias) Reset()         { *m = Alias{} }
+func (m *Alias) String() string { return proto.CompactTextString(m) }
+func (*Alias) ProtoMessage()    {}
+func (*Alias) Descriptor() ([]byte, []int) {
+	return fileDescriptor_doc_884361a2818b9d4a, []int{27}
+}
+func (m *Alias) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Alias) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Alias.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
+}
+func (dst *Alias) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Alias.Merge(dst, src)
+}
+func (m *Alias) XXX_Size() int {
+	return m.Size()
+}
+func (m *Alias) XXX_DiscardUnknown() {
+	xxx_messageInfo_Alias.DiscardUnknown(m)
 }
 
-func (m *Text) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_Alias proto.InternalMessageInfo
+
+func (m *Alias) GetAlias() string {
+	if m != nil {
+		return m.Alias
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *Text) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Id) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintDoc(dAtA, i, uint64(len(m.Id)))
-		i += copy(dAtA[i:], m.Id)
-	}
-	if len(m.Content) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintDoc(dAtA, i, uint64(len(m.Content)))
-		i += copy(dAtA[i:], m.Content)
+func (m *Alias) GetIndex() string {
+	if m != nil {
+		return m.Index
 	}
-	if m.Attri != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintDoc(dAtA, i, uint64(m.Attri.Size()))
-		n4, err := m.Attri.MarshalTo(dAtA[i:])
+	return ""
+}
+
+type IndexesReply struct {
+	Result               int32        `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	Msg                  string       `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	Indexes              []*IndexInfo `protobuf:"bytes,3,rep,name=indexes" json:"indexes,omitempty"`
+	Aliases              []*Alias     `protobuf:"bytes,4,rep,name=aliases" json:"aliases,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *IndexesReply) Reset()         { *m = IndexesReply{} }
+func (m *IndexesReply) String() string { return proto.CompactTextString(m) }
+func (*IndexesReply) ProtoMessage()    {}
+func (*IndexesReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_doc_884361a2818b9d4a, []int{28}
+}
+func (m *IndexesReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *IndexesReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_IndexesReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i += n4
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+		return b[:n], nil
 	}
-	return i, nil
+}
+func (dst *IndexesReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IndexesReply.Merge(dst, src)
+}
+func (m *IndexesReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *IndexesReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_IndexesReply.DiscardUnknown(m)
 }
 
-func (m *Attri) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_IndexesReply proto.InternalMessageInfo
+
+func (m *IndexesReply) GetResult() int32 {
+	if m != nil {
+		return m.Result
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *Attri) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Title) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintDoc(dAtA, i, uint64(len(m.Title)))
-		i += copy(dAtA[i:], m.Title)
-	}
-	if len(m.Author) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintDoc(dAtA, i, uint64(len(m.Author)))
-		i += copy(dAtA[i:], m.Author)
-	}
-	if len(m.Time) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintDoc(dAtA, i, uint64(len(m.Time)))
-		i += copy(dAtA[i:], m.Time)
+func (m *IndexesReply) GetMsg() string {
+	if m != nil {
+		return m.Msg
 	}
-	if m.Ts != 0 {
-		dAtA[i] = 0x20
-		i++
-		i = encodeVarintDoc(dAtA, i, uint64(m.Ts))
+	return ""
+}
+
+func (m *IndexesReply) GetIndexes() []*IndexInfo {
+	if m != nil {
+		return m.Indexes
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	return nil
+}
+
+func (m *IndexesReply) GetAliases() []*Alias {
+	if m != nil {
+		return m.Aliases
 	}
-	return i, nil
+	return nil
 }
 
-func (m *Logic) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func init() {
+	proto.RegisterType((*HeartReq)(nil), "doc.HeartReq")
+	proto.RegisterType((*DocReq)(nil), "doc.DocReq")
+	proto.RegisterType((*DocsReq)(nil), "doc.DocsReq")
+	proto.RegisterType((*DocsReply)(nil), "doc.DocsReply")
+	proto.RegisterType((*DocResult)(nil), "doc.DocResult")
+	proto.RegisterType((*TokenData)(nil), "doc.TokenData")
+	proto.RegisterType((*DeleteReq)(nil), "doc.DeleteReq")
+	proto.RegisterType((*Reply)(nil), "doc.Reply")
+	proto.RegisterType((*SearchReq)(nil), "doc.SearchReq")
+	proto.RegisterMapType((map[string]bool)(nil), "doc.SearchReq.DocIdsEntry")
+	proto.RegisterType((*Filter)(nil), "doc.Filter")
+	proto.RegisterType((*SearchReply)(nil), "doc.SearchReply")
+	proto.RegisterType((*GetReq)(nil), "doc.GetReq")
+	proto.RegisterType((*MultiGetReq)(nil), "doc.MultiGetReq")
+	proto.RegisterType((*StoredDoc)(nil), "doc.StoredDoc")
+	proto.RegisterType((*MultiGetReply)(nil), "doc.MultiGetReply")
+	proto.RegisterType((*Text)(nil), "doc.Text")
+	proto.RegisterType((*Attri)(nil), "doc.Attri")
+	proto.RegisterType((*Logic)(nil), "doc.Logic")
+	proto.RegisterType((*Expr)(nil), "doc.Expr")
+	proto.RegisterType((*Word)(nil), "doc.Word")
+	proto.RegisterType((*WordsReq)(nil), "doc.WordsReq")
+	proto.RegisterType((*WordsReply)(nil), "doc.WordsReply")
+	proto.RegisterType((*TaskReq)(nil), "doc.TaskReq")
+	proto.RegisterType((*TaskFailure)(nil), "doc.TaskFailure")
+	proto.RegisterType((*TaskReply)(nil), "doc.TaskReply")
+	proto.RegisterType((*IndexReq)(nil), "doc.IndexReq")
+	proto.RegisterType((*IndexInfo)(nil), "doc.IndexInfo")
+	proto.RegisterType((*Alias)(nil), "doc.Alias")
+	proto.RegisterType((*IndexesReply)(nil), "doc.IndexesReply")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Greeter service
+
+type GreeterClient interface {
+	// Sends a greeting
+	HeartBeat(ctx context.Context, in *HeartReq, opts ...grpc.CallOption) (*Reply, error)
+	DocInx(ctx context.Context, in *DocReq, opts ...grpc.CallOption) (*Reply, error)
+	DocsInx(ctx context.Context, in *DocsReq, opts ...grpc.CallOption) (*DocsReply, error)
+	Delete(ctx context.Context, in *DeleteReq, opts ...grpc.CallOption) (*Reply, error)
+	Search(ctx context.Context, in *SearchReq, opts ...grpc.CallOption) (*SearchReply, error)
+	GetDoc(ctx context.Context, in *GetReq, opts ...grpc.CallOption) (*StoredDoc, error)
+	MultiGet(ctx context.Context, in *MultiGetReq, opts ...grpc.CallOption) (*MultiGetReply, error)
+	// Manage the words added to the gse dictionary at runtime
+	AddWords(ctx context.Context, in *WordsReq, opts ...grpc.CallOption) (*WordsReply, error)
+	RemoveWords(ctx context.Context, in *WordsReq, opts ...grpc.CallOption) (*WordsReply, error)
+	UserWords(ctx context.Context, in *WordsReq, opts ...grpc.CallOption) (*WordsReply, error)
+	// Get the status of the background task, or cancel it
+	Task(ctx context.Context, in *TaskReq, opts ...grpc.CallOption) (*TaskReply, error)
+	// Manage the named indexes under the index root
+	CreateIndex(ctx context.Context, in *IndexReq, opts ...grpc.CallOption) (*Reply, error)
+	OpenIndex(ctx context.Context, in *IndexReq, opts ...grpc.CallOption) (*Reply, error)
+	CloseIndex(ctx context.Context, in *IndexReq, opts ...grpc.CallOption) (*Reply, error)
+	DropIndex(ctx context.Context, in *IndexReq, opts ...grpc.CallOption) (*Reply, error)
+	ListIndexes(ctx context.Context, in *IndexReq, opts ...grpc.CallOption) (*IndexesReply, error)
+}
+
+type greeterClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewGreeterClient(cc *grpc.ClientConn) GreeterClient {
+	return &greeterClient{cc}
+}
+
+func (c *greeterClient) HeartBeat(ctx context.Context, in *HeartReq, opts ...grpc.CallOption) (*Reply, error) {
+	out := new(Reply)
+	err := c.cc.Invoke(ctx, "/doc.Greeter/HeartBeat", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *Logic) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Must {
-		dAtA[i] = 0x8
-		i++
-		if m.Must {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
-	}
-	if m.Should {
-		dAtA[i] = 0x10
-		i++
-		if m.Should {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
-	}
-	if m.NotIn {
-		dAtA[i] = 0x18
-		i++
-		if m.NotIn {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
-	}
-	if m.Expr != nil {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintDoc(dAtA, i, uint64(m.Expr.Size()))
-		n5, err := m.Expr.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n5
+func (c *greeterClient) DocInx(ctx context.Context, in *DocReq, opts ...grpc.CallOption) (*Reply, error) {
+	out := new(Reply)
+	err := c.cc.Invoke(ctx, "/doc.Greeter/DocInx", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	return out, nil
+}
+
+func (c *greeterClient) DocsInx(ctx context.Context, in *DocsReq, opts ...grpc.CallOption) (*DocsReply, error) {
+	out := new(DocsReply)
+	err := c.cc.Invoke(ctx, "/doc.Greeter/DocsInx", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return i, nil
+	return out, nil
 }
 
-func (m *Expr) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+func (c *greeterClient) Delete(ctx context.Context, in *DeleteReq, opts ...grpc.CallOption) (*Reply, error) {
+	out := new(Reply)
+	err := c.cc.Invoke(ctx, "/doc.Greeter/Delete", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *Expr) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Must) > 0 {
-		for _, s := range m.Must {
-			dAtA[i] = 0xa
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
+func (c *greeterClient) Search(ctx context.Context, in *SearchReq, opts ...grpc.CallOption) (*SearchReply, error) {
+	out := new(SearchReply)
+	err := c.cc.Invoke(ctx, "/doc.Greeter/Search", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if len(m.Should) > 0 {
-		for _, s := range m.Should {
-			dAtA[i] = 0x12
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
+	return out, nil
+}
+
+func (c *greeterClient) GetDoc(ctx context.Context, in *GetReq, opts ...grpc.CallOption) (*StoredDoc, error) {
+	out := new(StoredDoc)
+	err := c.cc.Invoke(ctx, "/doc.Greeter/GetDoc", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if len(m.NotIn) > 0 {
-		for _, s := range m.NotIn {
-			dAtA[i] = 0x1a
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
+	return out, nil
+}
+
+func (c *greeterClient) MultiGet(ctx context.Context, in *MultiGetReq, opts ...grpc.CallOption) (*MultiGetReply, error) {
+	out := new(MultiGetReply)
+	err := c.cc.Invoke(ctx, "/doc.Greeter/MultiGet", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	return out, nil
+}
+
+func (c *greeterClient) AddWords(ctx context.Context, in *WordsReq, opts ...grpc.CallOption) (*WordsReply, error) {
+	out := new(WordsReply)
+	err := c.cc.Invoke(ctx, "/doc.Greeter/AddWords", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return i, nil
+	return out, nil
 }
 
-func encodeVarintDoc(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (c *greeterClient) RemoveWords(ctx context.Context, in *WordsReq, opts ...grpc.CallOption) (*WordsReply, error) {
+	out := new(WordsReply)
+	err := c.cc.Invoke(ctx, "/doc.Greeter/RemoveWords", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return out, nil
 }
-func (m *HeartReq) Size() (n int) {
-	if m == nil {
-		return 0
+
+func (c *greeterClient) UserWords(ctx context.Context, in *WordsReq, opts ...grpc.CallOption) (*WordsReply, error) {
+	out := new(WordsReply)
+	err := c.cc.Invoke(ctx, "/doc.Greeter/UserWords", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.Msg != 0 {
-		n += 1 + sovDoc(uint64(m.Msg))
+	return out, nil
+}
+
+func (c *greeterClient) Task(ctx context.Context, in *TaskReq, opts ...grpc.CallOption) (*TaskReply, error) {
+	out := new(TaskReply)
+	err := c.cc.Invoke(ctx, "/doc.Greeter/Task", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return out, nil
+}
+
+func (c *greeterClient) CreateIndex(ctx context.Context, in *IndexReq, opts ...grpc.CallOption) (*Reply, error) {
+	out := new(Reply)
+	err := c.cc.Invoke(ctx, "/doc.Greeter/CreateIndex", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return out, nil
 }
 
-func (m *DocReq) Size() (n int) {
-	if m == nil {
-		return 0
+func (c *greeterClient) OpenIndex(ctx context.Context, in *IndexReq, opts ...grpc.CallOption) (*Reply, error) {
+	out := new(Reply)
+	err := c.cc.Invoke(ctx, "/doc.Greeter/OpenIndex", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = len(m.DocId)
-	if l > 0 {
-		n += 1 + l + sovDoc(uint64(l))
+	return out, nil
+}
+
+func (c *greeterClient) CloseIndex(ctx context.Context, in *IndexReq, opts ...grpc.CallOption) (*Reply, error) {
+	out := new(Reply)
+	err := c.cc.Invoke(ctx, "/doc.Greeter/CloseIndex", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	l = len(m.Content)
-	if l > 0 {
-		n += 1 + l + sovDoc(uint64(l))
+	return out, nil
+}
+
+func (c *greeterClient) DropIndex(ctx context.Context, in *IndexReq, opts ...grpc.CallOption) (*Reply, error) {
+	out := new(Reply)
+	err := c.cc.Invoke(ctx, "/doc.Greeter/DropIndex", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	l = len(m.Attri)
-	if l > 0 {
-		n += 1 + l + sovDoc(uint64(l))
+	return out, nil
+}
+
+func (c *greeterClient) ListIndexes(ctx context.Context, in *IndexReq, opts ...grpc.CallOption) (*IndexesReply, error) {
+	out := new(IndexesReply)
+	err := c.cc.Invoke(ctx, "/doc.Greeter/ListIndexes", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if len(m.Tokens) > 0 {
-		for _, e := range m.Tokens {
-			l = e.Size()
-			n += 1 + l + sovDoc(uint64(l))
-		}
-	}
-	if len(m.Labels) > 0 {
-		for _, s := range m.Labels {
-			l = len(s)
-			n += 1 + l + sovDoc(uint64(l))
-		}
+	return out, nil
+}
+
+// Server API for Greeter service
+
+type GreeterServer interface {
+	// Sends a greeting
+	HeartBeat(context.Context, *HeartReq) (*Reply, error)
+	DocInx(context.Context, *DocReq) (*Reply, error)
+	DocsInx(context.Context, *DocsReq) (*DocsReply, error)
+	Delete(context.Context, *DeleteReq) (*Reply, error)
+	Search(context.Context, *SearchReq) (*SearchReply, error)
+	GetDoc(context.Context, *GetReq) (*StoredDoc, error)
+	MultiGet(context.Context, *MultiGetReq) (*MultiGetReply, error)
+	// Manage the words added to the gse dictionary at runtime
+	AddWords(context.Context, *WordsReq) (*WordsReply, error)
+	RemoveWords(context.Context, *WordsReq) (*WordsReply, error)
+	UserWords(context.Context, *WordsReq) (*WordsReply, error)
+	// Get the status of the background task, or cancel it
+	Task(context.Context, *TaskReq) (*TaskReply, error)
+	// Manage the named indexes under the index root
+	CreateIndex(context.Context, *IndexReq) (*Reply, error)
+	OpenIndex(context.Context, *IndexReq) (*Reply, error)
+	CloseIndex(context.Context, *IndexReq) (*Reply, error)
+	DropIndex(context.Context, *IndexReq) (*Reply, error)
+	ListIndexes(context.Context, *IndexReq) (*IndexesReply, error)
+}
+
+func RegisterGreeterServer(s *grpc.Server, srv GreeterServer) {
+	s.RegisterService(&_Greeter_serviceDesc, srv)
+}
+
+func _Greeter_HeartBeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeartReq)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	l = len(m.Fields)
-	if l > 0 {
-		n += 1 + l + sovDoc(uint64(l))
+	if interceptor == nil {
+		return srv.(GreeterServer).HeartBeat(ctx, in)
 	}
-	if m.ForceUpdate {
-		n += 2
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doc.Greeter/HeartBeat",
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).HeartBeat(ctx, req.(*HeartReq))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *TokenData) Size() (n int) {
-	if m == nil {
-		return 0
+func _Greeter_DocInx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DocReq)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = len(m.Text)
-	if l > 0 {
-		n += 1 + l + sovDoc(uint64(l))
+	if interceptor == nil {
+		return srv.(GreeterServer).DocInx(ctx, in)
 	}
-	if len(m.Locations) > 0 {
-		l = 0
-		for _, e := range m.Locations {
-			l += sovDoc(uint64(e))
-		}
-		n += 1 + sovDoc(uint64(l)) + l
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doc.Greeter/DocInx",
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).DocInx(ctx, req.(*DocReq))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *DeleteReq) Size() (n int) {
-	if m == nil {
-		return 0
+func _Greeter_DocsInx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DocsReq)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = len(m.DocId)
-	if l > 0 {
-		n += 1 + l + sovDoc(uint64(l))
+	if interceptor == nil {
+		return srv.(GreeterServer).DocsInx(ctx, in)
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doc.Greeter/DocsInx",
 	}
-	return n
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).DocsInx(ctx, req.(*DocsReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *Reply) Size() (n int) {
-	if m == nil {
-		return 0
+func _Greeter_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteReq)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.Result != 0 {
-		n += 1 + sovDoc(uint64(m.Result))
+	if interceptor == nil {
+		return srv.(GreeterServer).Delete(ctx, in)
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doc.Greeter/Delete",
 	}
-	return n
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).Delete(ctx, req.(*DeleteReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *SearchReq) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Id)
-	if l > 0 {
-		n += 1 + l + sovDoc(uint64(l))
+func _Greeter_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchReq)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	l = len(m.Query)
-	if l > 0 {
-		n += 1 + l + sovDoc(uint64(l))
+	if interceptor == nil {
+		return srv.(GreeterServer).Search(ctx, in)
 	}
-	if m.OutputOffset != 0 {
-		n += 1 + sovDoc(uint64(m.OutputOffset))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doc.Greeter/Search",
 	}
-	if m.MaxOutputs != 0 {
-		n += 1 + sovDoc(uint64(m.MaxOutputs))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).Search(ctx, req.(*SearchReq))
 	}
-	l = len(m.Time)
-	if l > 0 {
-		n += 1 + l + sovDoc(uint64(l))
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_GetDoc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReq)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if len(m.DocIds) > 0 {
-		for k, v := range m.DocIds {
-			_ = k
-			_ = v
-			mapEntrySize := 1 + len(k) + sovDoc(uint64(len(k))) + 1 + 1
-			n += mapEntrySize + 1 + sovDoc(uint64(mapEntrySize))
-		}
+	if interceptor == nil {
+		return srv.(GreeterServer).GetDoc(ctx, in)
 	}
-	if m.Logic != nil {
-		l = m.Logic.Size()
-		n += 1 + l + sovDoc(uint64(l))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doc.Greeter/GetDoc",
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).GetDoc(ctx, req.(*GetReq))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *SearchReply) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Code != 0 {
-		n += 1 + sovDoc(uint64(m.Code))
-	}
-	if m.Len != 0 {
-		n += 1 + sovDoc(uint64(m.Len))
+func _Greeter_MultiGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MultiGetReq)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.Timestamp != 0 {
-		n += 1 + sovDoc(uint64(m.Timestamp))
+	if interceptor == nil {
+		return srv.(GreeterServer).MultiGet(ctx, in)
 	}
-	if len(m.Docs) > 0 {
-		for _, e := range m.Docs {
-			l = e.Size()
-			n += 1 + l + sovDoc(uint64(l))
-		}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doc.Greeter/MultiGet",
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).MultiGet(ctx, req.(*MultiGetReq))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *Text) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Id)
-	if l > 0 {
-		n += 1 + l + sovDoc(uint64(l))
+func _Greeter_AddWords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WordsReq)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	l = len(m.Content)
-	if l > 0 {
-		n += 1 + l + sovDoc(uint64(l))
+	if interceptor == nil {
+		return srv.(GreeterServer).AddWords(ctx, in)
 	}
-	if m.Attri != nil {
-		l = m.Attri.Size()
-		n += 1 + l + sovDoc(uint64(l))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doc.Greeter/AddWords",
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).AddWords(ctx, req.(*WordsReq))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *Attri) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Title)
-	if l > 0 {
-		n += 1 + l + sovDoc(uint64(l))
-	}
-	l = len(m.Author)
-	if l > 0 {
-		n += 1 + l + sovDoc(uint64(l))
+func _Greeter_RemoveWords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WordsReq)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	l = len(m.Time)
-	if l > 0 {
-		n += 1 + l + sovDoc(uint64(l))
+	if interceptor == nil {
+		return srv.(GreeterServer).RemoveWords(ctx, in)
 	}
-	if m.Ts != 0 {
-		n += 1 + sovDoc(uint64(m.Ts))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doc.Greeter/RemoveWords",
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).RemoveWords(ctx, req.(*WordsReq))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *Logic) Size() (n int) {
-	if m == nil {
-		return 0
+func _Greeter_UserWords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WordsReq)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.Must {
-		n += 2
+	if interceptor == nil {
+		return srv.(GreeterServer).UserWords(ctx, in)
 	}
-	if m.Should {
-		n += 2
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doc.Greeter/UserWords",
 	}
-	if m.NotIn {
-		n += 2
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).UserWords(ctx, req.(*WordsReq))
 	}
-	if m.Expr != nil {
-		l = m.Expr.Size()
-		n += 1 + l + sovDoc(uint64(l))
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_Task_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).Task(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doc.Greeter/Task",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).Task(ctx, req.(*TaskReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_CreateIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IndexReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).CreateIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doc.Greeter/CreateIndex",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).CreateIndex(ctx, req.(*IndexReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_OpenIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IndexReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).OpenIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doc.Greeter/OpenIndex",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).OpenIndex(ctx, req.(*IndexReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_CloseIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IndexReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).CloseIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doc.Greeter/CloseIndex",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).CloseIndex(ctx, req.(*IndexReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_DropIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IndexReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).DropIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doc.Greeter/DropIndex",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).DropIndex(ctx, req.(*IndexReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_ListIndexes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IndexReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).ListIndexes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doc.Greeter/ListIndexes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).ListIndexes(ctx, req.(*IndexReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Greeter_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "doc.Greeter",
+	HandlerType: (*GreeterServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "HeartBeat",
+			Handler:    _Greeter_HeartBeat_Handler,
+		},
+		{
+			MethodName: "DocInx",
+			Handler:    _Greeter_DocInx_Handler,
+		},
+		{
+			MethodName: "DocsInx",
+			Handler:    _Greeter_DocsInx_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _Greeter_Delete_Handler,
+		},
+		{
+			MethodName: "Search",
+			Handler:    _Greeter_Search_Handler,
+		},
+		{
+			MethodName: "GetDoc",
+			Handler:    _Greeter_GetDoc_Handler,
+		},
+		{
+			MethodName: "MultiGet",
+			Handler:    _Greeter_MultiGet_Handler,
+		},
+		{
+			MethodName: "AddWords",
+			Handler:    _Greeter_AddWords_Handler,
+		},
+		{
+			MethodName: "RemoveWords",
+			Handler:    _Greeter_RemoveWords_Handler,
+		},
+		{
+			MethodName: "UserWords",
+			Handler:    _Greeter_UserWords_Handler,
+		},
+		{
+			MethodName: "Task",
+			Handler:    _Greeter_Task_Handler,
+		},
+		{
+			MethodName: "CreateIndex",
+			Handler:    _Greeter_CreateIndex_Handler,
+		},
+		{
+			MethodName: "OpenIndex",
+			Handler:    _Greeter_OpenIndex_Handler,
+		},
+		{
+			MethodName: "CloseIndex",
+			Handler:    _Greeter_CloseIndex_Handler,
+		},
+		{
+			MethodName: "DropIndex",
+			Handler:    _Greeter_DropIndex_Handler,
+		},
+		{
+			MethodName: "ListIndexes",
+			Handler:    _Greeter_ListIndexes_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "doc.proto",
+}
+
+func (m *HeartReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HeartReq) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Msg != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(m.Msg))
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *Expr) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *DocReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *DocReq) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if len(m.Must) > 0 {
-		for _, s := range m.Must {
-			l = len(s)
-			n += 1 + l + sovDoc(uint64(l))
+	if len(m.DocId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.DocId)))
+		i += copy(dAtA[i:], m.DocId)
+	}
+	if len(m.Content) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Content)))
+		i += copy(dAtA[i:], m.Content)
+	}
+	if len(m.Attri) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Attri)))
+		i += copy(dAtA[i:], m.Attri)
+	}
+	if len(m.Tokens) > 0 {
+		for _, msg := range m.Tokens {
+			dAtA[i] = 0x22
+			i++
+			i = encodeVarintDoc(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
 		}
 	}
-	if len(m.Should) > 0 {
-		for _, s := range m.Should {
+	if len(m.Labels) > 0 {
+		for _, s := range m.Labels {
+			dAtA[i] = 0x2a
+			i++
 			l = len(s)
-			n += 1 + l + sovDoc(uint64(l))
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
 		}
 	}
-	if len(m.NotIn) > 0 {
-		for _, s := range m.NotIn {
-			l = len(s)
-			n += 1 + l + sovDoc(uint64(l))
+	if len(m.Fields) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Fields)))
+		i += copy(dAtA[i:], m.Fields)
+	}
+	if m.ForceUpdate {
+		dAtA[i] = 0x38
+		i++
+		if m.ForceUpdate {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Version != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(m.Version))
+	}
+	if len(m.Index) > 0 {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Index)))
+		i += copy(dAtA[i:], m.Index)
+	}
+	if len(m.Routing) > 0 {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Routing)))
+		i += copy(dAtA[i:], m.Routing)
+	}
+	if m.ExpireAt != 0 {
+		dAtA[i] = 0x58
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(m.ExpireAt))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *DocsReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DocsReq) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Docs) > 0 {
+		for _, msg := range m.Docs {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintDoc(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.ForceUpdate {
+		dAtA[i] = 0x10
+		i++
+		if m.ForceUpdate {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.Index) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Index)))
+		i += copy(dAtA[i:], m.Index)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *DocsReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DocsReply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Results) > 0 {
+		for _, msg := range m.Results {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintDoc(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *DocResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DocResult) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.DocId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.DocId)))
+		i += copy(dAtA[i:], m.DocId)
+	}
+	if m.Result != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(m.Result))
+	}
+	if len(m.Msg) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Msg)))
+		i += copy(dAtA[i:], m.Msg)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *TokenData) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TokenData) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Text) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Text)))
+		i += copy(dAtA[i:], m.Text)
+	}
+	if len(m.Locations) > 0 {
+		dAtA2 := make([]byte, len(m.Locations)*10)
+		var j1 int
+		for _, num1 := range m.Locations {
+			num := uint64(num1)
+			for num >= 1<<7 {
+				dAtA2[j1] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j1++
+			}
+			dAtA2[j1] = uint8(num)
+			j1++
+		}
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(j1))
+		i += copy(dAtA[i:], dAtA2[:j1])
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *DeleteReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteReq) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.DocId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.DocId)))
+		i += copy(dAtA[i:], m.DocId)
+	}
+	if m.Version != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(m.Version))
+	}
+	if len(m.Index) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Index)))
+		i += copy(dAtA[i:], m.Index)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Reply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Reply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Result != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(m.Result))
+	}
+	if len(m.Msg) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Msg)))
+		i += copy(dAtA[i:], m.Msg)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *SearchReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SearchReq) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Id) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
+	}
+	if len(m.Query) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Query)))
+		i += copy(dAtA[i:], m.Query)
+	}
+	if m.OutputOffset != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(m.OutputOffset))
+	}
+	if m.MaxOutputs != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(m.MaxOutputs))
+	}
+	if len(m.Time) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Time)))
+		i += copy(dAtA[i:], m.Time)
+	}
+	if len(m.DocIds) > 0 {
+		for k, _ := range m.DocIds {
+			dAtA[i] = 0x32
+			i++
+			v := m.DocIds[k]
+			mapSize := 1 + len(k) + sovDoc(uint64(len(k))) + 1 + 1
+			i = encodeVarintDoc(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintDoc(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x10
+			i++
+			if v {
+				dAtA[i] = 1
+			} else {
+				dAtA[i] = 0
+			}
+			i++
+		}
+	}
+	if m.Logic != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(m.Logic.Size()))
+		n3, err := m.Logic.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
+	}
+	if len(m.Index) > 0 {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Index)))
+		i += copy(dAtA[i:], m.Index)
+	}
+	if len(m.Filters) > 0 {
+		for _, msg := range m.Filters {
+			dAtA[i] = 0x4a
+			i++
+			i = encodeVarintDoc(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.SubField) > 0 {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.SubField)))
+		i += copy(dAtA[i:], m.SubField)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Filter) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Filter) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Labels) > 0 {
+		for _, s := range m.Labels {
+			dAtA[i] = 0xa
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.DocIds) > 0 {
+		for _, s := range m.DocIds {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.Not {
+		dAtA[i] = 0x18
+		i++
+		if m.Not {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *SearchReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SearchReply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Code != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(m.Code))
+	}
+	if m.Len != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(m.Len))
+	}
+	if m.Timestamp != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(m.Timestamp))
+	}
+	if len(m.Docs) > 0 {
+		for _, msg := range m.Docs {
+			dAtA[i] = 0x22
+			i++
+			i = encodeVarintDoc(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *GetReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetReq) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.DocId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.DocId)))
+		i += copy(dAtA[i:], m.DocId)
+	}
+	if len(m.Index) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Index)))
+		i += copy(dAtA[i:], m.Index)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *MultiGetReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MultiGetReq) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.DocIds) > 0 {
+		for _, s := range m.DocIds {
+			dAtA[i] = 0xa
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Index) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Index)))
+		i += copy(dAtA[i:], m.Index)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *StoredDoc) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StoredDoc) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.DocId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.DocId)))
+		i += copy(dAtA[i:], m.DocId)
+	}
+	if m.Result != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(m.Result))
+	}
+	if len(m.Msg) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Msg)))
+		i += copy(dAtA[i:], m.Msg)
+	}
+	if len(m.Content) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Content)))
+		i += copy(dAtA[i:], m.Content)
+	}
+	if m.Attri != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(m.Attri.Size()))
+		n4, err := m.Attri.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n4
+	}
+	if len(m.Labels) > 0 {
+		for _, s := range m.Labels {
+			dAtA[i] = 0x32
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.Version != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(m.Version))
+	}
+	if len(m.Routing) > 0 {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Routing)))
+		i += copy(dAtA[i:], m.Routing)
+	}
+	if m.ExpireAt != 0 {
+		dAtA[i] = 0x48
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(m.ExpireAt))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *MultiGetReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MultiGetReply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Docs) > 0 {
+		for _, msg := range m.Docs {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintDoc(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Text) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Text) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Id) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
+	}
+	if len(m.Content) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Content)))
+		i += copy(dAtA[i:], m.Content)
+	}
+	if m.Attri != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(m.Attri.Size()))
+		n5, err := m.Attri.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n5
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Attri) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Attri) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Title) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Title)))
+		i += copy(dAtA[i:], m.Title)
+	}
+	if len(m.Author) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Author)))
+		i += copy(dAtA[i:], m.Author)
+	}
+	if len(m.Time) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Time)))
+		i += copy(dAtA[i:], m.Time)
+	}
+	if m.Ts != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(m.Ts))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Logic) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Logic) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Must {
+		dAtA[i] = 0x8
+		i++
+		if m.Must {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Should {
+		dAtA[i] = 0x10
+		i++
+		if m.Should {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.NotIn {
+		dAtA[i] = 0x18
+		i++
+		if m.NotIn {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Expr != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(m.Expr.Size()))
+		n6, err := m.Expr.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n6
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Expr) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Expr) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Must) > 0 {
+		for _, s := range m.Must {
+			dAtA[i] = 0xa
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Should) > 0 {
+		for _, s := range m.Should {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.NotIn) > 0 {
+		for _, s := range m.NotIn {
+			dAtA[i] = 0x1a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Word) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Word) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Text) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Text)))
+		i += copy(dAtA[i:], m.Text)
+	}
+	if m.Freq != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(m.Freq))
+	}
+	if len(m.Pos) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Pos)))
+		i += copy(dAtA[i:], m.Pos)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *WordsReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WordsReq) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Words) > 0 {
+		for _, msg := range m.Words {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintDoc(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Index) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Index)))
+		i += copy(dAtA[i:], m.Index)
+	}
+	if m.Reanalyze {
+		dAtA[i] = 0x18
+		i++
+		if m.Reanalyze {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *WordsReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WordsReply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Result != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(m.Result))
+	}
+	if len(m.Msg) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Msg)))
+		i += copy(dAtA[i:], m.Msg)
+	}
+	if len(m.TaskId) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.TaskId)))
+		i += copy(dAtA[i:], m.TaskId)
+	}
+	if len(m.Words) > 0 {
+		for _, msg := range m.Words {
+			dAtA[i] = 0x22
+			i++
+			i = encodeVarintDoc(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *TaskReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TaskReq) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Index) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Index)))
+		i += copy(dAtA[i:], m.Index)
+	}
+	if len(m.Id) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
+	}
+	if m.Cancel {
+		dAtA[i] = 0x18
+		i++
+		if m.Cancel {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *TaskFailure) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TaskFailure) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.DocId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.DocId)))
+		i += copy(dAtA[i:], m.DocId)
+	}
+	if len(m.Err) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Err)))
+		i += copy(dAtA[i:], m.Err)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *TaskReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TaskReply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Result != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(m.Result))
+	}
+	if len(m.Msg) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Msg)))
+		i += copy(dAtA[i:], m.Msg)
+	}
+	if len(m.Id) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
+	}
+	if len(m.Kind) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Kind)))
+		i += copy(dAtA[i:], m.Kind)
+	}
+	if m.Total != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(m.Total))
+	}
+	if m.Done != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(m.Done))
+	}
+	if m.Failed != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(m.Failed))
+	}
+	if len(m.Failures) > 0 {
+		for _, msg := range m.Failures {
+			dAtA[i] = 0x42
+			i++
+			i = encodeVarintDoc(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.Canceled {
+		dAtA[i] = 0x48
+		i++
+		if m.Canceled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Finished {
+		dAtA[i] = 0x50
+		i++
+		if m.Finished {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *IndexReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *IndexReq) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Index) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Index)))
+		i += copy(dAtA[i:], m.Index)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *IndexInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *IndexInfo) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if m.Opened {
+		dAtA[i] = 0x10
+		i++
+		if m.Opened {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.NumDocsIndexed != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(m.NumDocsIndexed))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Alias) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Alias) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Alias) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Alias)))
+		i += copy(dAtA[i:], m.Alias)
+	}
+	if len(m.Index) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Index)))
+		i += copy(dAtA[i:], m.Index)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *IndexesReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *IndexesReply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Result != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(m.Result))
+	}
+	if len(m.Msg) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Msg)))
+		i += copy(dAtA[i:], m.Msg)
+	}
+	if len(m.Indexes) > 0 {
+		for _, msg := range m.Indexes {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintDoc(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Aliases) > 0 {
+		for _, msg := range m.Aliases {
+			dAtA[i] = 0x22
+			i++
+			i = encodeVarintDoc(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func encodeVarintDoc(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *HeartReq) Size() (n int) {
+	var l int
+	_ = l
+	if m.Msg != 0 {
+		n += 1 + sovDoc(uint64(m.Msg))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DocReq) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.DocId)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	l = len(m.Content)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	l = len(m.Attri)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	if len(m.Tokens) > 0 {
+		for _, e := range m.Tokens {
+			l = e.Size()
+			n += 1 + l + sovDoc(uint64(l))
+		}
+	}
+	if len(m.Labels) > 0 {
+		for _, s := range m.Labels {
+			l = len(s)
+			n += 1 + l + sovDoc(uint64(l))
+		}
+	}
+	l = len(m.Fields)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	if m.ForceUpdate {
+		n += 2
+	}
+	if m.Version != 0 {
+		n += 1 + sovDoc(uint64(m.Version))
+	}
+	l = len(m.Index)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	l = len(m.Routing)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	if m.ExpireAt != 0 {
+		n += 1 + sovDoc(uint64(m.ExpireAt))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DocsReq) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Docs) > 0 {
+		for _, e := range m.Docs {
+			l = e.Size()
+			n += 1 + l + sovDoc(uint64(l))
+		}
+	}
+	if m.ForceUpdate {
+		n += 2
+	}
+	l = len(m.Index)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DocsReply) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Results) > 0 {
+		for _, e := range m.Results {
+			l = e.Size()
+			n += 1 + l + sovDoc(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DocResult) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.DocId)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	if m.Result != 0 {
+		n += 1 + sovDoc(uint64(m.Result))
+	}
+	l = len(m.Msg)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TokenData) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Text)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	if len(m.Locations) > 0 {
+		l = 0
+		for _, e := range m.Locations {
+			l += sovDoc(uint64(e))
+		}
+		n += 1 + sovDoc(uint64(l)) + l
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeleteReq) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.DocId)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 1 + sovDoc(uint64(m.Version))
+	}
+	l = len(m.Index)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Reply) Size() (n int) {
+	var l int
+	_ = l
+	if m.Result != 0 {
+		n += 1 + sovDoc(uint64(m.Result))
+	}
+	l = len(m.Msg)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SearchReq) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	l = len(m.Query)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	if m.OutputOffset != 0 {
+		n += 1 + sovDoc(uint64(m.OutputOffset))
+	}
+	if m.MaxOutputs != 0 {
+		n += 1 + sovDoc(uint64(m.MaxOutputs))
+	}
+	l = len(m.Time)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	if len(m.DocIds) > 0 {
+		for k, v := range m.DocIds {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovDoc(uint64(len(k))) + 1 + 1
+			n += mapEntrySize + 1 + sovDoc(uint64(mapEntrySize))
+		}
+	}
+	if m.Logic != nil {
+		l = m.Logic.Size()
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	l = len(m.Index)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	if len(m.Filters) > 0 {
+		for _, e := range m.Filters {
+			l = e.Size()
+			n += 1 + l + sovDoc(uint64(l))
+		}
+	}
+	l = len(m.SubField)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Filter) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Labels) > 0 {
+		for _, s := range m.Labels {
+			l = len(s)
+			n += 1 + l + sovDoc(uint64(l))
+		}
+	}
+	if len(m.DocIds) > 0 {
+		for _, s := range m.DocIds {
+			l = len(s)
+			n += 1 + l + sovDoc(uint64(l))
+		}
+	}
+	if m.Not {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SearchReply) Size() (n int) {
+	var l int
+	_ = l
+	if m.Code != 0 {
+		n += 1 + sovDoc(uint64(m.Code))
+	}
+	if m.Len != 0 {
+		n += 1 + sovDoc(uint64(m.Len))
+	}
+	if m.Timestamp != 0 {
+		n += 1 + sovDoc(uint64(m.Timestamp))
+	}
+	if len(m.Docs) > 0 {
+		for _, e := range m.Docs {
+			l = e.Size()
+			n += 1 + l + sovDoc(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetReq) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.DocId)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	l = len(m.Index)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *MultiGetReq) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.DocIds) > 0 {
+		for _, s := range m.DocIds {
+			l = len(s)
+			n += 1 + l + sovDoc(uint64(l))
+		}
+	}
+	l = len(m.Index)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *StoredDoc) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.DocId)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	if m.Result != 0 {
+		n += 1 + sovDoc(uint64(m.Result))
+	}
+	l = len(m.Msg)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	l = len(m.Content)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	if m.Attri != nil {
+		l = m.Attri.Size()
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	if len(m.Labels) > 0 {
+		for _, s := range m.Labels {
+			l = len(s)
+			n += 1 + l + sovDoc(uint64(l))
+		}
+	}
+	if m.Version != 0 {
+		n += 1 + sovDoc(uint64(m.Version))
+	}
+	l = len(m.Routing)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	if m.ExpireAt != 0 {
+		n += 1 + sovDoc(uint64(m.ExpireAt))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *MultiGetReply) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Docs) > 0 {
+		for _, e := range m.Docs {
+			l = e.Size()
+			n += 1 + l + sovDoc(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Text) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	l = len(m.Content)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	if m.Attri != nil {
+		l = m.Attri.Size()
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Attri) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Title)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	l = len(m.Author)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	l = len(m.Time)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	if m.Ts != 0 {
+		n += 1 + sovDoc(uint64(m.Ts))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Logic) Size() (n int) {
+	var l int
+	_ = l
+	if m.Must {
+		n += 2
+	}
+	if m.Should {
+		n += 2
+	}
+	if m.NotIn {
+		n += 2
+	}
+	if m.Expr != nil {
+		l = m.Expr.Size()
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Expr) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Must) > 0 {
+		for _, s := range m.Must {
+			l = len(s)
+			n += 1 + l + sovDoc(uint64(l))
+		}
+	}
+	if len(m.Should) > 0 {
+		for _, s := range m.Should {
+			l = len(s)
+			n += 1 + l + sovDoc(uint64(l))
+		}
+	}
+	if len(m.NotIn) > 0 {
+		for _, s := range m.NotIn {
+			l = len(s)
+			n += 1 + l + sovDoc(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Word) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Text)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	if m.Freq != 0 {
+		n += 1 + sovDoc(uint64(m.Freq))
+	}
+	l = len(m.Pos)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *WordsReq) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Words) > 0 {
+		for _, e := range m.Words {
+			l = e.Size()
+			n += 1 + l + sovDoc(uint64(l))
+		}
+	}
+	l = len(m.Index)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	if m.Reanalyze {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *WordsReply) Size() (n int) {
+	var l int
+	_ = l
+	if m.Result != 0 {
+		n += 1 + sovDoc(uint64(m.Result))
+	}
+	l = len(m.Msg)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	l = len(m.TaskId)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	if len(m.Words) > 0 {
+		for _, e := range m.Words {
+			l = e.Size()
+			n += 1 + l + sovDoc(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TaskReq) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Index)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	if m.Cancel {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TaskFailure) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.DocId)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	l = len(m.Err)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TaskReply) Size() (n int) {
+	var l int
+	_ = l
+	if m.Result != 0 {
+		n += 1 + sovDoc(uint64(m.Result))
+	}
+	l = len(m.Msg)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	l = len(m.Kind)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	if m.Total != 0 {
+		n += 1 + sovDoc(uint64(m.Total))
+	}
+	if m.Done != 0 {
+		n += 1 + sovDoc(uint64(m.Done))
+	}
+	if m.Failed != 0 {
+		n += 1 + sovDoc(uint64(m.Failed))
+	}
+	if len(m.Failures) > 0 {
+		for _, e := range m.Failures {
+			l = e.Size()
+			n += 1 + l + sovDoc(uint64(l))
+		}
+	}
+	if m.Canceled {
+		n += 2
+	}
+	if m.Finished {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *IndexReq) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Index)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *IndexInfo) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	if m.Opened {
+		n += 2
+	}
+	if m.NumDocsIndexed != 0 {
+		n += 1 + sovDoc(uint64(m.NumDocsIndexed))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Alias) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Alias)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	l = len(m.Index)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *IndexesReply) Size() (n int) {
+	var l int
+	_ = l
+	if m.Result != 0 {
+		n += 1 + sovDoc(uint64(m.Result))
+	}
+	l = len(m.Msg)
+	if l > 0 {
+		n += 1 + l + sovDoc(uint64(l))
+	}
+	if len(m.Indexes) > 0 {
+		for _, e := range m.Indexes {
+			l = e.Size()
+			n += 1 + l + sovDoc(uint64(l))
+		}
+	}
+	if len(m.Aliases) > 0 {
+		for _, e := range m.Aliases {
+			l = e.Size()
+			n += 1 + l + sovDoc(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovDoc(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozDoc(x uint64) (n int) {
+	return sovDoc(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *HeartReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDoc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HeartReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HeartReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
+			}
+			m.Msg = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Msg |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDoc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDoc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DocReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDoc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DocReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DocReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DocId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DocId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Content", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Content = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attri", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Attri = append(m.Attri[:0], dAtA[iNdEx:postIndex]...)
+			if m.Attri == nil {
+				m.Attri = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tokens", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tokens = append(m.Tokens, &TokenData{})
+			if err := m.Tokens[len(m.Tokens)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Labels = append(m.Labels, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Fields", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Fields = append(m.Fields[:0], dAtA[iNdEx:postIndex]...)
+			if m.Fields == nil {
+				m.Fields = []byte{}
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ForceUpdate", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ForceUpdate = bool(v != 0)
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Index = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Routing", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Routing = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpireAt", wireType)
+			}
+			m.ExpireAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpireAt |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDoc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDoc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DocsReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDoc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DocsReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DocsReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Docs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Docs = append(m.Docs, &DocReq{})
+			if err := m.Docs[len(m.Docs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ForceUpdate", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ForceUpdate = bool(v != 0)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Index = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDoc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDoc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DocsReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDoc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DocsReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DocsReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Results", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Results = append(m.Results, &DocResult{})
+			if err := m.Results[len(m.Results)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDoc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDoc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DocResult) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDoc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DocResult: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DocResult: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DocId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DocId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+			}
+			m.Result = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Result |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Msg = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDoc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDoc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TokenData) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDoc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TokenData: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TokenData: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Text", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Text = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType == 0 {
+				var v int32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowDoc
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (int32(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Locations = append(m.Locations, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowDoc
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthDoc
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v int32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowDoc
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (int32(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Locations = append(m.Locations, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Locations", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDoc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDoc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeleteReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDoc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeleteReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeleteReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DocId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DocId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Index = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDoc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDoc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Reply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDoc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Reply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Reply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+			}
+			m.Result = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Result |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Msg = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDoc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDoc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SearchReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDoc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SearchReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SearchReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Query = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OutputOffset", wireType)
+			}
+			m.OutputOffset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.OutputOffset |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxOutputs", wireType)
+			}
+			m.MaxOutputs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxOutputs |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Time = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DocIds", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DocIds == nil {
+				m.DocIds = make(map[string]bool)
+			}
+			var mapkey string
+			var mapvalue bool
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowDoc
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowDoc
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthDoc
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapvaluetemp int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowDoc
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapvaluetemp |= (int(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					mapvalue = bool(mapvaluetemp != 0)
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipDoc(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthDoc
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.DocIds[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Logic", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Logic == nil {
+				m.Logic = &Logic{}
+			}
+			if err := m.Logic.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Index = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Filters", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Filters = append(m.Filters, &Filter{})
+			if err := m.Filters[len(m.Filters)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SubField", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SubField = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDoc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDoc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Filter) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDoc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Filter: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Filter: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Labels = append(m.Labels, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DocIds", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DocIds = append(m.DocIds, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Not", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Not = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDoc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDoc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SearchReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDoc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SearchReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SearchReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			m.Code = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Code |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Len", wireType)
+			}
+			m.Len = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Len |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Docs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Docs = append(m.Docs, &Text{})
+			if err := m.Docs[len(m.Docs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDoc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDoc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDoc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DocId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DocId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Index = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDoc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDoc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MultiGetReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDoc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MultiGetReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MultiGetReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DocIds", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DocIds = append(m.DocIds, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Index = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDoc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDoc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StoredDoc) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDoc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StoredDoc: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StoredDoc: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DocId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DocId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+			}
+			m.Result = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Result |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Msg = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Content", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Content = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attri", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Attri == nil {
+				m.Attri = &Attri{}
+			}
+			if err := m.Attri.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Labels = append(m.Labels, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Routing", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Routing = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpireAt", wireType)
+			}
+			m.ExpireAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpireAt |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDoc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDoc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MultiGetReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDoc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MultiGetReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MultiGetReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Docs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Docs = append(m.Docs, &StoredDoc{})
+			if err := m.Docs[len(m.Docs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDoc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDoc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Text) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDoc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Text: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Text: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Content", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Content = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attri", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Attri == nil {
+				m.Attri = &Attri{}
+			}
+			if err := m.Attri.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDoc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDoc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Attri) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDoc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Attri: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Attri: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Title", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Title = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Author", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Author = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Time = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ts", wireType)
+			}
+			m.Ts = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Ts |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDoc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDoc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Logic) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDoc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Logic: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Logic: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Must", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Must = bool(v != 0)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Should", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Should = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NotIn", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.NotIn = bool(v != 0)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expr", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Expr == nil {
+				m.Expr = &Expr{}
+			}
+			if err := m.Expr.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDoc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDoc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
 		}
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
-}
 
-func sovDoc(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-func sozDoc(x uint64) (n int) {
-	return sovDoc(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *HeartReq) Unmarshal(dAtA []byte) error {
+func (m *Expr) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1829,17 +7648,17 @@ func (m *HeartReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HeartReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: Expr: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HeartReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Expr: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Must", wireType)
 			}
-			m.Msg = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDoc
@@ -1849,11 +7668,79 @@ func (m *HeartReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Msg |= (int32(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Must = append(m.Must, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Should", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Should = append(m.Should, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NotIn", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NotIn = append(m.NotIn, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDoc(dAtA[iNdEx:])
@@ -1876,7 +7763,7 @@ func (m *HeartReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DocReq) Unmarshal(dAtA []byte) error {
+func (m *Word) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1899,15 +7786,15 @@ func (m *DocReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DocReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: Word: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DocReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Word: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DocId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Text", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1932,13 +7819,13 @@ func (m *DocReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.DocId = string(dAtA[iNdEx:postIndex])
+			m.Text = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Content", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Freq", wireType)
 			}
-			var stringLen uint64
+			m.Freq = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDoc
@@ -1948,26 +7835,16 @@ func (m *DocReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Freq |= (int32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthDoc
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Content = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Attri", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Pos", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDoc
@@ -1977,26 +7854,75 @@ func (m *DocReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthDoc
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Attri = append(m.Attri[:0], dAtA[iNdEx:postIndex]...)
-			if m.Attri == nil {
-				m.Attri = []byte{}
-			}
+			m.Pos = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDoc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDoc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WordsReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDoc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WordsReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WordsReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tokens", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Words", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2020,14 +7946,14 @@ func (m *DocReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Tokens = append(m.Tokens, &TokenData{})
-			if err := m.Tokens[len(m.Tokens)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Words = append(m.Words, &Word{})
+			if err := m.Words[len(m.Words)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 5:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2052,42 +7978,11 @@ func (m *DocReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Labels = append(m.Labels, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Fields", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowDoc
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthDoc
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Fields = append(m.Fields[:0], dAtA[iNdEx:postIndex]...)
-			if m.Fields == nil {
-				m.Fields = []byte{}
-			}
+			m.Index = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 7:
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ForceUpdate", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Reanalyze", wireType)
 			}
 			var v int
 			for shift := uint(0); ; shift += 7 {
@@ -2104,7 +7999,7 @@ func (m *DocReq) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			m.ForceUpdate = bool(v != 0)
+			m.Reanalyze = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDoc(dAtA[iNdEx:])
@@ -2127,7 +8022,7 @@ func (m *DocReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TokenData) Unmarshal(dAtA []byte) error {
+func (m *WordsReply) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2150,15 +8045,34 @@ func (m *TokenData) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TokenData: wiretype end group for non-group")
+			return fmt.Errorf("proto: WordsReply: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TokenData: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: WordsReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+			}
+			m.Result = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Result |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Text", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2183,81 +8097,68 @@ func (m *TokenData) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Text = string(dAtA[iNdEx:postIndex])
+			m.Msg = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
-			if wireType == 0 {
-				var v int32
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowDoc
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= (int32(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				m.Locations = append(m.Locations, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowDoc
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= (int(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthDoc
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TaskId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
 				}
-				postIndex := iNdEx + packedLen
-				if postIndex > l {
+				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA {
-					if integer < 128 {
-						count++
-					}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-				elementCount = count
-				if elementCount != 0 && len(m.Locations) == 0 {
-					m.Locations = make([]int32, 0, elementCount)
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TaskId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Words", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
 				}
-				for iNdEx < postIndex {
-					var v int32
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowDoc
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= (int32(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.Locations = append(m.Locations, v)
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
 				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field Locations", wireType)
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
+			m.Words = append(m.Words, &Word{})
+			if err := m.Words[len(m.Words)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDoc(dAtA[iNdEx:])
@@ -2280,7 +8181,7 @@ func (m *TokenData) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DeleteReq) Unmarshal(dAtA []byte) error {
+func (m *TaskReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2303,15 +8204,15 @@ func (m *DeleteReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeleteReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: TaskReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeleteReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TaskReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DocId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2336,64 +8237,42 @@ func (m *DeleteReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.DocId = string(dAtA[iNdEx:postIndex])
+			m.Index = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipDoc(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthDoc
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *Reply) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowDoc
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
 			}
-			if iNdEx >= l {
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Reply: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Reply: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Cancel", wireType)
 			}
-			m.Result = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDoc
@@ -2403,11 +8282,12 @@ func (m *Reply) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Result |= (int32(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.Cancel = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDoc(dAtA[iNdEx:])
@@ -2430,7 +8310,7 @@ func (m *Reply) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SearchReq) Unmarshal(dAtA []byte) error {
+func (m *TaskFailure) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2453,15 +8333,15 @@ func (m *SearchReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SearchReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: TaskFailure: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SearchReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TaskFailure: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DocId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2486,11 +8366,11 @@ func (m *SearchReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Id = string(dAtA[iNdEx:postIndex])
+			m.DocId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Err", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2515,32 +8395,64 @@ func (m *SearchReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Query = string(dAtA[iNdEx:postIndex])
+			m.Err = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OutputOffset", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDoc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDoc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TaskReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDoc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			m.OutputOffset = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowDoc
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.OutputOffset |= (int32(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-		case 4:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TaskReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TaskReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MaxOutputs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
 			}
-			m.MaxOutputs = 0
+			m.Result = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDoc
@@ -2550,14 +8462,14 @@ func (m *SearchReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.MaxOutputs |= (int32(b) & 0x7F) << shift
+				m.Result |= (int32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 5:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2582,13 +8494,13 @@ func (m *SearchReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Time = string(dAtA[iNdEx:postIndex])
+			m.Msg = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 6:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DocIds", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDoc
@@ -2598,106 +8510,26 @@ func (m *SearchReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthDoc
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.DocIds == nil {
-				m.DocIds = make(map[string]bool)
-			}
-			var mapkey string
-			var mapvalue bool
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowDoc
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowDoc
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= (uint64(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthDoc
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					var mapvaluetemp int
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowDoc
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						mapvaluetemp |= (int(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					mapvalue = bool(mapvaluetemp != 0)
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipDoc(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if skippy < 0 {
-						return ErrInvalidLengthDoc
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
-				}
-			}
-			m.DocIds[mapkey] = mapvalue
+			m.Id = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 7:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Logic", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Kind", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDoc
@@ -2707,81 +8539,26 @@ func (m *SearchReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthDoc
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Logic == nil {
-				m.Logic = &Logic{}
-			}
-			if err := m.Logic.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Kind = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipDoc(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthDoc
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *SearchReply) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowDoc
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: SearchReply: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SearchReply: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Total", wireType)
 			}
-			m.Code = 0
+			m.Total = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDoc
@@ -2791,16 +8568,16 @@ func (m *SearchReply) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Code |= (int32(b) & 0x7F) << shift
+				m.Total |= (int32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+		case 6:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Len", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Done", wireType)
 			}
-			m.Len = 0
+			m.Done = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDoc
@@ -2810,16 +8587,16 @@ func (m *SearchReply) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Len |= (int32(b) & 0x7F) << shift
+				m.Done |= (int32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
+		case 7:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Failed", wireType)
 			}
-			m.Timestamp = 0
+			m.Failed = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDoc
@@ -2829,14 +8606,14 @@ func (m *SearchReply) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Timestamp |= (int64(b) & 0x7F) << shift
+				m.Failed |= (int32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Docs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Failures", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2860,11 +8637,51 @@ func (m *SearchReply) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Docs = append(m.Docs, &Text{})
-			if err := m.Docs[len(m.Docs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Failures = append(m.Failures, &TaskFailure{})
+			if err := m.Failures[len(m.Failures)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			iNdEx = postIndex
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Canceled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Canceled = bool(v != 0)
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Finished", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Finished = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDoc(dAtA[iNdEx:])
@@ -2887,7 +8704,7 @@ func (m *SearchReply) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Text) Unmarshal(dAtA []byte) error {
+func (m *IndexReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2910,44 +8727,15 @@ func (m *Text) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Text: wiretype end group for non-group")
+			return fmt.Errorf("proto: IndexReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Text: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: IndexReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowDoc
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthDoc
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Id = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Content", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2972,40 +8760,7 @@ func (m *Text) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Content = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Attri", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowDoc
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthDoc
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Attri == nil {
-				m.Attri = &Attri{}
-			}
-			if err := m.Attri.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Index = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3029,7 +8784,7 @@ func (m *Text) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Attri) Unmarshal(dAtA []byte) error {
+func (m *IndexInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3052,15 +8807,15 @@ func (m *Attri) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Attri: wiretype end group for non-group")
+			return fmt.Errorf("proto: IndexInfo: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Attri: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: IndexInfo: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Title", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3085,13 +8840,13 @@ func (m *Attri) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Title = string(dAtA[iNdEx:postIndex])
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Author", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Opened", wireType)
 			}
-			var stringLen uint64
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDoc
@@ -3101,55 +8856,17 @@ func (m *Attri) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthDoc
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Author = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
+			m.Opened = bool(v != 0)
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowDoc
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthDoc
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Time = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Ts", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NumDocsIndexed", wireType)
 			}
-			m.Ts = 0
+			m.NumDocsIndexed = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDoc
@@ -3159,7 +8876,7 @@ func (m *Attri) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Ts |= (int64(b) & 0x7F) << shift
+				m.NumDocsIndexed |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3186,7 +8903,7 @@ func (m *Attri) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Logic) Unmarshal(dAtA []byte) error {
+func (m *Alias) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3209,37 +8926,17 @@ func (m *Logic) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Logic: wiretype end group for non-group")
+			return fmt.Errorf("proto: Alias: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Logic: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Alias: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Must", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowDoc
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.Must = bool(v != 0)
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Should", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Alias", wireType)
 			}
-			var v int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDoc
@@ -3249,37 +8946,26 @@ func (m *Logic) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Should = bool(v != 0)
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NotIn", wireType)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDoc
 			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowDoc
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
-			m.NotIn = bool(v != 0)
-		case 4:
+			m.Alias = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Expr", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDoc
@@ -3289,24 +8975,20 @@ func (m *Logic) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthDoc
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Expr == nil {
-				m.Expr = &Expr{}
-			}
-			if err := m.Expr.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Index = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3330,7 +9012,7 @@ func (m *Logic) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Expr) Unmarshal(dAtA []byte) error {
+func (m *IndexesReply) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3353,15 +9035,34 @@ func (m *Expr) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Expr: wiretype end group for non-group")
+			return fmt.Errorf("proto: IndexesReply: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Expr: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: IndexesReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+			}
+			m.Result = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Result |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Must", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3386,13 +9087,13 @@ func (m *Expr) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Must = append(m.Must, string(dAtA[iNdEx:postIndex]))
+			m.Msg = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Should", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Indexes", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDoc
@@ -3402,26 +9103,28 @@ func (m *Expr) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthDoc
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Should = append(m.Should, string(dAtA[iNdEx:postIndex]))
+			m.Indexes = append(m.Indexes, &IndexInfo{})
+			if err := m.Indexes[len(m.Indexes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 3:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NotIn", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Aliases", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDoc
@@ -3431,20 +9134,22 @@ func (m *Expr) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthDoc
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.NotIn = append(m.NotIn, string(dAtA[iNdEx:postIndex]))
+			m.Aliases = append(m.Aliases, &Alias{})
+			if err := m.Aliases[len(m.Aliases)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3573,52 +9278,101 @@ var (
 	ErrIntOverflowDoc   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("doc.proto", fileDescriptor_doc_5a5b8862ec63a264) }
-
-var fileDescriptor_doc_5a5b8862ec63a264 = []byte{
-	// 692 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x54, 0x41, 0x6b, 0xdb, 0x4a,
-	0x10, 0x7e, 0x92, 0x2c, 0xc5, 0x1a, 0xe7, 0x85, 0xb0, 0xbc, 0xf7, 0x10, 0x26, 0xcf, 0x15, 0x2a,
-	0x04, 0x1f, 0x4a, 0x0e, 0xee, 0xa5, 0x2d, 0xf4, 0xd0, 0xe0, 0xd0, 0x18, 0x02, 0x81, 0x6d, 0x4b,
-	0xe9, 0xa9, 0x28, 0xd2, 0x38, 0x16, 0x91, 0xb5, 0x8a, 0xb4, 0x2a, 0xf6, 0x5f, 0xe9, 0xb1, 0x3f,
-	0xa0, 0xbf, 0xa3, 0xbd, 0xf5, 0x27, 0x94, 0xfc, 0x92, 0x32, 0xa3, 0x95, 0xe3, 0x24, 0xe4, 0x36,
-	0xdf, 0xb7, 0xb3, 0x33, 0x3b, 0xdf, 0xcc, 0x0e, 0xf8, 0xa9, 0x4a, 0x8e, 0xca, 0x4a, 0x69, 0x25,
-	0x9c, 0x54, 0x25, 0xd1, 0x01, 0xf4, 0x4f, 0x31, 0xae, 0xb4, 0xc4, 0x6b, 0xb1, 0x0f, 0xce, 0xb2,
-	0xbe, 0x0c, 0xac, 0xd0, 0x1a, 0xbb, 0x92, 0xcc, 0xe8, 0xa7, 0x05, 0xde, 0x54, 0x25, 0x74, 0xf8,
-	0x2f, 0x78, 0xa9, 0x4a, 0x3e, 0x67, 0x29, 0x9f, 0xfb, 0xd2, 0x4d, 0x55, 0x32, 0x4b, 0x45, 0x00,
-	0x3b, 0x89, 0x2a, 0x34, 0x16, 0x3a, 0xb0, 0x99, 0xef, 0xa0, 0xf8, 0x07, 0xdc, 0x58, 0xeb, 0x2a,
-	0x0b, 0x9c, 0xd0, 0x1a, 0xef, 0xca, 0x16, 0x88, 0x43, 0xf0, 0xb4, 0xba, 0xc2, 0xa2, 0x0e, 0x7a,
-	0xa1, 0x33, 0x1e, 0x4c, 0xf6, 0x8e, 0xe8, 0x41, 0xef, 0x89, 0x9a, 0xc6, 0x3a, 0x96, 0xe6, 0x54,
-	0xfc, 0x07, 0x5e, 0x1e, 0x5f, 0x60, 0x5e, 0x07, 0x6e, 0xe8, 0x8c, 0x7d, 0x69, 0x10, 0xf1, 0xf3,
-	0x0c, 0xf3, 0xb4, 0x0e, 0x3c, 0x0e, 0x6b, 0x90, 0x08, 0x61, 0x30, 0x57, 0x55, 0x82, 0x1f, 0xca,
-	0x34, 0xd6, 0x18, 0xec, 0x84, 0xd6, 0xb8, 0x2f, 0xb7, 0xa9, 0xe8, 0x35, 0xf8, 0x9b, 0x34, 0x42,
-	0x40, 0x4f, 0xe3, 0x4a, 0x9b, 0x5a, 0xd8, 0x16, 0x07, 0xe0, 0xe7, 0x2a, 0x89, 0x75, 0xa6, 0x8a,
-	0x3a, 0xb0, 0x43, 0x67, 0xec, 0xca, 0x5b, 0x22, 0x8a, 0xc0, 0x9f, 0x62, 0x8e, 0x1a, 0x1f, 0x17,
-	0x23, 0x7a, 0x02, 0xae, 0xc4, 0x32, 0x5f, 0xd3, 0x2b, 0x2b, 0xac, 0x9b, 0x5c, 0x1b, 0x31, 0x0d,
-	0x8a, 0xbe, 0xda, 0xe0, 0xbf, 0xc3, 0xb8, 0x4a, 0x16, 0x14, 0x65, 0x0f, 0xec, 0x4d, 0x04, 0x3b,
-	0x4b, 0x49, 0xb1, 0xeb, 0x06, 0xab, 0xb5, 0x51, 0xb2, 0x05, 0x22, 0x82, 0x5d, 0xd5, 0xe8, 0xb2,
-	0xd1, 0xe7, 0xf3, 0x79, 0x8d, 0x9a, 0xe5, 0x74, 0xe5, 0x1d, 0x4e, 0x8c, 0x00, 0x96, 0xf1, 0xea,
-	0x9c, 0x29, 0x52, 0x96, 0x3c, 0xb6, 0x18, 0x2e, 0x37, 0x5b, 0x62, 0xe0, 0x9a, 0x72, 0xb3, 0x25,
-	0x8a, 0x09, 0xd7, 0x30, 0x63, 0x25, 0xa9, 0x13, 0x43, 0xee, 0xc4, 0xe6, 0x75, 0x47, 0x53, 0x3e,
-	0x3c, 0x29, 0x74, 0xb5, 0x96, 0xc6, 0x53, 0x84, 0xe0, 0xe6, 0xea, 0x32, 0x4b, 0x58, 0xdf, 0xc1,
-	0x04, 0xf8, 0xca, 0x19, 0x31, 0xb2, 0x3d, 0x18, 0xbe, 0x84, 0xc1, 0xd6, 0x45, 0x1a, 0xa9, 0x2b,
-	0x5c, 0x9b, 0x1a, 0xc9, 0xa4, 0x22, 0xbf, 0xc4, 0x79, 0x83, 0x5c, 0x64, 0x5f, 0xb6, 0xe0, 0x95,
-	0xfd, 0xc2, 0x8a, 0x4a, 0x18, 0x74, 0xd9, 0x49, 0x43, 0x01, 0xbd, 0x44, 0xa5, 0x68, 0x14, 0x64,
-	0x9b, 0xc2, 0xe5, 0x58, 0xf0, 0x55, 0x57, 0x92, 0x49, 0x4d, 0xa3, 0x6a, 0x6a, 0x1d, 0x2f, 0x4b,
-	0x96, 0xc6, 0x91, 0xb7, 0x84, 0xf8, 0x1f, 0x7a, 0xa9, 0x4a, 0xba, 0x59, 0xf3, 0xdb, 0x59, 0xc3,
-	0x95, 0x96, 0x4c, 0x47, 0x12, 0x7a, 0x84, 0x1e, 0x34, 0xe2, 0xf1, 0xa1, 0x0e, 0xb7, 0x87, 0xba,
-	0x13, 0xe0, 0x0d, 0x31, 0x66, 0xc0, 0xa3, 0x4f, 0xe0, 0x32, 0xa6, 0x42, 0x75, 0xa6, 0x73, 0xec,
-	0x46, 0x84, 0x01, 0x4d, 0x46, 0xdc, 0xe8, 0x85, 0xaa, 0x4c, 0x64, 0x83, 0x36, 0x1d, 0x72, 0xb6,
-	0x3a, 0xb4, 0x07, 0xb6, 0xe9, 0xa6, 0x23, 0x6d, 0x5d, 0x47, 0x0b, 0x70, 0x59, 0x6b, 0x72, 0x5e,
-	0x36, 0x75, 0x3b, 0x5c, 0x7d, 0xc9, 0x36, 0x05, 0xae, 0x17, 0xaa, 0xc9, 0x53, 0x23, 0xac, 0x41,
-	0xf4, 0x8c, 0x42, 0xe9, 0x59, 0xc1, 0x91, 0xfb, 0xb2, 0x05, 0x24, 0x0c, 0xae, 0xca, 0x8a, 0x83,
-	0x77, 0xc2, 0x9c, 0xac, 0xca, 0x4a, 0x32, 0x1d, 0x9d, 0x42, 0x8f, 0xd0, 0x56, 0x22, 0xfa, 0x83,
-	0x0f, 0x13, 0xf1, 0xcf, 0x7c, 0x98, 0x88, 0xe8, 0x16, 0x4c, 0xbe, 0x5b, 0xb0, 0xf3, 0xb6, 0x42,
-	0xd4, 0x58, 0x89, 0x31, 0xf8, 0xbc, 0x6b, 0x8e, 0x31, 0xd6, 0xe2, 0x6f, 0xce, 0xd9, 0xed, 0x9e,
-	0x61, 0xab, 0x24, 0x77, 0x3e, 0xfa, 0x4b, 0x3c, 0xe5, 0xb5, 0x33, 0x2b, 0x56, 0x62, 0xc0, 0x7c,
-	0xbb, 0x83, 0xee, 0x39, 0x1d, 0x82, 0xd7, 0xfe, 0x48, 0xd1, 0x2e, 0x91, 0xcd, 0xf7, 0xbc, 0xe7,
-	0xf7, 0x0c, 0xbc, 0x76, 0xae, 0x8c, 0xdf, 0x66, 0xc4, 0x87, 0xfb, 0x77, 0x30, 0x7b, 0x1f, 0x8b,
-	0x1f, 0x37, 0x23, 0xeb, 0xd7, 0xcd, 0xc8, 0xfa, 0x7d, 0x33, 0xb2, 0xbe, 0xd9, 0xce, 0xe9, 0xd9,
-	0xc7, 0x0b, 0x8f, 0x17, 0xe6, 0xf3, 0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x70, 0x69, 0xbe, 0x5c,
-	0x3d, 0x05, 0x00, 0x00,
+func init() { proto.RegisterFile("doc.proto", fileDescriptor_doc_884361a2818b9d4a) }
+
+var fileDescriptor_doc_884361a2818b9d4a = []byte{
+	// 1484 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x57, 0xef, 0x6e, 0xdc, 0xc4,
+	0x16, 0xaf, 0xed, 0xf5, 0xae, 0x7d, 0x36, 0xcd, 0xcd, 0x1d, 0xdd, 0xdb, 0x6b, 0xed, 0x2d, 0x61,
+	0x65, 0xa0, 0x6c, 0x51, 0x29, 0x6a, 0xaa, 0x22, 0x40, 0x20, 0x91, 0x36, 0xfd, 0xb3, 0x22, 0xa8,
+	0xd2, 0xb4, 0x55, 0xc5, 0xa7, 0xc8, 0xb1, 0x67, 0x1b, 0x2b, 0x5e, 0x8f, 0xeb, 0x19, 0x97, 0x0d,
+	0xdf, 0xf8, 0xc6, 0x73, 0xf0, 0x04, 0x3c, 0x06, 0xe2, 0x13, 0x8f, 0x80, 0xfa, 0x06, 0x48, 0x3c,
+	0x00, 0x3a, 0xf3, 0xc7, 0xeb, 0xdd, 0x24, 0x55, 0x2a, 0xbe, 0x9d, 0xdf, 0x99, 0xe3, 0x99, 0xdf,
+	0xf9, 0x3b, 0x63, 0x08, 0x33, 0x9e, 0xde, 0xac, 0x6a, 0x2e, 0x39, 0xf1, 0x32, 0x9e, 0xc6, 0x57,
+	0x21, 0x78, 0xc4, 0x92, 0x5a, 0x52, 0xf6, 0x92, 0x6c, 0x81, 0x37, 0x17, 0x2f, 0x22, 0x67, 0xec,
+	0x4c, 0x7c, 0x8a, 0x62, 0xfc, 0x8b, 0x0b, 0xfd, 0x3d, 0x9e, 0xe2, 0xe2, 0x7f, 0xa1, 0x9f, 0xf1,
+	0xf4, 0x20, 0xcf, 0xd4, 0x7a, 0x48, 0xfd, 0x8c, 0xa7, 0xd3, 0x8c, 0x44, 0x30, 0x48, 0x79, 0x29,
+	0x59, 0x29, 0x23, 0x57, 0xe9, 0x2d, 0x24, 0xff, 0x01, 0x3f, 0x91, 0xb2, 0xce, 0x23, 0x6f, 0xec,
+	0x4c, 0x36, 0xa8, 0x06, 0xe4, 0x1a, 0xf4, 0x25, 0x3f, 0x66, 0xa5, 0x88, 0x7a, 0x63, 0x6f, 0x32,
+	0xdc, 0xd9, 0xbc, 0x89, 0x84, 0x9e, 0xa2, 0x6a, 0x2f, 0x91, 0x09, 0x35, 0xab, 0xe4, 0x0a, 0xf4,
+	0x8b, 0xe4, 0x90, 0x15, 0x22, 0xf2, 0xc7, 0xde, 0x24, 0xa4, 0x06, 0xa1, 0x7e, 0x96, 0xb3, 0x22,
+	0x13, 0x51, 0x5f, 0x6d, 0x6b, 0x10, 0x19, 0xc3, 0x70, 0xc6, 0xeb, 0x94, 0x3d, 0xab, 0xb2, 0x44,
+	0xb2, 0x68, 0x30, 0x76, 0x26, 0x01, 0xed, 0xaa, 0x90, 0xe9, 0x2b, 0x56, 0x8b, 0x9c, 0x97, 0x51,
+	0x30, 0x76, 0x26, 0x3d, 0x6a, 0x21, 0x32, 0xcd, 0xcb, 0x8c, 0x2d, 0xa2, 0x50, 0x7b, 0xa6, 0x00,
+	0xda, 0xd7, 0xbc, 0x91, 0x79, 0xf9, 0x22, 0x02, 0xed, 0x99, 0x81, 0xe4, 0xff, 0x10, 0xb2, 0x45,
+	0x95, 0xd7, 0xec, 0x20, 0x91, 0xd1, 0x70, 0xec, 0x4c, 0x3c, 0x1a, 0x68, 0xc5, 0xae, 0x8c, 0x0f,
+	0x61, 0xb0, 0xc7, 0x53, 0x81, 0x21, 0x7b, 0x17, 0x7a, 0x19, 0x4f, 0x45, 0xe4, 0x28, 0x4f, 0x87,
+	0xca, 0x53, 0x1d, 0x4d, 0xaa, 0x16, 0xd6, 0x49, 0xbb, 0xa7, 0x49, 0xb7, 0xd4, 0xbc, 0x0e, 0xb5,
+	0xf8, 0x0e, 0x84, 0xfa, 0x8c, 0xaa, 0x38, 0x21, 0x13, 0x18, 0xd4, 0x4c, 0x34, 0x85, 0xb4, 0x07,
+	0x6d, 0x2e, 0x0f, 0x42, 0x35, 0xb5, 0xcb, 0xf1, 0x3e, 0x84, 0xad, 0xf6, 0xbc, 0x7c, 0x5e, 0x81,
+	0xbe, 0x36, 0x57, 0x6c, 0x7c, 0x6a, 0x90, 0xad, 0x0d, 0x4d, 0x03, 0xc5, 0xf8, 0x2b, 0x08, 0xdb,
+	0xb4, 0x11, 0x02, 0x3d, 0xc9, 0x16, 0xd2, 0xec, 0xa5, 0x64, 0x72, 0x15, 0xc2, 0x82, 0xa7, 0x89,
+	0xcc, 0x79, 0x29, 0x22, 0x77, 0xec, 0x4d, 0x7c, 0xba, 0x54, 0xc4, 0x14, 0xc2, 0x3d, 0x56, 0x30,
+	0xc9, 0xde, 0x5c, 0x5c, 0x36, 0x65, 0xee, 0x39, 0x29, 0x5b, 0x89, 0xcb, 0x2d, 0xf0, 0x75, 0x4c,
+	0x96, 0x5e, 0x38, 0x67, 0x79, 0xe1, 0x2e, 0xbd, 0xf8, 0xcb, 0x85, 0xf0, 0x09, 0x4b, 0xea, 0xf4,
+	0x08, 0x79, 0x6c, 0x82, 0xdb, 0x72, 0x70, 0xf3, 0x0c, 0x8f, 0x79, 0xd9, 0xb0, 0xfa, 0xc4, 0x7c,
+	0xa1, 0x01, 0x89, 0x61, 0x83, 0x37, 0xb2, 0x6a, 0xe4, 0xe3, 0xd9, 0x4c, 0x30, 0xa9, 0x38, 0xf8,
+	0x74, 0x45, 0x47, 0xb6, 0x01, 0xe6, 0xc9, 0xe2, 0xb1, 0x52, 0x61, 0xad, 0xa3, 0x45, 0x47, 0xa3,
+	0x02, 0x96, 0xcf, 0x59, 0xe4, 0x9b, 0x80, 0xe5, 0x73, 0x46, 0x76, 0x54, 0x14, 0xa6, 0xaa, 0xb6,
+	0x31, 0x91, 0x23, 0x95, 0xc8, 0x96, 0x1d, 0xa6, 0x74, 0x9a, 0x89, 0xfb, 0xa5, 0xac, 0x4f, 0xa8,
+	0xb1, 0x24, 0x63, 0xf0, 0x0b, 0xfe, 0x22, 0x4f, 0x55, 0xc5, 0x0f, 0x77, 0x40, 0x7d, 0xb2, 0x8f,
+	0x1a, 0xaa, 0x17, 0x96, 0xa1, 0x0a, 0xba, 0xd5, 0xfd, 0x01, 0x0c, 0x66, 0x79, 0x21, 0x59, 0x2d,
+	0xa2, 0xb0, 0x53, 0x9e, 0x0f, 0x94, 0x8e, 0xda, 0x35, 0x2c, 0x75, 0xd1, 0x1c, 0x1e, 0xa8, 0x26,
+	0x33, 0x6d, 0x10, 0x88, 0xe6, 0xf0, 0x01, 0xe2, 0xd1, 0xe7, 0x30, 0xec, 0x50, 0xc2, 0xe0, 0x1e,
+	0xb3, 0x13, 0x13, 0x3d, 0x14, 0xf1, 0xe8, 0x57, 0x49, 0xd1, 0xd8, 0xca, 0xd6, 0xe0, 0x0b, 0xf7,
+	0x33, 0x27, 0xfe, 0x06, 0xfa, 0xfa, 0xa8, 0x4e, 0xa3, 0x3b, 0x2b, 0x8d, 0xfe, 0x3f, 0x18, 0xe8,
+	0x92, 0xd0, 0xb5, 0x13, 0xb6, 0x1e, 0x6f, 0x81, 0x57, 0x72, 0x1d, 0xf4, 0x80, 0xa2, 0x18, 0x57,
+	0x30, 0xb4, 0x41, 0xc2, 0xe4, 0x13, 0xe8, 0xa5, 0x3c, 0x63, 0x26, 0xf5, 0x4a, 0xc6, 0x8f, 0x0a,
+	0x56, 0x9a, 0x9a, 0x46, 0x11, 0xab, 0x13, 0x83, 0x2e, 0x64, 0x32, 0xaf, 0xd4, 0x66, 0x1e, 0x5d,
+	0x2a, 0xc8, 0x3b, 0xa6, 0x75, 0xf5, 0x90, 0x0a, 0xf5, 0x90, 0x62, 0x0b, 0xa9, 0x1b, 0x37, 0xbe,
+	0x03, 0xfd, 0x87, 0x4c, 0xbe, 0xa1, 0x72, 0xdb, 0xa0, 0xbb, 0xdd, 0xfa, 0xfc, 0x12, 0x86, 0xdf,
+	0x36, 0x85, 0xcc, 0xcd, 0xb7, 0x1d, 0x17, 0x9d, 0x15, 0x17, 0xcf, 0xfe, 0xfa, 0x4f, 0x07, 0xc2,
+	0x27, 0x92, 0xd7, 0x2c, 0xdb, 0xe3, 0xe9, 0x3f, 0xee, 0xdf, 0xee, 0xe4, 0xee, 0xad, 0x4e, 0xee,
+	0xb1, 0x9d, 0xdc, 0x7e, 0xa7, 0xa6, 0x76, 0x51, 0x63, 0xa7, 0xf8, 0x32, 0x69, 0xfd, 0x95, 0xa4,
+	0x75, 0x1a, 0x76, 0xb0, 0xda, 0xb0, 0x9d, 0x69, 0x1a, 0xbc, 0x61, 0x9a, 0x86, 0x6b, 0xd3, 0xf4,
+	0x36, 0x5c, 0x5e, 0x46, 0x0c, 0x93, 0x1b, 0xaf, 0xcc, 0x54, 0x3d, 0xea, 0xda, 0xa0, 0x98, 0xec,
+	0x50, 0xe8, 0x61, 0xae, 0x4e, 0x75, 0xf3, 0xf9, 0x77, 0xd5, 0xb8, 0x7b, 0x57, 0x9d, 0xe5, 0x71,
+	0xfc, 0x1d, 0xf8, 0x0a, 0x63, 0x6e, 0x64, 0x2e, 0x0b, 0x66, 0xc3, 0xae, 0x00, 0x06, 0x24, 0x69,
+	0xe4, 0x11, 0xaf, 0xcd, 0xce, 0x06, 0xb5, 0x6d, 0xee, 0x75, 0xda, 0x7c, 0x13, 0x5c, 0x33, 0x12,
+	0x3c, 0xea, 0x4a, 0x11, 0x1f, 0x81, 0xaf, 0x1a, 0x16, 0x8d, 0xe7, 0x8d, 0xd0, 0x33, 0x2b, 0xa0,
+	0x4a, 0xc6, 0x8d, 0xc5, 0x11, 0x6f, 0x8a, 0xcc, 0xf4, 0x90, 0x41, 0x48, 0xa3, 0xe4, 0x72, 0x5a,
+	0x9a, 0x3e, 0xd0, 0x00, 0xcb, 0x96, 0x2d, 0xaa, 0x5a, 0x6d, 0x6e, 0xcb, 0xf6, 0xfe, 0xa2, 0xaa,
+	0xa9, 0x52, 0xc7, 0x8f, 0xa0, 0x87, 0xa8, 0x73, 0x10, 0x26, 0xef, 0xf4, 0x41, 0x2a, 0xa5, 0xa7,
+	0x0f, 0x42, 0xb5, 0x06, 0xf1, 0xd7, 0xd0, 0x7b, 0xce, 0xeb, 0xec, 0xcc, 0xb9, 0x4f, 0xa0, 0x37,
+	0xab, 0xd9, 0x4b, 0x53, 0x80, 0x4a, 0xc6, 0xf2, 0xab, 0xb8, 0xb0, 0xe5, 0x57, 0x71, 0x11, 0x1f,
+	0x40, 0x80, 0x3b, 0x98, 0x8b, 0xd2, 0xff, 0x1e, 0x65, 0x93, 0x55, 0xcd, 0x1b, 0x57, 0xa9, 0xd6,
+	0x9f, 0xdd, 0x10, 0xd8, 0xc2, 0x35, 0x4b, 0xca, 0xa4, 0x38, 0xf9, 0x81, 0x99, 0x38, 0x2c, 0x15,
+	0x71, 0x05, 0x60, 0x0e, 0x78, 0xab, 0x1b, 0x01, 0xbb, 0x52, 0x26, 0xe2, 0x18, 0x3b, 0x4b, 0xd3,
+	0xed, 0x23, 0x9c, 0x66, 0x4b, 0x96, 0xbd, 0xb3, 0x59, 0xc6, 0x0f, 0x61, 0xf0, 0x34, 0x11, 0xc7,
+	0xe8, 0x51, 0x4b, 0xd8, 0xe9, 0x12, 0xd6, 0x05, 0xe9, 0xb6, 0x05, 0x79, 0x05, 0xfa, 0x69, 0x52,
+	0xa6, 0xac, 0x30, 0xec, 0x0d, 0x8a, 0x3f, 0x85, 0x21, 0x6e, 0xf4, 0x20, 0xc9, 0x8b, 0xa6, 0x66,
+	0xe7, 0xb5, 0xfa, 0x16, 0x78, 0xac, 0xb6, 0x05, 0x87, 0x62, 0xfc, 0xa3, 0x0b, 0xa1, 0x66, 0xf0,
+	0x76, 0x2e, 0x6b, 0x5e, 0x5e, 0xcb, 0x8b, 0x40, 0xef, 0x38, 0x2f, 0x33, 0x33, 0x17, 0x94, 0xac,
+	0xea, 0x9e, 0xcb, 0xa4, 0x50, 0x43, 0xc1, 0xa7, 0x1a, 0xa0, 0x65, 0xc6, 0x4b, 0xa6, 0x1e, 0x63,
+	0x3e, 0x55, 0xb2, 0x7a, 0xa2, 0x25, 0x79, 0xc1, 0x32, 0x35, 0x03, 0x7c, 0x6a, 0x10, 0xb9, 0x01,
+	0xc1, 0x4c, 0x7b, 0x24, 0xa2, 0x40, 0x85, 0x70, 0x4b, 0xcf, 0xd5, 0xa5, 0xab, 0xb4, 0xb5, 0x20,
+	0x23, 0x08, 0x74, 0x34, 0x58, 0xa6, 0xa6, 0x42, 0x40, 0x5b, 0x8c, 0x6b, 0xb3, 0xbc, 0xcc, 0xc5,
+	0x11, 0xd3, 0x97, 0x52, 0x40, 0x5b, 0x1c, 0x8f, 0x21, 0x98, 0x62, 0xb0, 0xcf, 0xcd, 0x42, 0x9c,
+	0x40, 0xa8, 0x2c, 0xa6, 0xe5, 0x8c, 0xa3, 0x03, 0x65, 0x32, 0xb7, 0xdd, 0xac, 0x64, 0x74, 0x80,
+	0x57, 0xac, 0x64, 0x6d, 0xcf, 0x69, 0x44, 0x26, 0xb0, 0x55, 0x36, 0xf3, 0x03, 0x9c, 0x31, 0x07,
+	0x6a, 0x2b, 0xa6, 0x83, 0xd6, 0xa3, 0x9b, 0x65, 0x33, 0xc7, 0x17, 0xd9, 0x54, 0x6b, 0xe3, 0xdb,
+	0xe0, 0xef, 0x16, 0x79, 0xa2, 0x0a, 0x37, 0x41, 0xc1, 0x32, 0x48, 0xac, 0xf6, 0x8c, 0xf9, 0xfe,
+	0x93, 0x03, 0x1b, 0x7a, 0x83, 0xb7, 0xae, 0xd9, 0x09, 0x0c, 0x34, 0x21, 0x11, 0x79, 0x9d, 0xc1,
+	0xd8, 0xba, 0x49, 0xed, 0x32, 0x79, 0x1f, 0x06, 0x8a, 0x03, 0xb3, 0x65, 0x6c, 0x66, 0x1d, 0xea,
+	0xa8, 0x5d, 0xda, 0xf9, 0xcd, 0x87, 0xc1, 0xc3, 0x9a, 0x31, 0xbc, 0xa0, 0x27, 0x10, 0xaa, 0x3f,
+	0x84, 0xbb, 0x2c, 0x91, 0xe4, 0xb2, 0xb2, 0xb6, 0x7f, 0x0c, 0x23, 0xfd, 0xb1, 0x62, 0x1b, 0x5f,
+	0x22, 0xef, 0xa9, 0x9f, 0x85, 0x69, 0xb9, 0x20, 0xdd, 0xb7, 0xee, 0x9a, 0xd1, 0x75, 0xfd, 0x3e,
+	0x46, 0xab, 0x0d, 0x6b, 0x85, 0x43, 0x60, 0xb4, 0xd9, 0x41, 0xda, 0xf4, 0x1a, 0xf4, 0xf5, 0x13,
+	0x91, 0x98, 0x35, 0xfb, 0x5e, 0x5c, 0xdb, 0xf2, 0x06, 0xf4, 0xf5, 0xfd, 0x6f, 0xec, 0xda, 0x17,
+	0xd3, 0x68, 0x6b, 0x05, 0x6b, 0xeb, 0x0f, 0xd5, 0xdd, 0x8d, 0x57, 0xa8, 0x66, 0xa9, 0x2f, 0xe3,
+	0xd1, 0xda, 0x55, 0x12, 0x5f, 0x22, 0x3b, 0x10, 0xd8, 0xbb, 0x87, 0xe8, 0x8d, 0x3a, 0x97, 0xf7,
+	0x88, 0xac, 0x69, 0x2c, 0x95, 0x60, 0x37, 0xcb, 0xd4, 0xdc, 0x31, 0xb1, 0xb2, 0x43, 0x6e, 0xf4,
+	0xaf, 0x2e, 0xd4, 0xd6, 0x9f, 0xc0, 0x90, 0xb2, 0x39, 0x7f, 0xc5, 0x2e, 0xfa, 0xc1, 0xc7, 0x10,
+	0x3e, 0x13, 0xac, 0xbe, 0xa8, 0xf9, 0x35, 0xe8, 0x61, 0x73, 0x99, 0x40, 0x9b, 0xd9, 0x34, 0xda,
+	0xec, 0x20, 0x6d, 0xf7, 0x11, 0x0c, 0xef, 0xd5, 0x2c, 0x91, 0x4c, 0x15, 0x8c, 0xd9, 0xd8, 0x76,
+	0xd1, 0x5a, 0xb0, 0x27, 0x10, 0x3e, 0xae, 0x58, 0x79, 0x01, 0xcb, 0xeb, 0x00, 0xf7, 0x0a, 0x2e,
+	0x2e, 0xb8, 0xe9, 0x5e, 0xcd, 0xab, 0x0b, 0x58, 0xde, 0x82, 0xe1, 0x7e, 0x2e, 0xa4, 0xe9, 0x93,
+	0x75, 0xdb, 0x7f, 0x2f, 0x21, 0xb3, 0x51, 0xb8, 0x4b, 0x7e, 0x7d, 0xbd, 0xed, 0xfc, 0xfe, 0x7a,
+	0xdb, 0xf9, 0xe3, 0xf5, 0xb6, 0xf3, 0xb3, 0xeb, 0x3d, 0xda, 0x7f, 0x7e, 0xd8, 0x57, 0xbf, 0xc0,
+	0xb7, 0xff, 0x1e, 0x00, 0x4a, 0xcd, 0xe1, 0x0c, 0x0f, 0x0f, 0x00, 0x00,
 }
@@ -76,9 +76,12 @@ func rpcSearch(sea com.SearchArgs) *pb.SearchReply {
 	}
 
 	docs := com.Search(sea)
+	if docs.Err != nil {
+		return &pb.SearchReply{Code: 1, Timestamp: time.Now().Unix()}
+	}
 	var textArr []*pb.Text
 
-	scoDocs := docs.Docs.(types.ScoredDocs)
+	scoDocs, _ := docs.Docs.(types.ScoredDocs)
 	for i := 0; i < len(scoDocs); i++ {
 		attri := &pb.Attri{
 			Time: scoDocs[i].Attri.(types.Attri).Time,
@@ -16,6 +16,7 @@ package http
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
@@ -23,8 +24,10 @@ import (
 	"net/url"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/go-ego/riot"
 	"github.com/go-ego/riot/net/com"
 	"github.com/go-ego/riot/types"
 )
@@ -81,6 +84,14 @@ func Search(w http.ResponseWriter, req *http.Request) {
 		atime = req.Form["time"][0]
 	}
 
+	// 每个 filter 是逗号分隔的标签，以 - 开头时排除这些文档
+	var filters []types.Filter
+	for _, value := range req.Form["filter"] {
+		filter := types.Filter{Not: strings.HasPrefix(value, "-")}
+		filter.Labels = strings.Split(strings.TrimPrefix(value, "-"), ",")
+		filters = append(filters, filter)
+	}
+
 	config = com.Conf
 	log.Println("config: ", config, "; com.Conf: ", com.Conf)
 	if maxOutputs == 0 {
@@ -91,15 +102,23 @@ func Search(w http.ResponseWriter, req *http.Request) {
 	// searcher.Flush() /// todo
 
 	sea := com.SearchArgs{
+		Index:        req.FormValue("index"),
 		Id:           userid,
 		Query:        query,
 		Time:         atime,
+		Filters:      filters,
+		SubField:     req.FormValue("sub_field"),
 		OutputOffset: outputOffset,
 		MaxOutputs:   maxOutputs,
 	}
 	docs := com.Search(sea)
+	if docs.Err != nil {
+		log.Println("search: ", docs.Err)
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
 
-	scoDocs := docs.Docs.(types.ScoredDocs)
+	scoDocs, _ := docs.Docs.(types.ScoredDocs)
 	var textArr []Text
 	for i := 0; i < len(scoDocs); i++ {
 		text := Text{
@@ -152,11 +171,22 @@ func AddIndex(w http.ResponseWriter, req *http.Request) {
 		Ts:   time.Now().UnixNano(),
 	}
 
+	version, _ := strconv.ParseUint(req.FormValue("version"), 10, 64)
+	expireAt, _ := strconv.ParseInt(req.FormValue("expire_at"), 10, 64)
+
 	// inxid, _ := strconv.ParseUint(docid, 10, 64)
-	com.AddDocInx(docid, types.DocData{Content: query, Attri: attri}, false)
+	var code int64
+	err := com.AddDocInx(req.FormValue("index"), docid, types.DocData{
+		Content: query, Attri: attri, Version: version,
+		Routing: req.FormValue("routing"), ExpireAt: expireAt}, false)
+	if err != nil {
+		// 版本冲突等
+		code = 1
+	}
 
 	timestamp := time.Now().Unix()
 	response, _ := json.Marshal(&JsonResponse{
+		Code:      code,
 		Timestamp: timestamp,
 		Docs:      nil})
 
@@ -164,11 +194,368 @@ func AddIndex(w http.ResponseWriter, req *http.Request) {
 	io.WriteString(w, string(response))
 }
 
-// DelIndex remove search engine index
+// AddIndexBatch add search engine index in batch,
+// the request body is a json array of Doc
+func AddIndexBatch(w http.ResponseWriter, req *http.Request) {
+	var docs []Doc
+	err := json.NewDecoder(req.Body).Decode(&docs)
+	if err != nil {
+		log.Println("json.Decode: ", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	forceUpdate, _ := strconv.ParseBool(req.URL.Query().Get("forceUpdate"))
+	timeFormat := "2006-01-02 15:04:05"
+
+	batch := make([]types.BatchDoc, len(docs))
+	for i, doc := range docs {
+		attri := types.Attri{
+			Time: time.Now().Format(timeFormat),
+			Ts:   time.Now().UnixNano(),
+		}
+
+		batch[i] = types.BatchDoc{
+			DocId: doc.Id,
+			Data: types.DocData{
+				Content: doc.Content, Labels: doc.Labels, Attri: attri,
+				Version: doc.Version, Routing: doc.Routing,
+				ExpireAt: doc.ExpireAt},
+		}
+	}
+
+	errs := com.AddDocsInx(req.URL.Query().Get("index"), batch, forceUpdate)
+	results := make([]Result, len(docs))
+	for i, err := range errs {
+		results[i].Id = docs[i].Id
+		if err != nil {
+			results[i].Code = 1
+			results[i].Msg = err.Error()
+		}
+	}
+
+	timestamp := time.Now().Unix()
+	response, _ := json.Marshal(&BatchResponse{
+		Len:       len(results),
+		Timestamp: timestamp,
+		Results:   results})
+
+	w.Header().Set("Content-Type", "application/json;charset=utf-8")
+	io.WriteString(w, string(response))
+}
+
+// DelIndex remove search engine index,
+// response 409 if the version conflicts
 func DelIndex(w http.ResponseWriter, req *http.Request) {
-	docid := req.URL.Query().Get("docid")
+	query := req.URL.Query()
+	docid := query.Get("docid")
+
+	var version uint64
+	if v := query.Get("version"); v != "" {
+		var err error
+		version, err = strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			http.Error(w, "invalid version: "+v, http.StatusBadRequest)
+			return
+		}
+	}
 
 	// docid := string(indexid)
 	// inxId, _ := strconv.ParseUint(docid, 10, 64)
-	com.Delete(docid, false)
+	err := com.DeleteVersion(query.Get("index"), docid, version, false)
+	switch err {
+	case nil:
+	case riot.ErrVersionConflict:
+		http.Error(w, err.Error(), http.StatusConflict)
+	case riot.ErrIndexNotFound:
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// docStatus the HTTP status of getting the document
+func docStatus(err error) int {
+	switch err {
+	case nil:
+		return http.StatusOK
+	case riot.ErrDocNotFound:
+		return http.StatusNotFound
+	case riot.ErrEngineClosed:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func storedDoc(docid string, data types.DocData, err error) StoredDoc {
+	if err != nil {
+		return StoredDoc{Id: docid, Code: 1, Status: docStatus(err),
+			Msg: err.Error()}
+	}
+
+	return StoredDoc{
+		Id: docid, Status: http.StatusOK,
+		Content: data.Content, Labels: data.Labels,
+		Attri: data.Attri, Fields: data.Fields,
+		Version: data.Version, Routing: data.Routing,
+		ExpireAt: data.ExpireAt,
+	}
+}
+
+// GetDoc get the document by docid, response 404 if the document
+// does not exist, 503 if the index is closed and 500 for other errors
+func GetDoc(w http.ResponseWriter, req *http.Request) {
+	docid := req.URL.Query().Get("docid")
+	index := req.URL.Query().Get("index")
+
+	data, err := com.GetDoc(index, docid)
+	response, _ := json.Marshal(storedDoc(docid, data, err))
+
+	w.Header().Set("Content-Type", "application/json;charset=utf-8")
+	if err != nil {
+		w.WriteHeader(docStatus(err))
+	}
+	io.WriteString(w, string(response))
+}
+
+// MultiGet get the documents by the comma separated docids,
+// the status of each document is the same as GetDoc
+func MultiGet(w http.ResponseWriter, req *http.Request) {
+	var docids []string
+	if ids := req.URL.Query().Get("docids"); ids != "" {
+		docids = strings.Split(ids, ",")
+	}
+	index := req.URL.Query().Get("index")
+
+	datas, errs := com.MultiGet(index, docids)
+	docs := make([]StoredDoc, len(docids))
+	for i, docid := range docids {
+		docs[i] = storedDoc(docid, datas[i], errs[i])
+	}
+
+	response, _ := json.Marshal(&DocsResponse{
+		Len:       len(docs),
+		Timestamp: time.Now().Unix(),
+		Docs:      docs})
+
+	w.Header().Set("Content-Type", "application/json;charset=utf-8")
+	io.WriteString(w, string(response))
+}
+
+// Metrics serve the metrics of the index in the
+// Prometheus text exposition format
+func Metrics(w http.ResponseWriter, req *http.Request) {
+	engine, err := com.GetEngine(req.URL.Query().Get("index"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	engine.MetricsHandler().ServeHTTP(w, req)
+}
+
+// Changes stream the change events of the index as server-sent events,
+// the stream resumes after the sequence number in the Last-Event-ID
+// header or the after parameter, otherwise only the new events are sent;
+// 410 Gone is returned when the events after the sequence number are not
+// retained or the sequence number is from before a restart
+func Changes(w http.ResponseWriter, req *http.Request) {
+	engine, err := com.GetEngine(req.URL.Query().Get("index"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	after := engine.ChangeSeq()
+	lastId := req.Header.Get("Last-Event-ID")
+	if lastId == "" {
+		lastId = req.URL.Query().Get("after")
+	}
+	if lastId != "" {
+		after, err = strconv.ParseUint(lastId, 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	events, err := engine.Subscribe(after)
+	if err == riot.ErrChangesTruncated {
+		http.Error(w, err.Error(), http.StatusGone)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	defer engine.Unsubscribe(events)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			data, _ := json.Marshal(event)
+			fmt.Fprintf(w, "id: %d\ndata: %s\n\n", event.Seq, data)
+			flusher.Flush()
+		case <-req.Context().Done():
+			return
+		}
+	}
+}
+
+// DictResponse the response of Dict
+type DictResponse struct {
+	Words []types.UserWord `json:"words,omitempty"`
+	// ReanalyzeDocs 后台任务的 id，用 Tasks 查询进度
+	Task string `json:"task,omitempty"`
+}
+
+// IndexesResponse the response of Indexes
+type IndexesResponse struct {
+	Indexes []com.IndexInfo   `json:"indexes"`
+	Aliases map[string]string `json:"aliases"`
+}
+
+// Indexes manage the named indexes under the index root: GET lists
+// the indexes and the aliases, POST creates the index, POST with op=open
+// or op=close opens or closes it and DELETE drops it with all its data
+func Indexes(w http.ResponseWriter, req *http.Request) {
+	index := req.URL.Query().Get("index")
+
+	var err error
+	switch req.Method {
+	case http.MethodGet:
+		var resp IndexesResponse
+		resp.Indexes, resp.Aliases, err = com.ListIndexes()
+		if err == nil {
+			response, _ := json.Marshal(resp)
+			w.Header().Set("Content-Type", "application/json;charset=utf-8")
+			io.WriteString(w, string(response))
+			return
+		}
+	case http.MethodPost:
+		switch op := req.URL.Query().Get("op"); op {
+		case "", "create":
+			err = com.CreateIndex(index)
+		case "open":
+			err = com.OpenIndex(index)
+		case "close":
+			err = com.CloseIndex(index)
+		default:
+			http.Error(w, "unknown op: "+op, http.StatusBadRequest)
+			return
+		}
+	case http.MethodDelete:
+		err = com.DropIndex(index)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	switch err {
+	case nil:
+	case riot.ErrIndexNotFound:
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case riot.ErrIndexExists:
+		http.Error(w, err.Error(), http.StatusConflict)
+	case riot.ErrInvalidIndexName, com.ErrNoIndexRoot:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// Dict manage the words added to the gse dictionary at runtime:
+// GET lists the words, POST adds the JSON array of the words in the body
+// and DELETE removes the comma separated words; reanalyze=true segments
+// the stored documents containing the words again in a background task.
+// POST responds after the dictionary is loaded again, which takes seconds
+// for the default dictionary, see riot.Engine.AddWords
+func Dict(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	index := query.Get("index")
+	reanalyze, _ := strconv.ParseBool(query.Get("reanalyze"))
+
+	var (
+		resp DictResponse
+		err  error
+	)
+	switch req.Method {
+	case http.MethodGet:
+		resp.Words, err = com.UserWords(index)
+	case http.MethodPost:
+		var words []types.UserWord
+		if err = json.NewDecoder(req.Body).Decode(&words); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		resp.Task, err = com.AddWords(index, words, reanalyze)
+	case http.MethodDelete:
+		var texts []string
+		if words := query.Get("words"); words != "" {
+			texts = strings.Split(words, ",")
+		}
+		resp.Task, err = com.RemoveWords(index, texts, reanalyze)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err == riot.ErrIndexNotFound {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, _ := json.Marshal(resp)
+	w.Header().Set("Content-Type", "application/json;charset=utf-8")
+	io.WriteString(w, string(response))
+}
+
+// Tasks get the status of the background task by the id parameter,
+// such as the task returned by Dict; DELETE cancels the task
+func Tasks(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+
+	var cancel bool
+	switch req.Method {
+	case http.MethodGet:
+	case http.MethodDelete:
+		cancel = true
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	info, err := com.Task(query.Get("index"), query.Get("id"), cancel)
+	if err == riot.ErrIndexNotFound || err == com.ErrTaskNotFound {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response, _ := json.Marshal(info)
+	w.Header().Set("Content-Type", "application/json;charset=utf-8")
+	io.WriteString(w, string(response))
 }
@@ -35,6 +35,54 @@ type JsonResponse struct {
 	Docs      []Text `json:"docs"`
 }
 
+// Doc index document
+type Doc struct {
+	Id       string   `json:"id"`
+	Content  string   `json:"content"`
+	Labels   []string `json:"labels"`
+	Version  uint64   `json:"version"`
+	Routing  string   `json:"routing"`
+	ExpireAt int64    `json:"expire_at"`
+}
+
+// Result index result of the document, code 0 succeed, 1 fail
+type Result struct {
+	Id   string `json:"id"`
+	Code int64  `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+// BatchResponse batch index Json response
+type BatchResponse struct {
+	Code      int64    `json:"code"`
+	Len       int      `json:"len"`
+	Timestamp int64    `json:"timestamp"`
+	Results   []Result `json:"results"`
+}
+
+// StoredDoc stored document, code 0 succeed, 1 not found or fail;
+// status is the HTTP status of the document, 404 only if it does not exist
+type StoredDoc struct {
+	Id       string      `json:"id"`
+	Code     int64       `json:"code"`
+	Status   int         `json:"status"`
+	Msg      string      `json:"msg"`
+	Content  string      `json:"content"`
+	Labels   []string    `json:"labels"`
+	Attri    interface{} `json:"attri"`
+	Fields   interface{} `json:"fields"`
+	Version  uint64      `json:"version"`
+	Routing  string      `json:"routing"`
+	ExpireAt int64       `json:"expire_at"`
+}
+
+// DocsResponse get documents Json response
+type DocsResponse struct {
+	Len       int         `json:"len"`
+	Timestamp int64       `json:"timestamp"`
+	Docs      []StoredDoc `json:"docs"`
+}
+
 type docsSlice []Text
 
 func (s docsSlice) Len() int      { return len(s) }
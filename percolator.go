@@ -0,0 +1,311 @@
+// Copyright 2016 ego authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package riot
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/go-ego/riot/types"
+)
+
+// ErrEmptyQuery the stored query has no search keys and filters
+var ErrEmptyQuery = errors.New("empty query")
+
+// storedQuery 注册的查询，搜索键已经分词
+type storedQuery struct {
+	// 必须全部存在、至少存在一个、不能存在的搜索键
+	must, should, notIn []string
+
+	docIds  map[string]bool
+	filters []types.Filter
+
+	// 反向索引中的搜索键，文档至少包含其中一个才可能匹配；
+	// 为空时每个文档都需要检查
+	anchors []string
+}
+
+// percolator 注册的查询和查询的搜索键的反向索引
+type percolator struct {
+	sync.RWMutex
+	queries map[string]*storedQuery
+	// 搜索键到查询 id
+	terms map[string]map[string]bool
+	// 没有 anchors 的查询
+	always map[string]bool
+}
+
+// match 文档的搜索键是否满足查询
+func (q *storedQuery) match(docId string, keywords map[string]bool) bool {
+	if q.docIds != nil && !q.docIds[docId] {
+		return false
+	}
+
+	for _, kw := range q.must {
+		if !keywords[kw] {
+			return false
+		}
+	}
+
+	if len(q.should) > 0 {
+		found := false
+		for _, kw := range q.should {
+			if keywords[kw] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	for _, kw := range q.notIn {
+		if keywords[kw] {
+			return false
+		}
+	}
+
+	for _, filter := range q.filters {
+		if matchFilter(filter, docId, keywords) == filter.Not {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchFilter 文档是否满足过滤条件（不考虑 Not）
+func matchFilter(filter types.Filter, docId string,
+	keywords map[string]bool) bool {
+	for _, label := range filter.Labels {
+		if !keywords[label] {
+			return false
+		}
+	}
+
+	if len(filter.DocIds) == 0 {
+		return true
+	}
+
+	for _, id := range filter.DocIds {
+		if id == docId {
+			return true
+		}
+	}
+
+	return false
+}
+
+// storedQuery 将请求分词并转换为注册的查询，逻辑检索的规则和 Lookup 相同
+func (engine *Engine) storedQuery(request types.SearchReq) *storedQuery {
+	tokens := engine.Tokens(request)
+	keywords := append(append([]string(nil), tokens...), request.Labels...)
+
+	q := &storedQuery{docIds: request.DocIds, filters: request.Filters}
+
+	logic, expr := request.Logic, request.Logic.Expr
+	flags := logic.Must || logic.Should || logic.NotIn
+	if (len(keywords) > 0 && flags) ||
+		len(expr.Must) > 0 || len(expr.Should) > 0 {
+		q.must = logicTerms(expr.Must, logic.Must, keywords)
+		q.should = logicTerms(expr.Should, logic.Should, keywords)
+		q.notIn = logicTerms(expr.NotIn, logic.NotIn, keywords)
+	} else {
+		q.must = keywords
+	}
+
+	return q
+}
+
+func logicTerms(expr []string, flag bool, keywords []string) []string {
+	if len(expr) > 0 {
+		return expr
+	}
+
+	if flag {
+		return keywords
+	}
+
+	return nil
+}
+
+// anchorsOf 选择查询在反向索引中的搜索键，调用前需要加锁
+//
+// 有必须存在的搜索键时选择其中包含查询最少的一个，
+// 否则使用全部至少存在一个的搜索键
+func (p *percolator) anchorsOf(q *storedQuery) []string {
+	must := append([]string(nil), q.must...)
+	for _, filter := range q.filters {
+		if !filter.Not {
+			must = append(must, filter.Labels...)
+		}
+	}
+
+	if len(must) > 0 {
+		anchor := must[0]
+		for _, kw := range must[1:] {
+			if len(p.terms[kw]) < len(p.terms[anchor]) {
+				anchor = kw
+			}
+		}
+		return []string{anchor}
+	}
+
+	return q.should
+}
+
+func (p *percolator) remove(id string) bool {
+	q, found := p.queries[id]
+	if !found {
+		return false
+	}
+
+	delete(p.queries, id)
+	delete(p.always, id)
+	for _, kw := range q.anchors {
+		delete(p.terms[kw], id)
+		if len(p.terms[kw]) == 0 {
+			delete(p.terms, kw)
+		}
+	}
+
+	return true
+}
+
+// RegisterQuery register the search request as a stored query under the id,
+// the query with the same id is replaced; the Text of the request is
+// segmented by the engine, RankOpts, Timeout and the other options
+// are ignored
+func (engine *Engine) RegisterQuery(id string, request types.SearchReq) error {
+	if err := engine.begin(); err != nil {
+		return err
+	}
+	defer engine.end()
+
+	q := engine.storedQuery(request)
+	if len(q.must) == 0 && len(q.should) == 0 && len(q.notIn) == 0 &&
+		q.docIds == nil && len(q.filters) == 0 {
+		return ErrEmptyQuery
+	}
+
+	p := &engine.percolator
+	p.Lock()
+	defer p.Unlock()
+
+	if p.queries == nil {
+		p.queries = make(map[string]*storedQuery)
+		p.terms = make(map[string]map[string]bool)
+		p.always = make(map[string]bool)
+	}
+
+	p.remove(id)
+	q.anchors = p.anchorsOf(q)
+	p.queries[id] = q
+	if len(q.anchors) == 0 {
+		p.always[id] = true
+	}
+	for _, kw := range q.anchors {
+		if p.terms[kw] == nil {
+			p.terms[kw] = make(map[string]bool)
+		}
+		p.terms[kw][id] = true
+	}
+
+	return nil
+}
+
+// UnregisterQuery remove the stored query,
+// return false if the query does not exist
+func (engine *Engine) UnregisterQuery(id string) bool {
+	engine.percolator.Lock()
+	defer engine.percolator.Unlock()
+
+	return engine.percolator.remove(id)
+}
+
+// NumQueries get the number of the stored queries
+func (engine *Engine) NumQueries() int {
+	engine.percolator.RLock()
+	defer engine.percolator.RUnlock()
+
+	return len(engine.percolator.queries)
+}
+
+// Percolate get the sorted ids of the stored queries which match
+// the document, the document is segmented as it is indexed
+// but not added to the index
+func (engine *Engine) Percolate(docId string, data types.DocData) ([]string, error) {
+	if err := engine.begin(); err != nil {
+		return nil, err
+	}
+	defer engine.end()
+
+	return engine.percolate(docId, data), nil
+}
+
+func (engine *Engine) percolate(docId string, data types.DocData) []string {
+	p := &engine.percolator
+	p.RLock()
+	defer p.RUnlock()
+
+	if len(p.queries) == 0 {
+		return nil
+	}
+
+	doc := engine.makeDocIndex(segmenterReq{docId: docId, data: data})
+	keywords := make(map[string]bool, len(doc.Keywords))
+	for _, kw := range doc.Keywords {
+		keywords[kw.Text] = true
+	}
+
+	// 候选的查询
+	candidates := make(map[string]bool, len(p.always))
+	for id := range p.always {
+		candidates[id] = true
+	}
+	for kw := range keywords {
+		for id := range p.terms[kw] {
+			candidates[id] = true
+		}
+	}
+
+	var ids []string
+	for id := range candidates {
+		if p.queries[id].match(docId, keywords) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+// IndexPercolate index the document and return the sorted ids of
+// the stored queries which match it
+func (engine *Engine) IndexPercolate(docId string, data types.DocData,
+	forceUpdate ...bool) ([]string, error) {
+	ids, err := engine.Percolate(docId, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := engine.Index(docId, data, forceUpdate...); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,56 @@
+package riot
+
+import (
+	"testing"
+
+	"github.com/go-ego/riot/types"
+	"github.com/vcaesar/tt"
+)
+
+func TestPercolate(t *testing.T) {
+	var engine Engine
+	engine.Init(types.EngineOpts{
+		Using:       1,
+		GseDict:     "./testdata/test_dict.txt",
+		IndexerOpts: inxOpts,
+	})
+	defer engine.Close()
+
+	tt.Equal(t, ErrEmptyQuery, engine.RegisterQuery("empty", types.SearchReq{}))
+
+	tt.Nil(t, engine.RegisterQuery("world", types.SearchReq{Text: "World"}))
+	tt.Nil(t, engine.RegisterQuery("people", types.SearchReq{Text: "人口",
+		Filters: []types.Filter{{Labels: []string{"news"}}}}))
+	tt.Nil(t, engine.RegisterQuery("either", types.SearchReq{Logic: types.Logic{
+		Expr: types.Expr{Should: []string{"人口", "hello"}}}}))
+	tt.Nil(t, engine.RegisterQuery("not-news", types.SearchReq{
+		Filters: []types.Filter{{Labels: []string{"news"}, Not: true}}}))
+	tt.Equal(t, 4, engine.NumQueries())
+
+	ids, err := engine.Percolate("1", types.DocData{Content: "The world"})
+	tt.Nil(t, err)
+	tt.Equal(t, []string{"not-news", "world"}, ids)
+
+	ids, _ = engine.Percolate("2", types.DocData{Content: "The world, 人口",
+		Labels: []string{"news"}})
+	tt.Equal(t, []string{"either", "people", "world"}, ids)
+
+	ids, _ = engine.Percolate("3", types.DocData{Content: "Hello, 人口"})
+	tt.Equal(t, []string{"either", "not-news"}, ids)
+
+	// 重新注册替换原来的查询
+	tt.Nil(t, engine.RegisterQuery("world", types.SearchReq{Text: "world",
+		DocIds: map[string]bool{"5": true}}))
+	ids, _ = engine.Percolate("1", types.DocData{Content: "The world"})
+	tt.Equal(t, []string{"not-news"}, ids)
+	tt.Equal(t, 4, engine.NumQueries())
+
+	tt.True(t, engine.UnregisterQuery("not-news"))
+	tt.False(t, engine.UnregisterQuery("not-news"))
+
+	ids, err = engine.IndexPercolate("5", types.DocData{Content: "The world"})
+	tt.Nil(t, err)
+	tt.Equal(t, []string{"world"}, ids)
+	engine.Flush()
+	tt.Equal(t, 1, len(searchIds(&engine, "world")))
+}
@@ -0,0 +1,178 @@
+// Copyright 2016 ego authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package riot
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-ego/riot/types"
+)
+
+// profiler 收集一次搜索各 shard 的耗时，nil 表示不收集
+type profiler struct {
+	sync.Mutex
+	shards []types.ShardProfile
+	// 最后一个 shard 返回结果的时间
+	last time.Time
+}
+
+// add 记录一个 shard 的耗时，在向 rankerReturnChan 发送结果之前调用
+func (p *profiler) add(shard types.ShardProfile) {
+	if p == nil {
+		return
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	p.shards = append(p.shards, shard)
+	p.last = time.Now()
+}
+
+// profile 生成查询的耗时，超时之后返回的 shard 不计入
+func (p *profiler) profile(start time.Time) *types.SearchProfile {
+	p.Lock()
+	defer p.Unlock()
+
+	now := time.Now()
+	profile := &types.SearchProfile{
+		Shards: append([]types.ShardProfile(nil), p.shards...),
+		Total:  now.Sub(start),
+	}
+	if !p.last.IsZero() {
+		profile.Merge = now.Sub(p.last)
+	}
+
+	sort.Slice(profile.Shards, func(i, j int) bool {
+		return profile.Shards[i].Shard < profile.Shards[j].Shard
+	})
+
+	return profile
+}
+
+// slowLog 慢查询日志
+type slowLog struct {
+	sync.Mutex
+	w    io.Writer
+	file *os.File
+}
+
+// slowQuery 慢查询日志的一行
+type slowQuery struct {
+	Time    string               `json:"time"`
+	Took    float64              `json:"took_ms"`
+	Text    string               `json:"text,omitempty"`
+	Tokens  []string             `json:"tokens"`
+	Labels  []string             `json:"labels,omitempty"`
+	NumDocs int                  `json:"num_docs"`
+	Timeout bool                 `json:"timeout"`
+	Err     string               `json:"err,omitempty"`
+	Profile *types.SearchProfile `json:"profile"`
+}
+
+// openSlowLog 打开 SlowQueryFile，为空时使用标准日志的输出
+func (engine *Engine) openSlowLog(options types.EngineOpts) {
+	if options.SlowQuery <= 0 {
+		return
+	}
+
+	if options.SlowQueryFile == "" {
+		engine.slowLog.w = log.Writer()
+		return
+	}
+
+	file, err := os.OpenFile(options.SlowQueryFile,
+		os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		log.Fatalf("Can not open the slow query log: %v", err)
+	}
+	engine.slowLog.w = file
+	engine.slowLog.file = file
+}
+
+// closeSlowLog 关闭 SlowQueryFile
+func (engine *Engine) closeSlowLog() error {
+	engine.slowLog.Lock()
+	defer engine.slowLog.Unlock()
+
+	file := engine.slowLog.file
+	engine.slowLog.w = nil
+	engine.slowLog.file = nil
+	if file == nil {
+		return nil
+	}
+
+	return file.Close()
+}
+
+// SetSlowQueryLog set the writer of the slow query log,
+// the queries slower than EngineOpts.SlowQuery are written to it
+// as JSON lines
+func (engine *Engine) SetSlowQueryLog(w io.Writer) {
+	engine.slowLog.Lock()
+	defer engine.slowLog.Unlock()
+
+	engine.slowLog.w = w
+}
+
+// profiled 在查询结束后返回耗时，并记录慢查询
+func (engine *Engine) profiled(request types.SearchReq, start time.Time,
+	prof *profiler, output *types.SearchResp) {
+	if prof == nil {
+		return
+	}
+
+	profile := prof.profile(start)
+	if request.Profile {
+		output.Profile = profile
+	}
+
+	threshold := time.Duration(engine.initOptions.SlowQuery) * time.Millisecond
+	if threshold <= 0 || profile.Total < threshold {
+		return
+	}
+
+	query := slowQuery{
+		Time:    start.Format(time.RFC3339Nano),
+		Took:    float64(profile.Total) / float64(time.Millisecond),
+		Text:    request.Text,
+		Tokens:  output.Tokens,
+		Labels:  request.Labels,
+		NumDocs: output.NumDocs,
+		Timeout: output.Timeout,
+		Profile: profile,
+	}
+	if output.Err != nil {
+		query.Err = output.Err.Error()
+	}
+
+	buf, err := json.Marshal(query)
+	if err != nil {
+		return
+	}
+
+	engine.slowLog.Lock()
+	defer engine.slowLog.Unlock()
+
+	if engine.slowLog.w != nil {
+		engine.slowLog.w.Write(append(buf, '\n'))
+	}
+}
@@ -0,0 +1,91 @@
+package riot
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-ego/riot/types"
+	"github.com/vcaesar/tt"
+)
+
+func profileOpts(slow int) types.EngineOpts {
+	return types.EngineOpts{
+		Using:       1,
+		GseDict:     "./testdata/test_dict.txt",
+		IndexerOpts: inxOpts,
+		NumShards:   3,
+		SlowQuery:   slow,
+	}
+}
+
+func TestSearchProfile(t *testing.T) {
+	var engine Engine
+	engine.Init(profileOpts(0))
+	defer engine.Close()
+
+	engine.Index("1", types.DocData{Content: "The world"})
+	engine.Index("2", types.DocData{Content: "The world, 人口"})
+	engine.Index("3", types.DocData{Content: "有人口"})
+	engine.Flush()
+
+	outputs := engine.Search(types.SearchReq{Text: "world"})
+	tt.Nil(t, outputs.Profile)
+
+	outputs = engine.Search(types.SearchReq{Text: "world", Profile: true})
+	profile := outputs.Profile
+	tt.NotNil(t, profile)
+	tt.Equal(t, 3, len(profile.Shards))
+
+	var numDocs int
+	for i, shard := range profile.Shards {
+		tt.Equal(t, i, shard.Shard)
+		numDocs += shard.NumDocs
+	}
+	tt.Equal(t, 2, numDocs)
+	tt.True(t, profile.Total >= profile.Merge)
+
+	outputs = engine.Search(types.SearchReq{
+		Text: "world", CountDocsOnly: true, Profile: true})
+	tt.Equal(t, 3, len(outputs.Profile.Shards))
+	tt.Expect(t, "2", outputs.NumDocs)
+}
+
+// slowCriteria 每个文档评分时等待 60 毫秒
+type slowCriteria struct{}
+
+func (slowCriteria) Score(doc types.IndexedDoc, fields interface{}) []float32 {
+	time.Sleep(60 * time.Millisecond)
+	return []float32{1}
+}
+
+func TestSlowQueryLog(t *testing.T) {
+	var engine Engine
+	// 没有超过阈值的查询不记录
+	engine.Init(profileOpts(50))
+	defer engine.Close()
+
+	var buf bytes.Buffer
+	engine.SetSlowQueryLog(&buf)
+
+	engine.Index("1", types.DocData{Content: "The world"})
+	engine.Flush()
+
+	engine.Search(types.SearchReq{Text: "world"})
+	tt.Equal(t, "", buf.String())
+
+	engine.Search(types.SearchReq{Text: "world",
+		RankOpts: &types.RankOpts{ScoringCriteria: slowCriteria{}}})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	tt.Equal(t, 1, len(lines))
+
+	var query slowQuery
+	tt.Nil(t, json.Unmarshal([]byte(lines[0]), &query))
+	tt.Equal(t, "world", query.Text)
+	tt.Equal(t, 1, query.NumDocs)
+	tt.True(t, query.Took >= 50)
+	tt.Equal(t, 3, len(query.Profile.Shards))
+}
@@ -0,0 +1,224 @@
+// Copyright 2016 ego authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package riot
+
+import (
+	"container/list"
+	"encoding/json"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/go-ego/riot/types"
+)
+
+// cacheKey 查询缓存的键，分词之后的请求和评分规则
+type cacheKey struct {
+	query    string
+	criteria types.ScoringCriteria
+}
+
+// normalQuery 决定 shard 查询结果的请求字段
+type normalQuery struct {
+	Tokens    []string        `json:"t"`
+	Labels    []string        `json:"l,omitempty"`
+	DocIds    map[string]bool `json:"d,omitempty"`
+	Filters   []types.Filter  `json:"f,omitempty"`
+	Logic     types.Logic     `json:"g"`
+	Reverse   bool            `json:"r,omitempty"`
+	Offset    int             `json:"o,omitempty"`
+	Max       int             `json:"m,omitempty"`
+	Count     bool            `json:"c,omitempty"`
+	Orderless bool            `json:"u,omitempty"`
+}
+
+// cacheRef 结果写入缓存的键和查找时的 generation，key 为 nil 表示不缓存
+type cacheRef struct {
+	key        *cacheKey
+	generation uint64
+}
+
+type queryEntry struct {
+	key        cacheKey
+	generation uint64
+	output     rankerReturnReq
+}
+
+// queryCache 一个 shard 的查询结果的 LRU 缓存，nil 表示不缓存
+//
+// 结果和查找时 shard 的 generation 一起保存，
+// 索引器或排序器改变之后 generation 不同，缓存的结果失效。
+type queryCache struct {
+	sync.Mutex
+	size  int
+	list  *list.List
+	items map[cacheKey]*list.Element
+}
+
+func newQueryCache(size int) *queryCache {
+	if size <= 0 {
+		return nil
+	}
+
+	return &queryCache{
+		size:  size,
+		list:  list.New(),
+		items: make(map[cacheKey]*list.Element),
+	}
+}
+
+func (c *queryCache) get(key cacheKey, generation uint64) (rankerReturnReq, bool) {
+	if c == nil {
+		return rankerReturnReq{}, false
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return rankerReturnReq{}, false
+	}
+
+	entry := elem.Value.(*queryEntry)
+	if entry.generation != generation {
+		// 索引已经改变
+		c.list.Remove(elem)
+		delete(c.items, key)
+		return rankerReturnReq{}, false
+	}
+	c.list.MoveToFront(elem)
+
+	return entry.output, true
+}
+
+func (c *queryCache) put(key cacheKey, generation uint64, output rankerReturnReq) {
+	if c == nil {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	entry := &queryEntry{key: key, generation: generation, output: output}
+	if elem, ok := c.items[key]; ok {
+		if elem.Value.(*queryEntry).generation > generation {
+			return
+		}
+		elem.Value = entry
+		c.list.MoveToFront(elem)
+		return
+	}
+
+	c.items[key] = c.list.PushFront(entry)
+	if c.list.Len() > c.size {
+		last := c.list.Back()
+		c.list.Remove(last)
+		delete(c.items, last.Value.(*queryEntry).key)
+	}
+}
+
+func (c *queryCache) len() int {
+	if c == nil {
+		return 0
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	return c.list.Len()
+}
+
+// queryKey 生成查询缓存的键，评分规则不能作为 map 的键时不缓存
+func (engine *Engine) queryKey(request types.SearchReq, tokens []string,
+	rankOpts types.RankOpts) *cacheKey {
+	if engine.queryCaches == nil || engine.hasExpiry() {
+		// 过期的文档在查找时过滤，结果随时间变化
+		return nil
+	}
+
+	criteria := rankOpts.ScoringCriteria
+	if criteria != nil && !reflect.TypeOf(criteria).Comparable() {
+		return nil
+	}
+
+	query, err := json.Marshal(normalQuery{
+		Tokens:    tokens,
+		Labels:    request.Labels,
+		DocIds:    request.DocIds,
+		Filters:   request.Filters,
+		Logic:     request.Logic,
+		Reverse:   rankOpts.ReverseOrder,
+		Offset:    rankOpts.OutputOffset,
+		Max:       rankOpts.MaxOutputs,
+		Count:     request.CountDocsOnly,
+		Orderless: request.Orderless,
+	})
+	if err != nil {
+		return nil
+	}
+
+	return &cacheKey{query: string(query), criteria: criteria}
+}
+
+// generation shard 的索引器和排序器的 generation 之和，两者都只增不减
+func (engine *Engine) generation(shard int) uint64 {
+	return engine.indexers[shard].Generation() +
+		engine.rankers[shard].Generation()
+}
+
+// cachedResult 读取 shard 缓存的结果，并记录命中率
+func (engine *Engine) cachedResult(shard int, ref cacheRef) (rankerReturnReq, bool) {
+	if ref.key == nil {
+		return rankerReturnReq{}, false
+	}
+
+	output, ok := engine.queryCaches[shard].get(*ref.key, ref.generation)
+	if ok {
+		atomic.AddUint64(&engine.metrics.queryHits, 1)
+	} else {
+		atomic.AddUint64(&engine.metrics.queryMisses, 1)
+	}
+
+	return output, ok
+}
+
+// cacheResult 缓存 shard 的结果
+func (engine *Engine) cacheResult(shard int, ref cacheRef, output rankerReturnReq) {
+	if ref.key == nil {
+		return
+	}
+
+	engine.queryCaches[shard].put(*ref.key, ref.generation, output)
+}
+
+// QueryCacheStats get the number of hits and misses of the query cache,
+// each shard is counted separately
+func (engine *Engine) QueryCacheStats() (hits, misses uint64) {
+	return atomic.LoadUint64(&engine.metrics.queryHits),
+		atomic.LoadUint64(&engine.metrics.queryMisses)
+}
+
+// FilterCacheStats get the number of hits and misses of the filter
+// bitmap cache of all the shards
+func (engine *Engine) FilterCacheStats() (hits, misses uint64) {
+	for i := range engine.indexers {
+		h, m, _ := engine.indexers[i].FilterCacheStats()
+		hits += h
+		misses += m
+	}
+
+	return
+}
@@ -0,0 +1,62 @@
+package riot
+
+import (
+	"testing"
+
+	"github.com/go-ego/riot/types"
+	"github.com/vcaesar/tt"
+)
+
+func TestQueryCache(t *testing.T) {
+	var engine Engine
+	engine.Init(types.EngineOpts{
+		Using:          1,
+		GseDict:        "./testdata/test_dict.txt",
+		IndexerOpts:    inxOpts,
+		NumShards:      2,
+		QueryCacheSize: 2,
+	})
+	defer engine.Close()
+
+	engine.Index("1", types.DocData{Content: "The world"})
+	engine.Index("2", types.DocData{Content: "The world, 人口"})
+	engine.Flush()
+
+	tt.Equal(t, 2, len(searchIds(&engine, "world")))
+	hits, misses := engine.QueryCacheStats()
+	tt.Equal(t, uint64(0), hits)
+	tt.Equal(t, uint64(2), misses)
+
+	// 重复的查询从缓存返回
+	outputs := engine.Search(types.SearchReq{Text: "world", Profile: true})
+	tt.Equal(t, 2, outputs.NumDocs)
+	tt.True(t, outputs.Profile.Shards[0].Cached)
+	tt.True(t, outputs.Profile.Shards[1].Cached)
+	hits, _ = engine.QueryCacheStats()
+	tt.Equal(t, uint64(2), hits)
+
+	// 分页不同的请求分别缓存
+	outputs = engine.Search(types.SearchReq{Text: "world",
+		RankOpts: &types.RankOpts{MaxOutputs: 1}})
+	tt.Equal(t, 1, len(outputs.Docs.(types.ScoredDocs)))
+	hits, _ = engine.QueryCacheStats()
+	tt.Equal(t, uint64(2), hits)
+
+	// 索引改变之后缓存失效
+	engine.Index("3", types.DocData{Content: "The world"})
+	engine.Flush()
+	tt.Equal(t, 3, len(searchIds(&engine, "world")))
+
+	engine.RemoveDoc("1", true)
+	engine.Flush()
+	tt.Equal(t, 2, len(searchIds(&engine, "world")))
+	tt.Equal(t, 2, len(searchIds(&engine, "world")))
+
+	outputs = engine.Search(types.SearchReq{Text: "world", CountDocsOnly: true})
+	tt.Equal(t, 2, outputs.NumDocs)
+
+	// 每个 shard 最多缓存 2 个结果
+	for _, cache := range engine.queryCaches {
+		tt.True(t, cache.len() <= 2)
+	}
+}
@@ -16,6 +16,8 @@
 package riot
 
 import (
+	"time"
+
 	"github.com/go-ego/riot/types"
 )
 
@@ -26,6 +28,8 @@ type rankerAddDocReq struct {
 	content string
 	// new 属性
 	attri interface{}
+	// 从该 shard 删除文档，在之前加入的文档之后执行
+	remove bool
 }
 
 type rankerRankReq struct {
@@ -33,6 +37,11 @@ type rankerRankReq struct {
 	options          types.RankOpts
 	rankerReturnChan chan rankerReturnReq
 	countDocsOnly    bool
+
+	// 索引器查找的耗时，排序后加上排序的耗时
+	profiler *profiler
+	profile  types.ShardProfile
+	cache    cacheRef
 }
 
 type rankerReturnReq struct {
@@ -41,13 +50,22 @@ type rankerReturnReq struct {
 	numDocs int
 }
 
-type rankerRemoveDocReq struct {
-	docId string
-}
-
 func (engine *Engine) rankerAddDoc(shard int) {
+	defer engine.wg.Done()
+
 	for {
-		request := <-engine.rankerAddDocChans[shard]
+		var request rankerAddDocReq
+		select {
+		case request = <-engine.rankerAddDocChans[shard]:
+		case <-engine.closeChan:
+			return
+		}
+
+		if request.remove {
+			engine.rankers[shard].RemoveDoc(request.docId)
+			continue
+		}
+
 		if engine.initOptions.IDOnly {
 			engine.rankers[shard].AddDoc(request.docId, request.fields)
 		} else {
@@ -58,23 +76,29 @@ func (engine *Engine) rankerAddDoc(shard int) {
 }
 
 func (engine *Engine) rankerRank(shard int) {
+	defer engine.wg.Done()
+
 	for {
-		request := <-engine.rankerRankChans[shard]
+		var request rankerRankReq
+		select {
+		case request = <-engine.rankerRankChans[shard]:
+		case <-engine.closeChan:
+			return
+		}
+
 		if request.options.MaxOutputs != 0 {
 			request.options.MaxOutputs += request.options.OutputOffset
 		}
 		request.options.OutputOffset = 0
+		start := time.Now()
 		outputDocs, numDocs := engine.rankers[shard].Rank(request.docs,
 			request.options, request.countDocsOnly)
 
-		request.rankerReturnChan <- rankerReturnReq{
-			docs: outputDocs, numDocs: numDocs}
-	}
-}
+		request.profile.Rank = time.Since(start)
+		request.profiler.add(request.profile)
 
-func (engine *Engine) rankerRemoveDoc(shard int) {
-	for {
-		request := <-engine.rankerRemoveDocChans[shard]
-		engine.rankers[shard].RemoveDoc(request.docId)
+		output := rankerReturnReq{docs: outputDocs, numDocs: numDocs}
+		engine.cacheResult(shard, request.cache, output)
+		request.rankerReturnChan <- output
 	}
 }
@@ -0,0 +1,347 @@
+// Copyright 2016 ego authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package riot
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/go-ego/murmur"
+	"github.com/go-ego/riot/store"
+)
+
+// ErrInvalidShards is returned when resharding to a shard number less than 1
+var ErrInvalidShards = errors.New("invalid shard number")
+
+const (
+	// reshardFolder 重新分片时新存储 shard 的临时目录
+	reshardFolder = ".reshard"
+	// reshardBackup 替换时旧存储 shard 的备份目录
+	reshardBackup = ".reshard.old"
+	// reshardDone 新的 shard 全部写入后在 reshardFolder 中创建的标记文件
+	reshardDone = "done"
+	// reshardStep 每迁移多少个文档报告一次进度
+	reshardStep = 1000
+)
+
+// ReshardProgress the progress of resharding
+type ReshardProgress struct {
+	// Stage "store" 迁移存储，"index" 重建索引
+	Stage string
+	// 已经处理的文档数和文档总数
+	Done, Total int
+}
+
+func storePath(folder string, shard int) string {
+	return folder + "/" + StoreFilePrefix + "." + strconv.Itoa(shard)
+}
+
+// StoreShardsOf get the number of store shards in the folder,
+// 0 if the folder has no store
+func StoreShardsOf(folder string) int {
+	infos, err := ioutil.ReadDir(folder)
+	if err != nil {
+		return 0
+	}
+
+	num := 0
+	for _, info := range infos {
+		name := strings.TrimPrefix(info.Name(), StoreFilePrefix+".")
+		if name == info.Name() {
+			continue
+		}
+
+		if _, err := strconv.Atoi(name); err == nil {
+			num++
+		}
+	}
+
+	return num
+}
+
+// ReshardStore redistribute the stored documents in the folder
+// from the `from` store shards to the `to` store shards
+// 离线迁移持久化存储到新的 shard 数目
+//
+// 文档按 murmur(docId) % to 重新分配，墓碑按对应的 docId 分配。
+// 新的 shard 先写入 folder/.reshard，全部完成后把旧的 shard 移到
+// folder/.reshard.old，再移入新的 shard，最后删除备份，
+// 中断后由 RecoverReshard 恢复。
+// 调用时不能有引擎打开该目录。progress 可以为 nil。
+func ReshardStore(folder, storeEngine string, from, to int,
+	progress func(ReshardProgress)) (err error) {
+	if from < 1 || to < 1 {
+		return ErrInvalidShards
+	}
+
+	if err = RecoverReshard(folder); err != nil {
+		return
+	}
+
+	if num := StoreShardsOf(folder); num != 0 && num != from {
+		// from 和实际的 shard 数目不一致会丢失文档
+		return ErrInvalidShards
+	}
+
+	olds := make([]store.Store, from)
+	for i := range olds {
+		if olds[i], err = store.OpenStore(storePath(folder, i), storeEngine); err != nil {
+			closeStores(olds)
+			return
+		}
+	}
+	defer func() {
+		if e := closeStores(olds); e != nil && err == nil {
+			err = e
+		}
+	}()
+
+	// 统计文档总数
+	var total int
+	for _, db := range olds {
+		err = db.ForEach(func(k, v []byte) error {
+			total++
+			return nil
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	tmp := filepath.Join(folder, reshardFolder)
+	if err = os.RemoveAll(tmp); err != nil {
+		return
+	}
+	if err = os.MkdirAll(tmp, 0700); err != nil {
+		return
+	}
+
+	news := make([]store.Store, to)
+	for i := range news {
+		if news[i], err = store.OpenStore(storePath(tmp, i), storeEngine); err != nil {
+			closeStores(news)
+			return
+		}
+	}
+
+	done := 0
+	report := func() {
+		if progress != nil {
+			progress(ReshardProgress{Stage: "store", Done: done, Total: total})
+		}
+	}
+
+	for _, db := range olds {
+		err = db.ForEach(func(k, v []byte) error {
+			docId := strings.TrimPrefix(string(k), tombstonePrefix)
+			shard := murmur.Sum32(docId) % uint32(to)
+			if err := news[shard].Set(k, v); err != nil {
+				return err
+			}
+
+			done++
+			if done%reshardStep == 0 {
+				report()
+			}
+			return nil
+		})
+		if err != nil {
+			closeStores(news)
+			return
+		}
+	}
+
+	if err = closeStores(news); err != nil {
+		return
+	}
+	report()
+
+	// 标记新的 shard 已经完整，之后中断时继续替换
+	mark := filepath.Join(tmp, reshardDone)
+	if err = ioutil.WriteFile(mark, []byte(strconv.Itoa(to)), 0600); err != nil {
+		return
+	}
+
+	// 替换旧的 shard
+	if err = closeStores(olds); err != nil {
+		return
+	}
+	olds = nil
+
+	return RecoverReshard(folder)
+}
+
+// RecoverReshard finish or roll back the ReshardStore interrupted
+// in the folder, it does nothing if there is no unfinished resharding.
+// The engine calls it before opening the store.
+// 新的 shard 没有写完时删除 folder/.reshard，保留旧的 shard；
+// 否则把剩余的旧 shard 移到备份目录，移入新的 shard 后删除备份。
+// 只剩备份目录时新的 shard 已经全部移入，删除备份即可
+func RecoverReshard(folder string) error {
+	tmp := filepath.Join(folder, reshardFolder)
+	backup := filepath.Join(folder, reshardBackup)
+
+	data, err := ioutil.ReadFile(filepath.Join(tmp, reshardDone))
+	if os.IsNotExist(err) {
+		// 没有完成标记时旧的 shard 还没有移动，或者已经全部替换
+		if err := os.RemoveAll(tmp); err != nil {
+			return err
+		}
+		return os.RemoveAll(backup)
+	}
+	if err != nil {
+		return err
+	}
+
+	to, err := strconv.Atoi(string(data))
+	if err != nil {
+		return err
+	}
+
+	// 新的 shard 还没有开始移动时，folder 中的 shard 都是旧的
+	if StoreShardsOf(tmp) == to {
+		if err := os.MkdirAll(backup, 0700); err != nil {
+			return err
+		}
+		if err := moveShards(folder, backup); err != nil {
+			return err
+		}
+	}
+
+	if err := moveShards(tmp, folder); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(tmp); err != nil {
+		return err
+	}
+
+	return os.RemoveAll(backup)
+}
+
+// moveShards 把 from 中所有的存储 shard 移到 to
+func moveShards(from, to string) error {
+	infos, err := ioutil.ReadDir(from)
+	if err != nil {
+		return err
+	}
+
+	for _, info := range infos {
+		name := strings.TrimPrefix(info.Name(), StoreFilePrefix+".")
+		if _, err := strconv.Atoi(name); err != nil || name == info.Name() {
+			continue
+		}
+
+		err := os.Rename(filepath.Join(from, info.Name()),
+			filepath.Join(to, info.Name()))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func closeStores(dbs []store.Store) (err error) {
+	for _, db := range dbs {
+		if db == nil {
+			continue
+		}
+
+		if e := db.Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+
+	return
+}
+
+// Reshard redistribute the documents to numShards index shards
+// and storeShards store shards without restarting the process
+// 在线重新分片
+//
+// Reshard 等待正在处理的请求完成，停止工作协程，迁移持久化存储，
+// 然后按新的 shard 数目重建索引器和排序器并从存储恢复索引。
+// 期间新的请求会等待 Reshard 完成。
+// 修改 NumShards 需要使用持久化存储，否则返回 ErrNoStore。
+func (engine *Engine) Reshard(numShards, storeShards int,
+	progress func(ReshardProgress)) error {
+	if numShards < 1 || storeShards < 1 {
+		return ErrInvalidShards
+	}
+
+	engine.loc.Lock()
+	defer engine.loc.Unlock()
+
+	if engine.closed {
+		return ErrEngineClosed
+	}
+
+	options := engine.initOptions
+	if !options.UseStore {
+		if numShards != options.NumShards {
+			return ErrNoStore
+		}
+		return nil
+	}
+
+	// 停止工作协程并关闭数据库
+	engine.flush()
+	close(engine.closeChan)
+	engine.wg.Wait()
+
+	err := closeStores(engine.dbs)
+	if err == nil && storeShards != options.StoreShards {
+		err = ReshardStore(options.StoreFolder, options.StoreEngine,
+			options.StoreShards, storeShards, progress)
+	}
+
+	if err == nil {
+		options.NumShards = numShards
+		options.StoreShards = storeShards
+	}
+
+	// 重置状态，按新的 shard 数目重新启动
+	engine.reset()
+	engine.initOptions = options
+	engine.start(options)
+	engine.flush()
+
+	if progress != nil {
+		num := int(engine.NumDocsIndexed())
+		progress(ReshardProgress{Stage: "index", Done: num, Total: num})
+	}
+
+	return err
+}
+
+// reset 清空索引器、排序器、通道和计数器
+func (engine *Engine) reset() {
+	engine.indexers = nil
+	engine.rankers = nil
+	engine.dbs = nil
+
+	engine.numDocsIndexed = 0
+	engine.numDocsRemoved = 0
+	engine.numDocsForceUpdated = 0
+	engine.numIndexingReqs = 0
+	engine.numRemovingReqs = 0
+	engine.numForceUpdatingReqs = 0
+	engine.numTokenIndexAdded = 0
+	engine.numDocsStored = 0
+}
@@ -0,0 +1,117 @@
+package riot
+
+import (
+	"encoding/gob"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/go-ego/riot/types"
+	"github.com/vcaesar/tt"
+)
+
+func reshardOpts() types.EngineOpts {
+	return types.EngineOpts{
+		Using:       1,
+		GseDict:     "./testdata/test_dict.txt",
+		DefRankOpts: &rankOptsMax10,
+		IndexerOpts: inxOpts,
+		NumShards:   2,
+		UseStore:    true,
+		StoreFolder: "riot.reshard",
+		StoreShards: 2,
+	}
+}
+
+func TestReshard(t *testing.T) {
+	gob.Register(ScoringFields{})
+
+	var engine Engine
+	engine.Init(reshardOpts())
+	AddDocs(&engine)
+
+	var stages []string
+	err := engine.Reshard(3, 4, func(p ReshardProgress) {
+		stages = append(stages, p.Stage)
+		tt.Expect(t, "6", p.Total)
+	})
+	tt.Nil(t, err)
+	tt.Expect(t, "[store index]", stages)
+	tt.Expect(t, "4", StoreShardsOf("riot.reshard"))
+
+	tt.Expect(t, "6", engine.NumDocsIndexed())
+	tt.Expect(t, "6", len(engine.GetDBAllIds()))
+
+	outputs := engine.Search(Req1)
+	tt.Expect(t, "3", outputs.NumDocs)
+
+	// 路由到新的 shard
+	engine.RemoveDoc("1")
+	engine.Flush()
+	tt.False(t, engine.HasDocDB("1"))
+	tt.Expect(t, "5", len(engine.GetDBAllIds()))
+	engine.Close()
+
+	// 离线迁移
+	tt.Equal(t, ErrInvalidShards, ReshardStore("riot.reshard", "", 2, 1, nil))
+	tt.Nil(t, ReshardStore("riot.reshard", "", 4, 1, nil))
+	tt.Expect(t, "1", StoreShardsOf("riot.reshard"))
+
+	opts := reshardOpts()
+	opts.StoreShards = 1
+	var engine1 Engine
+	engine1.Init(opts)
+	engine1.Flush()
+	tt.Expect(t, "5", engine1.NumDocsIndexed())
+	engine1.Close()
+
+	os.RemoveAll("riot.reshard")
+}
+
+func TestRecoverReshard(t *testing.T) {
+	gob.Register(ScoringFields{})
+
+	var engine Engine
+	engine.Init(reshardOpts())
+	AddDocs(&engine)
+	engine.Close()
+
+	// 新的 shard 没有写完，保留旧的 shard
+	tmp := "riot.reshard/" + reshardFolder
+	tt.Nil(t, os.MkdirAll(tmp+"/"+StoreFilePrefix+".0", 0700))
+	tt.Nil(t, RecoverReshard("riot.reshard"))
+	tt.Expect(t, "2", StoreShardsOf("riot.reshard"))
+	_, err := os.Stat(tmp)
+	tt.True(t, os.IsNotExist(err))
+
+	// 写完新的 shard，移动旧的 shard 时中断
+	opts := reshardOpts()
+	opts.StoreFolder = tmp
+	opts.StoreShards = 3
+	var engine1 Engine
+	engine1.Init(opts)
+	AddDocs(&engine1)
+	engine1.Close()
+
+	backup := "riot.reshard/" + reshardBackup
+	tt.Nil(t, ioutil.WriteFile(tmp+"/"+reshardDone, []byte("3"), 0600))
+	tt.Nil(t, os.MkdirAll(backup, 0700))
+	tt.Nil(t, os.Rename(storePath("riot.reshard", 0), storePath(backup, 0)))
+
+	// 打开时完成替换
+	opts = reshardOpts()
+	opts.StoreShards = 3
+	var engine2 Engine
+	engine2.Init(opts)
+	engine2.Flush()
+	tt.Expect(t, "6", engine2.NumDocsIndexed())
+	tt.Expect(t, "3", StoreShardsOf("riot.reshard"))
+	engine2.Close()
+
+	_, err = os.Stat(tmp)
+	tt.True(t, os.IsNotExist(err))
+	_, err = os.Stat(backup)
+	tt.True(t, os.IsNotExist(err))
+
+	os.RemoveAll("riot.reshard")
+}
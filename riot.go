@@ -23,7 +23,6 @@ import (
 	"encoding/gob"
 
 	"github.com/go-ego/murmur"
-	"github.com/go-ego/riot/core"
 	"github.com/go-ego/riot/types"
 	toml "github.com/go-vgo/gt/conf"
 )
@@ -104,17 +103,11 @@ func NewEngine(conf ...interface{}) *Engine {
 
 // HasDoc if the document is exist return true
 func (engine *Engine) HasDoc(docId string) bool {
-	for shard := 0; shard < engine.initOptions.NumShards; shard++ {
-		engine.indexers = append(engine.indexers, core.Indexer{})
-
-		has := engine.indexers[shard].HasDoc(docId)
-
-		if has {
-			return true
-		}
+	if !engine.initialized {
+		return false
 	}
 
-	return false
+	return engine.indexers[engine.DocShard(docId)].HasDoc(docId)
 }
 
 // HasDocDB if the document is exist in the database
@@ -130,6 +123,64 @@ func (engine *Engine) HasDocDB(docId string) bool {
 	return has
 }
 
+// GetDoc get the document by docId, return ErrDocNotFound
+// if the document does not exist
+// 获取一个文档
+//
+// 使用持久化存储时从文档所在的存储 shard 读取完整的 DocData，
+// 否则从排序器读取，只有 Content、Attri 和 Fields。
+// 和 Search 一样，刚加入的文档需要 Flush 之后才能读取到。
+func (engine *Engine) GetDoc(docId string) (types.DocData, error) {
+	if !engine.initialized {
+		log.Fatal("The engine must be initialized first.")
+	}
+
+	if err := engine.begin(); err != nil {
+		return types.DocData{}, err
+	}
+	defer engine.end()
+
+	return engine.getDoc(docId)
+}
+
+// MultiGet get the documents by docIds, the returned docs and errs
+// correspond to docIds one by one
+// 批量获取文档，文档不存在时对应的 err 为 ErrDocNotFound
+func (engine *Engine) MultiGet(docIds []string) ([]types.DocData, []error) {
+	if !engine.initialized {
+		log.Fatal("The engine must be initialized first.")
+	}
+
+	docs := make([]types.DocData, len(docIds))
+	errs := make([]error, len(docIds))
+	if err := engine.begin(); err != nil {
+		for i := range errs {
+			errs[i] = err
+		}
+		return docs, errs
+	}
+	defer engine.end()
+
+	for i, docId := range docIds {
+		docs[i], errs[i] = engine.getDoc(docId)
+	}
+
+	return docs, errs
+}
+
+func (engine *Engine) getDoc(docId string) (types.DocData, error) {
+	if engine.initOptions.UseStore {
+		return engine.storedDoc(docId)
+	}
+
+	fields, content, attri, ok := engine.rankers[engine.DocShard(docId)].GetDoc(docId)
+	if !ok {
+		return types.DocData{}, ErrDocNotFound
+	}
+
+	return types.DocData{Content: content, Attri: attri, Fields: fields}, nil
+}
+
 // GetDBAllIds get all the DocId from the storage database
 // and return
 // 从数据库遍历所有的 DocId, 并返回
@@ -138,6 +189,9 @@ func (engine *Engine) GetDBAllIds() []string {
 
 	for i := range engine.dbs {
 		engine.dbs[i].ForEach(func(k, v []byte) error {
+			if isTombstone(k) {
+				return nil
+			}
 			// fmt.Println(k, v)
 			docsId = append(docsId, string(k))
 			return nil
@@ -151,6 +205,9 @@ func (engine *Engine) GetDBAllIds() []string {
 func (engine *Engine) GetDBAllDocs() (docsId []string, docsData []types.DocData) {
 	for i := range engine.dbs {
 		engine.dbs[i].ForEach(func(key, val []byte) error {
+			if isTombstone(key) {
+				return nil
+			}
 			// fmt.Println(k, v)
 			docsId = append(docsId, string(key))
 
@@ -172,6 +229,31 @@ func (engine *Engine) GetDBAllDocs() (docsId []string, docsData []types.DocData)
 	return docsId, docsData
 }
 
+// forEachDBDoc call fn with every document in the storage database
+func (engine *Engine) forEachDBDoc(
+	fn func(docId string, data types.DocData) error) error {
+	for i := range engine.dbs {
+		err := engine.dbs[i].ForEach(func(key, val []byte) error {
+			if isTombstone(key) {
+				return nil
+			}
+
+			var data types.DocData
+			err := gob.NewDecoder(bytes.NewReader(val)).Decode(&data)
+			if err != nil {
+				return err
+			}
+
+			return fn(string(key), data)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // GetAllDocIds get all the DocId from the storage database
 // and return
 // 从数据库遍历所有的 DocId, 并返回
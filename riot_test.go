@@ -40,11 +40,11 @@ func TestEngineIndexWithNewStore(t *testing.T) {
 	tt.Expect(t, "2", len(outDocs))
 
 	// tt.Expect(t, "2", outDocs[0].DocId)
-	tt.Expect(t, "2500", int(outDocs[0].Scores[0]*1000))
+	tt.Expect(t, "2531", int(outDocs[0].Scores[0]*1000))
 	tt.Expect(t, "[]", outDocs[0].TokenSnippetLocs)
 
 	// tt.Expect(t, "1", outDocs[1].DocId)
-	tt.Expect(t, "2215", int(outDocs[1].Scores[0]*1000))
+	tt.Expect(t, "2000", int(outDocs[1].Scores[0]*1000))
 	tt.Expect(t, "[]", outDocs[1].TokenSnippetLocs)
 
 	engine1.Close()
@@ -191,7 +191,7 @@ func TestDocGetAllDocAndID(t *testing.T) {
 	tt.Expect(t, "5", len(ids))
 	tt.Expect(t, "5", len(docs))
 	tt.Expect(t, "[3 4 1 6 2]", ids)
-	allDoc := `[{The world <nil> [] [] <nil>} {有人口 <nil> [] [] {2 3 1}} {The world, 有七十亿人口人口 <nil> [] [] {1 2 3}} {有七十亿人口 <nil> [] [] {2 3 3}} {The world, 人口 <nil> [] [] <nil>}]`
+	allDoc := `[{The world <nil> [] [] <nil> 0  0} {有人口 <nil> [] [] {2 3 1} 0  0} {The world, 有七十亿人口人口 <nil> [] [] {1 2 3} 0  0} {有七十亿人口 <nil> [] [] {2 3 3} 0  0} {The world, 人口 <nil> [] [] <nil> 0  0}]`
 	tt.Expect(t, allDoc, docs)
 
 	has := engine.HasDoc("5")
@@ -234,6 +234,40 @@ func TestDocGetAllDocAndID(t *testing.T) {
 	os.RemoveAll("riot.id")
 }
 
+func TestGetDoc(t *testing.T) {
+	gob.Register(ScoringFields{})
+
+	for _, useStore := range []bool{true, false} {
+		opts := engOpts
+		opts.UseStore = useStore
+		opts.StoreFolder = "riot.get"
+		opts.StoreShards = 2
+
+		var engine Engine
+		engine.Init(opts)
+		AddDocs(&engine)
+
+		doc, err := engine.GetDoc("4")
+		tt.Nil(t, err)
+		tt.Equal(t, "有人口", doc.Content)
+		tt.Equal(t, ScoringFields{2, 3, 1}, doc.Fields)
+
+		_, err = engine.GetDoc("10")
+		tt.Equal(t, ErrDocNotFound, err)
+
+		docs, errs := engine.MultiGet([]string{"1", "10", "6"})
+		tt.Expect(t, "3", len(docs))
+		tt.Nil(t, errs[0])
+		tt.Equal(t, "The world, 有七十亿人口人口", docs[0].Content)
+		tt.Equal(t, ErrDocNotFound, errs[1])
+		tt.Nil(t, errs[2])
+		tt.Equal(t, "有七十亿人口", docs[2].Content)
+
+		engine.Close()
+		os.RemoveAll("riot.get")
+	}
+}
+
 func testOpts(use int, store string, args ...bool) types.EngineOpts {
 	var pinyin bool
 	if len(args) > 0 {
@@ -355,7 +389,7 @@ func TestForSplitData(t *testing.T) {
 func testNum(t *testing.T, numAdd, numInx, numRm uint64) {
 	tt.Expect(t, "26", numAdd)
 	tt.Expect(t, "6", numInx)
-	tt.Expect(t, "8", numRm)
+	tt.Expect(t, "1", numRm)
 }
 func TestDocCounters(t *testing.T) {
 	var engine Engine
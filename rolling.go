@@ -0,0 +1,483 @@
+// Copyright 2016 ego authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package riot
+
+import (
+	"errors"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-ego/riot/types"
+)
+
+// ErrPartitionExpired is returned when indexing a document whose
+// partition has already passed the retention window
+var ErrPartitionExpired = errors.New("the partition has expired")
+
+const (
+	// defaultPeriod 默认按天分区
+	defaultPeriod = 24 * time.Hour
+	// defaultCheckInterval 默认检查保留时间的间隔
+	defaultCheckInterval = time.Minute
+)
+
+// RollingOpts the options of the time-partitioned rolling index
+type RollingOpts struct {
+	// 每个分区的引擎选项，StoreFolder 为 root/分区名
+	EngineOpts types.EngineOpts
+
+	// 每个分区的时间跨度，至少一分钟，默认 24 小时
+	Period time.Duration
+	// 保留时间，分区的结束时间早于 now - Retention 时整个分区被删除，
+	// 0 表示永久保留；CheckInterval 为检查的间隔，默认一分钟
+	Retention     time.Duration
+	CheckInterval time.Duration
+
+	// 文档的时间，决定文档所在的分区；
+	// 默认使用 types.Attri.Ts（Unix 纳秒），没有时使用当前时间
+	TimeField func(types.DocData) time.Time
+}
+
+// Rolling time-partitioned rolling index
+// 按时间分区的滚动索引
+//
+// 文档按 TimeField 写入对应时间段的分区，每个分区是 Manager 中的
+// 一个命名索引，名字为分区开始的 UTC 时间，如按天分区的 "20170102"、
+// 按小时分区的 "2017010215"。Search 同时查询全部分区并合并结果，
+// 超过保留时间的分区整个删除，代价远小于逐个删除文档。
+// 文档的时间改变后会同时存在于新旧两个分区，需要先 RemoveDoc。
+// Rolling 是线程安全的。
+type Rolling struct {
+	opts    RollingOpts
+	layout  string
+	manager *Manager
+
+	// 创建和删除分区时持有写锁
+	lock      sync.RWMutex
+	closeChan chan bool
+	wg        sync.WaitGroup
+}
+
+// NewRolling create the rolling index under the data root,
+// the existing partitions are opened and the expired ones are dropped
+func NewRolling(root string, opts RollingOpts) *Rolling {
+	if opts.Period == 0 {
+		opts.Period = defaultPeriod
+	}
+	if opts.Period < time.Minute || opts.Period%time.Minute != 0 {
+		log.Fatal("The period of the rolling index must be whole minutes.")
+	}
+
+	if opts.CheckInterval <= 0 {
+		opts.CheckInterval = defaultCheckInterval
+	}
+
+	if opts.TimeField == nil {
+		opts.TimeField = attriTime
+	}
+
+	r := &Rolling{
+		opts:      opts,
+		layout:    periodLayout(opts.Period),
+		manager:   NewManager(root),
+		closeChan: make(chan bool),
+	}
+
+	names, err := r.manager.List()
+	if err != nil {
+		log.Fatalf("Can not list the partitions: %v", err)
+	}
+	for _, name := range names {
+		if _, ok := r.start(name); ok {
+			r.manager.Open(name, opts.EngineOpts)
+		}
+	}
+	r.Expire()
+
+	if opts.Retention > 0 {
+		r.wg.Add(1)
+		go r.retain()
+	}
+
+	return r
+}
+
+// attriTime 默认的文档时间
+func attriTime(data types.DocData) time.Time {
+	if attri, ok := data.Attri.(types.Attri); ok && attri.Ts > 0 {
+		return time.Unix(0, attri.Ts)
+	}
+
+	return time.Now()
+}
+
+// periodLayout 分区名的时间格式，精确到分区的时间跨度
+func periodLayout(period time.Duration) string {
+	switch {
+	case period%(24*time.Hour) == 0:
+		return "20060102"
+	case period%time.Hour == 0:
+		return "2006010215"
+	default:
+		return "200601021504"
+	}
+}
+
+// Manager return the index manager of the partitions
+func (r *Rolling) Manager() *Manager {
+	return r.manager
+}
+
+// Partition get the partition name of the time
+func (r *Rolling) Partition(t time.Time) string {
+	return t.UTC().Truncate(r.opts.Period).Format(r.layout)
+}
+
+// start 分区的开始时间，不是分区名时返回 false
+func (r *Rolling) start(name string) (time.Time, bool) {
+	t, err := time.ParseInLocation(r.layout, name, time.UTC)
+	if err != nil || r.Partition(t) != name {
+		return t, false
+	}
+
+	return t, true
+}
+
+// expired 分区是否已经超过保留时间
+func (r *Rolling) expired(start, now time.Time) bool {
+	if r.opts.Retention <= 0 {
+		return false
+	}
+
+	return !start.Add(r.opts.Period).After(now.Add(-r.opts.Retention))
+}
+
+// Partitions list the opened partitions, sorted from old to new
+func (r *Rolling) Partitions() []string {
+	var names []string
+	for _, name := range r.manager.Opened() {
+		if _, ok := r.start(name); ok {
+			names = append(names, name)
+		}
+	}
+
+	// 分区名的时间格式是定长的，按名字排序即按时间排序
+	sort.Strings(names)
+	return names
+}
+
+// Get get the engine of the partition
+func (r *Rolling) Get(name string) (*Engine, error) {
+	if _, ok := r.start(name); !ok {
+		return nil, ErrIndexNotFound
+	}
+
+	return r.manager.Get(name)
+}
+
+// engines 全部分区的引擎
+func (r *Rolling) engines() []*Engine {
+	var engines []*Engine
+	for _, name := range r.Partitions() {
+		if engine, err := r.manager.Get(name); err == nil {
+			engines = append(engines, engine)
+		}
+	}
+
+	return engines
+}
+
+// closed 是否已经 Close，调用者需持有 r.lock
+func (r *Rolling) closed() bool {
+	select {
+	case <-r.closeChan:
+		return true
+	default:
+		return false
+	}
+}
+
+// partition 获取文档时间所在的分区，分区不存在时创建
+func (r *Rolling) partition(t time.Time) (*Engine, error) {
+	name := r.Partition(t)
+	start, _ := r.start(name)
+	if r.expired(start, time.Now()) {
+		return nil, ErrPartitionExpired
+	}
+
+	r.lock.RLock()
+	if r.closed() {
+		r.lock.RUnlock()
+		return nil, ErrEngineClosed
+	}
+	engine, err := r.manager.Get(name)
+	r.lock.RUnlock()
+	if err == nil {
+		return engine, nil
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	// Close 之后不再创建新的分区
+	if r.closed() {
+		return nil, ErrEngineClosed
+	}
+
+	engine, err = r.manager.Create(name, r.opts.EngineOpts)
+	if err == ErrIndexExists {
+		return r.manager.Open(name, r.opts.EngineOpts)
+	}
+
+	return engine, err
+}
+
+// Index add the document to the partition of its time
+// 将文档加入所在时间段的分区，分区不存在时自动创建，
+// 分区已经超过保留时间返回 ErrPartitionExpired
+func (r *Rolling) Index(docId string, data types.DocData,
+	forceUpdate ...bool) error {
+	engine, err := r.partition(r.opts.TimeField(data))
+	if err != nil {
+		return err
+	}
+
+	return engine.Index(docId, data, forceUpdate...)
+}
+
+// IndexBatch add the documents to the partitions of their time in batch,
+// return the error of each document
+func (r *Rolling) IndexBatch(docs []types.BatchDoc,
+	forceUpdate ...bool) []error {
+	errs := make([]error, len(docs))
+
+	// 按分区分组，每个分区批量加入一次
+	groups := make(map[*Engine][]int)
+	for i, doc := range docs {
+		engine, err := r.partition(r.opts.TimeField(doc.Data))
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		groups[engine] = append(groups[engine], i)
+	}
+
+	for engine, idx := range groups {
+		batch := make([]types.BatchDoc, len(idx))
+		for j, i := range idx {
+			batch[j] = docs[i]
+		}
+
+		for j, err := range engine.IndexBatch(batch, forceUpdate...) {
+			errs[idx[j]] = err
+		}
+	}
+
+	return errs
+}
+
+// RemoveDoc remove the document from all the partitions
+func (r *Rolling) RemoveDoc(docId string, forceUpdate ...bool) error {
+	for _, engine := range r.engines() {
+		err := engine.RemoveDoc(docId, forceUpdate...)
+		if err != nil && err != ErrEngineClosed {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Flush block wait until all the partitions are flushed
+func (r *Rolling) Flush() {
+	for _, engine := range r.engines() {
+		engine.Flush()
+	}
+}
+
+// Search search all the partitions and merge the results
+// 同时查询全部分区，按评分合并结果后再截取 OutputOffset 和 MaxOutputs
+func (r *Rolling) Search(request types.SearchReq) (output types.SearchResp) {
+	engines := r.engines()
+	if len(engines) == 0 {
+		return
+	}
+
+	var rankOpts types.RankOpts
+	if request.RankOpts == nil {
+		rankOpts = *engines[0].initOptions.DefRankOpts
+	} else {
+		rankOpts = *request.RankOpts
+	}
+
+	// 每个分区返回 offset 之前的全部文档
+	partOpts := rankOpts
+	if partOpts.MaxOutputs != 0 {
+		partOpts.MaxOutputs += partOpts.OutputOffset
+	}
+	partOpts.OutputOffset = 0
+	partReq := request
+	partReq.RankOpts = &partOpts
+
+	outputs := make([]types.SearchResp, len(engines))
+	var wg sync.WaitGroup
+	wg.Add(len(engines))
+	for i, engine := range engines {
+		go func(i int, engine *Engine) {
+			defer wg.Done()
+			outputs[i] = engine.Search(partReq)
+		}(i, engine)
+	}
+	wg.Wait()
+
+	var (
+		ids  types.ScoredIDs
+		docs types.ScoredDocs
+	)
+	for _, out := range outputs {
+		if out.Err != nil {
+			// 分区在查询期间被删除
+			if out.Err == ErrEngineClosed {
+				continue
+			}
+			output.Err = out.Err
+			return
+		}
+
+		output.Tokens = out.Tokens
+		output.NumDocs += out.NumDocs
+		output.Timeout = output.Timeout || out.Timeout
+
+		switch d := out.Docs.(type) {
+		case types.ScoredIDs:
+			ids = append(ids, d...)
+		case types.ScoredDocs:
+			docs = append(docs, d...)
+		}
+	}
+
+	if request.CountDocsOnly {
+		return
+	}
+
+	if engines[0].initOptions.IDOnly {
+		output.Docs = mergeRank(ids, request, rankOpts)
+	} else {
+		output.Docs = mergeRank(docs, request, rankOpts)
+	}
+
+	return
+}
+
+// mergeRank 对合并后的结果重新排序并截取
+func mergeRank(docs sort.Interface, request types.SearchReq,
+	rankOpts types.RankOpts) interface{} {
+	if !request.Orderless {
+		if rankOpts.ReverseOrder {
+			sort.Stable(sort.Reverse(docs))
+		} else {
+			sort.Stable(docs)
+		}
+	}
+
+	start, end := 0, docs.Len()
+	if !request.Orderless {
+		start, end = maxRankOutput(rankOpts, docs.Len())
+	}
+
+	switch d := docs.(type) {
+	case types.ScoredIDs:
+		return d[start:end]
+	case types.ScoredDocs:
+		return d[start:end]
+	}
+
+	return docs
+}
+
+// Drop drop the partition and all its data
+func (r *Rolling) Drop(name string) error {
+	if _, ok := r.start(name); !ok {
+		return ErrIndexNotFound
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.manager.Drop(name)
+}
+
+// Expire drop all the partitions which have passed the retention
+// window, return the names of the dropped partitions
+// 删除超过保留时间的分区，后台每隔 CheckInterval 自动执行
+func (r *Rolling) Expire() ([]string, error) {
+	names, err := r.manager.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var dropped []string
+	now := time.Now()
+	for _, name := range names {
+		start, ok := r.start(name)
+		if !ok || !r.expired(start, now) {
+			continue
+		}
+
+		if err := r.Drop(name); err != nil {
+			return dropped, err
+		}
+		dropped = append(dropped, name)
+	}
+
+	return dropped, nil
+}
+
+// retain 定时删除超过保留时间的分区
+func (r *Rolling) retain() {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.opts.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := r.Expire(); err != nil {
+				log.Println("Expire the partitions: ", err)
+			}
+		case <-r.closeChan:
+			return
+		}
+	}
+}
+
+// Close stop the retention and close all the partitions
+func (r *Rolling) Close() error {
+	r.lock.Lock()
+	select {
+	case <-r.closeChan:
+		r.lock.Unlock()
+		return nil
+	default:
+		close(r.closeChan)
+	}
+	r.lock.Unlock()
+
+	r.wg.Wait()
+	return r.manager.CloseAll()
+}
@@ -0,0 +1,90 @@
+package riot
+
+import (
+	"encoding/gob"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-ego/riot/types"
+	"github.com/vcaesar/tt"
+)
+
+func rollingOpts(retention time.Duration) RollingOpts {
+	return RollingOpts{
+		EngineOpts: types.EngineOpts{
+			Using:       1,
+			GseDict:     "./testdata/test_dict.txt",
+			IndexerOpts: inxOpts,
+			UseStore:    true,
+			StoreShards: 2,
+		},
+		Retention: retention,
+	}
+}
+
+func tsDoc(content string, t time.Time) types.DocData {
+	return types.DocData{Content: content, Attri: types.Attri{Ts: t.UnixNano()}}
+}
+
+func TestRolling(t *testing.T) {
+	gob.Register(types.Attri{})
+	root := "riot.rolling"
+	os.RemoveAll(root)
+	defer os.RemoveAll(root)
+
+	now := time.Now()
+	day := 24 * time.Hour
+
+	r := NewRolling(root, rollingOpts(0))
+	tt.Nil(t, r.Index("1", tsDoc("The world", now)))
+	errs := r.IndexBatch([]types.BatchDoc{
+		{DocId: "2", Data: tsDoc("The world, 人口", now.Add(-day))},
+		{DocId: "3", Data: tsDoc("The world, 有人口", now.Add(-3*day))},
+	})
+	tt.Nil(t, errs[0])
+	tt.Nil(t, errs[1])
+	r.Flush()
+
+	tt.Equal(t, []string{r.Partition(now.Add(-3 * day)),
+		r.Partition(now.Add(-day)), r.Partition(now)}, r.Partitions())
+	tt.Equal(t, 8, len(r.Partition(now)))
+
+	// 分区共享同一个分词器
+	first, _ := r.Manager().Get(r.Partition(now))
+	last, _ := r.Manager().Get(r.Partition(now.Add(-3 * day)))
+	tt.True(t, first.dict == last.dict)
+
+	outputs := r.Search(types.SearchReq{Text: "world"})
+	tt.Expect(t, "3", outputs.NumDocs)
+	tt.Expect(t, "3", len(outputs.Docs.(types.ScoredDocs)))
+
+	outputs = r.Search(types.SearchReq{Text: "world", RankOpts: &types.RankOpts{
+		OutputOffset: 1, MaxOutputs: 1}})
+	tt.Expect(t, "3", outputs.NumDocs)
+	tt.Expect(t, "1", len(outputs.Docs.(types.ScoredDocs)))
+
+	tt.Nil(t, r.RemoveDoc("1", true))
+	r.Flush()
+	tt.Expect(t, "2", r.Search(types.SearchReq{Text: "world"}).NumDocs)
+	tt.Nil(t, r.Close())
+	// Close 之后不再创建分区
+	tt.Equal(t, ErrEngineClosed, r.Index("4", tsDoc("The world", now.Add(day))))
+
+	// 重新打开时删除超过保留时间的分区
+	r = NewRolling(root, rollingOpts(2*day))
+	defer r.Close()
+	r.Flush()
+
+	tt.Equal(t, []string{r.Partition(now.Add(-day)), r.Partition(now)},
+		r.Partitions())
+	outputs = r.Search(types.SearchReq{Text: "world"})
+	tt.Expect(t, "1", outputs.NumDocs)
+	tt.Equal(t, "2", outputs.Docs.(types.ScoredDocs)[0].DocId)
+
+	tt.Equal(t, ErrPartitionExpired,
+		r.Index("4", tsDoc("The world", now.Add(-3*day))))
+
+	tt.Nil(t, r.Drop(r.Partition(now.Add(-day))))
+	tt.Expect(t, "0", r.Search(types.SearchReq{Text: "world"}).NumDocs)
+}
@@ -0,0 +1,133 @@
+// Copyright 2016 ego authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package riot
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/go-ego/murmur"
+)
+
+// routes 使用自定义路由键的文档，docId 到 DocData.Routing
+//
+// 文档按 murmur(Routing) 分配索引 shard，Routing 为空时使用 docId，
+// 所以只需要记录自定义了路由键的文档。
+// 旧版本按 docId 和文本分配 shard，索引在启动时从持久化存储重建，
+// 因此旧的存储不需要迁移，重新打开后即按新的规则分配。
+type routes struct {
+	sync.RWMutex
+	docs map[string]string
+}
+
+// routeShard 路由键对应的索引 shard
+func (engine *Engine) routeShard(routing string) int {
+	return engine.getShard(murmur.Sum32(routing))
+}
+
+// DocShard get the index shard of the document
+// 文档所在的索引 shard
+func (engine *Engine) DocShard(docId string) int {
+	engine.routes.RLock()
+	defer engine.routes.RUnlock()
+
+	return engine.docShard(docId)
+}
+
+// docShard 调用者需持有 routes 的锁
+func (engine *Engine) docShard(docId string) int {
+	routing, ok := engine.routes.docs[docId]
+	if !ok {
+		routing = docId
+	}
+
+	return engine.routeShard(routing)
+}
+
+// route 记录文档的路由键，返回文档的新 shard 和之前所在的 shard
+func (engine *Engine) route(docId, routing string) (shard, old int) {
+	if routing == "" {
+		routing = docId
+	}
+	shard = engine.routeShard(routing)
+
+	engine.routes.Lock()
+	prev, ok := engine.routes.docs[docId]
+	if routing == docId {
+		delete(engine.routes.docs, docId)
+	} else {
+		engine.routes.docs[docId] = routing
+	}
+	engine.routes.Unlock()
+
+	if !ok {
+		prev = docId
+	}
+
+	return shard, engine.routeShard(prev)
+}
+
+// unroute 删除文档的路由键，返回文档所在的 shard
+func (engine *Engine) unroute(docId string) int {
+	shard := engine.DocShard(docId)
+
+	engine.routes.Lock()
+	delete(engine.routes.docs, docId)
+	engine.routes.Unlock()
+
+	return shard
+}
+
+// moveDoc 文档的路由键改变后，从之前的 shard 中删除
+func (engine *Engine) moveDoc(docId string, old int) {
+	engine.queueRemove(old, docId, false)
+}
+
+// queueRemove 从 shard 中删除文档
+//
+// 删除请求经过加入文档的通道，保证在之前发送到该 shard 的文档之后执行，
+// 否则索引器还不知道的文档不会被删除。
+func (engine *Engine) queueRemove(shard int, docId string, force bool) {
+	atomic.AddUint64(&engine.numRemovingReqs, 1)
+
+	engine.indexerAddDocChans[shard] <- indexerAddDocReq{
+		remove: docId, forceUpdate: force}
+	engine.rankerAddDocChans[shard] <- rankerAddDocReq{docId: docId, remove: true}
+}
+
+// sendDoc 将文档发送到 shard 的索引器和排序器，emit 为 true 时产生变更事件，
+// 文档的版本已经过期或者路由已经改变时不发送，返回 false
+func (engine *Engine) sendDoc(shard int, docId string, version docVersion,
+	indexerReq indexerAddDocReq, rankerReq rankerAddDocReq, emit bool) bool {
+	sent := false
+	engine.ifCurrent(docId, version, func() {
+		// 发送期间路由不会改变，moveDoc 的删除请求一定在这之后
+		engine.routes.RLock()
+		defer engine.routes.RUnlock()
+
+		if engine.docShard(docId) != shard {
+			return
+		}
+
+		engine.indexerAddDocChans[shard] <- indexerReq
+		engine.rankerAddDocChans[shard] <- rankerReq
+		sent = true
+		if emit {
+			engine.indexed(docId, version.version)
+		}
+	})
+
+	return sent
+}
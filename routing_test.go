@@ -0,0 +1,86 @@
+package riot
+
+import (
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/go-ego/riot/types"
+	"github.com/vcaesar/tt"
+)
+
+func routingOpts() types.EngineOpts {
+	return types.EngineOpts{
+		Using:       1,
+		GseDict:     "./testdata/test_dict.txt",
+		IndexerOpts: inxOpts,
+		NumShards:   4,
+		UseStore:    true,
+		StoreFolder: "riot.routing",
+		StoreShards: 2,
+	}
+}
+
+func searchIds(engine *Engine, text string) []string {
+	outputs := engine.Search(types.SearchReq{Text: text})
+	outDocs, _ := outputs.Docs.(types.ScoredDocs)
+
+	ids := make([]string, len(outDocs))
+	for i, doc := range outDocs {
+		ids[i] = doc.DocId
+	}
+	return ids
+}
+
+func TestRouting(t *testing.T) {
+	os.RemoveAll("riot.routing")
+	defer os.RemoveAll("riot.routing")
+
+	var engine Engine
+	engine.Init(routingOpts())
+
+	// 同一个 docId 修改文本后仍在同一个 shard
+	engine.Index("1", types.DocData{Content: "The world"})
+	engine.Index("1", types.DocData{Content: "The world, 人口"})
+	engine.Flush()
+	tt.Equal(t, []string{"1"}, searchIds(&engine, "world"))
+	tt.True(t, engine.HasDoc("1"))
+
+	// 找一个和 docId 分配到不同 shard 的路由键
+	var routing string
+	for i := 0; ; i++ {
+		routing = "user" + strconv.Itoa(i)
+		if engine.routeShard(routing) != engine.DocShard("2") {
+			break
+		}
+	}
+
+	engine.Index("2", types.DocData{Content: "The world"})
+	engine.IndexBatch([]types.BatchDoc{
+		{DocId: "2", Data: types.DocData{Content: "The world", Routing: routing}},
+		{DocId: "3", Data: types.DocData{Content: "The world", Routing: routing}},
+	})
+	engine.Flush()
+
+	tt.Equal(t, engine.routeShard(routing), engine.DocShard("2"))
+	tt.Equal(t, engine.DocShard("2"), engine.DocShard("3"))
+	tt.Expect(t, "3", len(searchIds(&engine, "world")))
+	tt.True(t, engine.HasDoc("2"))
+
+	tt.Nil(t, engine.UpdateFields("3", nil))
+	engine.RemoveDoc("3", true)
+	engine.Flush()
+	tt.False(t, engine.HasDoc("3"))
+	tt.Expect(t, "2", len(searchIds(&engine, "world")))
+	engine.Close()
+
+	// 从数据库恢复路由键
+	var engine1 Engine
+	engine1.Init(routingOpts())
+	engine1.Flush()
+
+	tt.Equal(t, engine1.routeShard(routing), engine1.DocShard("2"))
+	tt.True(t, engine1.HasDoc("2"))
+	tt.Expect(t, "2", len(searchIds(&engine1, "world")))
+	engine1.Close()
+}
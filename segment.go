@@ -19,6 +19,7 @@ import (
 	// "fmt"
 
 	"strings"
+	"sync/atomic"
 
 	"github.com/go-ego/gpy"
 	"github.com/go-ego/gpy/phrase"
@@ -30,87 +31,50 @@ type TMap map[string][]int
 
 type segmenterReq struct {
 	docId string
-	hash  uint32
+	shard int
 	data  types.DocData
 	// data        types.DocumentIndexData
 	forceUpdate bool
+	// 从持久化存储恢复，不产生变更事件
+	recovered bool
 }
 
 // ForSplitData for split segment's data, segspl
+//
+// Deprecated: use the PrefixTokenizer in an Analyzer.
 func (engine *Engine) ForSplitData(strData []string, num int) (TMap, int) {
-	var (
-		numTokens int
-		splitStr  string
-	)
+	numTokens := 0
 	tokensMap := make(map[string][]int)
 
-	for i := 0; i < num; i++ {
-		if strData[i] != "" {
-			if !engine.stopTokens.IsStopToken(strData[i]) {
-				numTokens++
-				tokensMap[strData[i]] = append(tokensMap[strData[i]], numTokens)
-			}
-
-			splitStr += strData[i]
-			if !engine.stopTokens.IsStopToken(splitStr) {
-				numTokens++
-				tokensMap[splitStr] = append(tokensMap[splitStr], numTokens)
-			}
-
-			if engine.initOptions.Using == 6 {
-				// more combination
-				var splitsStr string
-				for s := i + 1; s < len(strData); s++ {
-					splitsStr += strData[s]
-
-					if !engine.stopTokens.IsStopToken(splitsStr) {
-						numTokens++
-						tokensMap[splitsStr] = append(tokensMap[splitsStr], numTokens)
-					}
-				}
-			}
-
+	for _, token := range prefixTokens(strData[:num], engine.initOptions.Using == 6) {
+		if !engine.stopTokens.IsStopToken(token.Text) {
+			numTokens++
+			tokensMap[token.Text] = append(tokensMap[token.Text], numTokens)
 		}
 	}
 
 	return tokensMap, numTokens
 }
 
-func (engine *Engine) splitData(request segmenterReq) (TMap, int) {
-	var (
-		num       int
-		numTokens int
-	)
+// makeTokensMap 使用索引的分析器分析文档正文，并加入 DocData.Tokens
+func (engine *Engine) makeTokensMap(request segmenterReq) (map[string][]int, int) {
 	tokensMap := make(map[string][]int)
+	numTokens := 0
 
 	if request.data.Content != "" {
-		content := strings.ToLower(request.data.Content)
-		if engine.initOptions.Using == 3 {
-			// use segmenter
-			segments := engine.segmenter.ModeSegment([]byte(content),
-				engine.initOptions.GseMode)
-
-			for _, segment := range segments {
-				token := segment.Token().Text()
-				if !engine.stopTokens.IsStopToken(token) {
-					tokensMap[token] = append(tokensMap[token], segment.Start())
-				}
-			}
-			numTokens += len(segments)
-		}
+		engine.addSubFields(request.data.Content, tokensMap)
+	}
 
-		if engine.initOptions.Using == 4 {
-			tokensMap, numTokens = engine.defaultTokens(content)
+	analyzer := engine.analyzer
+	if request.data.Content != "" && analyzer.Tokenizer != nil {
+		var tokens []Token
+		tokens, numTokens = analyzer.Analyze(request.data.Content)
+		for _, token := range tokens {
+			tokensMap[token.Text] = append(tokensMap[token.Text], token.Start)
 		}
 
-		if engine.initOptions.Using != 4 {
-			strData := strings.Split(content, "")
-			num = len(strData)
-			tokenMap, numToken := engine.ForSplitData(strData, num)
-			numTokens += numToken
-			for key, val := range tokenMap {
-				tokensMap[key] = val
-			}
+		if analyzer.ContentOnly {
+			return tokensMap, numTokens
 		}
 	}
 
@@ -119,135 +83,61 @@ func (engine *Engine) splitData(request segmenterReq) (TMap, int) {
 			tokensMap[t.Text] = t.Locations
 		}
 	}
-
 	numTokens += len(request.data.Tokens)
 
 	return tokensMap, numTokens
 }
 
-func (engine *Engine) segmenterData(request segmenterReq) (TMap, int) {
-	tokensMap := make(map[string][]int)
-	numTokens := 0
+// makeDocIndex segment the document and make the document index
+func (engine *Engine) makeDocIndex(request segmenterReq) *types.DocIndex {
+	tokensMap, numTokens := engine.makeTokensMap(request)
 
-	if engine.initOptions.Using == 0 && request.data.Content != "" {
-		// Content 分词, 当文档正文不为空时，优先从内容分词中得到关键词
-		segments := engine.segmenter.ModeSegment([]byte(request.data.Content),
-			engine.initOptions.GseMode)
-
-		for _, segment := range segments {
-			token := segment.Token().Text()
-			if !engine.stopTokens.IsStopToken(token) {
-				tokensMap[token] = append(tokensMap[token], segment.Start())
+	// 加入非分词的文档标签
+	for _, label := range request.data.Labels {
+		if !engine.initOptions.NotUseGse {
+			if !engine.stopTokens.IsStopToken(label) {
+				// 当正文中已存在关键字时，若不判断，位置信息将会丢失
+				if _, ok := tokensMap[label]; !ok {
+					tokensMap[label] = []int{}
+				}
 			}
-		}
-
-		for _, t := range request.data.Tokens {
-			if !engine.stopTokens.IsStopToken(t.Text) {
-				tokensMap[t.Text] = t.Locations
+		} else {
+			// 当正文中已存在关键字时，若不判断，位置信息将会丢失
+			if _, ok := tokensMap[label]; !ok {
+				tokensMap[label] = []int{}
 			}
 		}
-
-		numTokens = len(segments) + len(request.data.Tokens)
-
-		return tokensMap, numTokens
 	}
 
-	if engine.initOptions.Using == 1 && request.data.Content != "" {
-		// Content 分词, 当文档正文不为空时，优先从内容分词中得到关键词
-		segments := engine.segmenter.ModeSegment([]byte(request.data.Content),
-			engine.initOptions.GseMode)
-
-		for _, segment := range segments {
-			token := segment.Token().Text()
-			if !engine.stopTokens.IsStopToken(token) {
-				tokensMap[token] = append(tokensMap[token], segment.Start())
-			}
-		}
-		numTokens = len(segments)
-
-		return tokensMap, numTokens
+	doc := &types.DocIndex{
+		DocId:    request.docId,
+		TokenLen: float32(numTokens),
+		Keywords: make([]types.KeywordIndex, len(tokensMap)),
 	}
-
-	useOpts := engine.initOptions.Using == 1 || engine.initOptions.Using == 3
-	contentNil := request.data.Content == ""
-	opts := useOpts && contentNil
-
-	if engine.initOptions.Using == 2 || opts {
-		for _, t := range request.data.Tokens {
-			if !engine.stopTokens.IsStopToken(t.Text) {
-				tokensMap[t.Text] = t.Locations
-			}
-		}
-
-		numTokens = len(request.data.Tokens)
-
-		return tokensMap, numTokens
-	}
-
-	tokenMap, lenSplitData := engine.splitData(request)
-
-	return tokenMap, lenSplitData
-}
-
-func (engine *Engine) defaultTokens(content string) (tokensMap TMap, numTokens int) {
-	// use segmenter
-	tokensMap = make(map[string][]int)
-	strData := strings.Split(content, " ")
-	num := len(strData)
-
-	if num > 0 {
-		tokenMap, numToken := engine.ForSplitData(strData, num)
-		numTokens += numToken
-
-		for key, val := range tokenMap {
-			tokensMap[key] = val
-		}
+	iTokens := 0
+	for k, v := range tokensMap {
+		doc.Keywords[iTokens] = types.KeywordIndex{
+			Text: k,
+			// 非分词标注的词频设置为0，不参与tf-idf计算
+			Frequency: float32(len(v)),
+			Starts:    v}
+		iTokens++
 	}
 
-	return
+	return doc
 }
 
-func (engine *Engine) makeTokensMap(request segmenterReq) (map[string][]int, int) {
-	tokensMap := make(map[string][]int)
-	numTokens := 0
-
-	if !(engine.initOptions.NotUseGse && engine.initOptions.Using == 0) {
-		tokensMap, numTokens = engine.segmenterData(request)
-	} else {
-		if request.data.Content != "" {
-			content := strings.ToLower(request.data.Content)
-			tokensMap, numTokens = engine.defaultTokens(content)
-		}
-
-		for _, t := range request.data.Tokens {
-			if !engine.stopTokens.IsStopToken(t.Text) {
-				tokensMap[t.Text] = t.Locations
-			}
-		}
-
-		numTokens += len(request.data.Tokens)
-	}
-
-	if engine.initOptions.PinYin {
-		strArr := engine.PinYin(request.data.Content)
-		count := len(strArr)
+func (engine *Engine) segmenterWorker() {
+	defer engine.wg.Done()
 
-		for i := 0; i < count; i++ {
-			str := strArr[i]
-			if !engine.stopTokens.IsStopToken(str) {
-				tokensMap[str] = []int{i}
-			}
+	for {
+		var request segmenterReq
+		select {
+		case request = <-engine.segmenterChan:
+		case <-engine.closeChan:
+			return
 		}
 
-		numTokens += count
-	}
-
-	return tokensMap, numTokens
-}
-
-func (engine *Engine) segmenterWorker() {
-	for {
-		request := <-engine.segmenterChan
 		if request.docId == "0" {
 			if request.forceUpdate {
 				for i := 0; i < engine.initOptions.NumShards; i++ {
@@ -258,45 +148,27 @@ func (engine *Engine) segmenterWorker() {
 			continue
 		}
 
-		shard := engine.getShard(request.hash)
-		tokensMap, numTokens := engine.makeTokensMap(request)
-
-		// 加入非分词的文档标签
-		for _, label := range request.data.Labels {
-			if !engine.initOptions.NotUseGse {
-				if !engine.stopTokens.IsStopToken(label) {
-					// 当正文中已存在关键字时，若不判断，位置信息将会丢失
-					if _, ok := tokensMap[label]; !ok {
-						tokensMap[label] = []int{}
-					}
-				}
-			} else {
-				// 当正文中已存在关键字时，若不判断，位置信息将会丢失
-				if _, ok := tokensMap[label]; !ok {
-					tokensMap[label] = []int{}
-				}
-			}
-		}
-
+		shard := request.shard
 		indexerRequest := indexerAddDocReq{
-			doc: &types.DocIndex{
-				DocId:    request.docId,
-				TokenLen: float32(numTokens),
-				Keywords: make([]types.KeywordIndex, len(tokensMap)),
-			},
+			doc:         engine.makeDocIndex(request),
 			forceUpdate: request.forceUpdate,
 		}
-		iTokens := 0
-		for k, v := range tokensMap {
-			indexerRequest.doc.Keywords[iTokens] = types.KeywordIndex{
-				Text: k,
-				// 非分词标注的词频设置为0，不参与tf-idf计算
-				Frequency: float32(len(v)),
-				Starts:    v}
-			iTokens++
+		rankerRequest := rankerAddDocReq{
+			// docId: request.docId, fields: request.data.Fields}
+			docId: request.docId, fields: request.data.Fields,
+			content: request.data.Content, attri: request.data.Attri}
+
+		version := docVersion{version: request.data.Version}
+		if !engine.sendDoc(shard, request.docId, version,
+			indexerRequest, rankerRequest, !request.recovered) {
+			// 过期的版本或者已经改变路由的文档不加入索引
+			atomic.AddUint64(&engine.numDocsIndexed, 1)
+			if request.forceUpdate {
+				engine.indexerAddDocChans[shard] <- indexerAddDocReq{
+					forceUpdate: true}
+			}
 		}
 
-		engine.indexerAddDocChans[shard] <- indexerRequest
 		if request.forceUpdate {
 			for i := 0; i < engine.initOptions.NumShards; i++ {
 				if i == shard {
@@ -305,17 +177,15 @@ func (engine *Engine) segmenterWorker() {
 				engine.indexerAddDocChans[i] <- indexerAddDocReq{forceUpdate: true}
 			}
 		}
-		rankerRequest := rankerAddDocReq{
-			// docId: request.docId, fields: request.data.Fields}
-			docId: request.docId, fields: request.data.Fields,
-			content: request.data.Content, attri: request.data.Attri}
-		engine.rankerAddDocChans[shard] <- rankerRequest
 	}
 }
 
 // PinYin get the Chinese alphabet and abbreviation
 func (engine *Engine) PinYin(hans string) []string {
 	if engine.initOptions.UsePhrase {
+		engine.dict.lock.RLock()
+		defer engine.dict.lock.RUnlock()
+
 		if !engine.initOptions.NotUseGse {
 			phrase.WithGse(engine.segmenter)
 		}
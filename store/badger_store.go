@@ -63,6 +63,21 @@ func (s *Badger) Set(k, v []byte) error {
 	return err
 }
 
+// BatchSet sets the key-value pairs with a badger.WriteBatch,
+// the batch is split into several transactions when it is too big.
+func (s *Badger) BatchSet(keys, values [][]byte) error {
+	wb := s.db.NewWriteBatch()
+	defer wb.Cancel()
+
+	for i := range keys {
+		if err := wb.Set(keys[i], values[i]); err != nil {
+			return err
+		}
+	}
+
+	return wb.Flush()
+}
+
 // Get looks for key and returns a value.
 // If key is not found, value is nil.
 func (s *Badger) Get(k []byte) ([]byte, error) {
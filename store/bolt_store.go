@@ -61,6 +61,20 @@ func (s *Bolt) Set(k []byte, v []byte) error {
 	})
 }
 
+// BatchSet sets the key-value pairs in one read-write transaction.
+func (s *Bolt) BatchSet(keys, values [][]byte) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(gdocs)
+		for i := range keys {
+			if err := b.Put(keys[i], values[i]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
 // Get executes a function within the context of a managed read-only transaction.
 // Any error that is returned from the function is returned from the View() method.
 func (s *Bolt) Get(k []byte) (b []byte, err error) {
@@ -78,6 +78,15 @@ func DBTest(t *testing.T, db Store) {
 	tt.Expect(t, "<nil>", err)
 	tt.Expect(t, "value1", string(buf))
 
+	keys := [][]byte{[]byte("key2"), []byte("key3")}
+	values := [][]byte{[]byte("value2"), []byte("value3")}
+	err = BatchSet(db, keys, values)
+	tt.Expect(t, "<nil>", err)
+
+	buf, err = db.Get([]byte("key3"))
+	tt.Expect(t, "<nil>", err)
+	tt.Expect(t, "value3", string(buf))
+
 	walFile := db.WALName()
 	db.Close()
 	os.Remove(walFile)
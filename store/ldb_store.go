@@ -48,6 +48,17 @@ func (s *Leveldb) Set(k, v []byte) error {
 	return s.db.Put(k, v, nil)
 }
 
+// BatchSet sets the key-value pairs in one leveldb.Batch,
+// the batch is written atomically.
+func (s *Leveldb) BatchSet(keys, values [][]byte) error {
+	batch := new(leveldb.Batch)
+	for i := range keys {
+		batch.Put(keys[i], values[i])
+	}
+
+	return s.db.Write(batch, nil)
+}
+
 // Get gets the value for the given key. It returns
 // ErrNotFound if the DB does not contains the key.
 //
@@ -51,6 +51,28 @@ type Store interface {
 	WALName() string
 }
 
+// Batcher is implemented by the store engine
+// which can write several key-value pairs in one batch
+type Batcher interface {
+	BatchSet(keys, values [][]byte) error
+}
+
+// BatchSet sets the key-value pairs in one batch if the store
+// is a Batcher, otherwise sets them one by one
+func BatchSet(s Store, keys, values [][]byte) error {
+	if b, ok := s.(Batcher); ok {
+		return b.BatchSet(keys, values)
+	}
+
+	for i := range keys {
+		if err := s.Set(keys[i], values[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // OpenStore open store engine
 func OpenStore(path string, args ...string) (Store, error) {
 	storeName := DefaultStore
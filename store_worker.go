@@ -19,8 +19,11 @@ import (
 	"bytes"
 
 	"encoding/gob"
+	"strings"
 	"sync/atomic"
+	"time"
 
+	"github.com/go-ego/riot/store"
 	"github.com/go-ego/riot/types"
 )
 
@@ -28,19 +31,69 @@ type storeIndexDocReq struct {
 	docId string
 	data  types.DocData
 	// data        types.DocumentIndexData
+
+	// 批量写入的 key-value，写入结果通过 errChan 返回
+	keys, values [][]byte
+	versions     []uint64
+	errChan      chan error
+
+	// 部分更新已存储的文档，结果通过 errChan 返回
+	update func(data *types.DocData)
+}
+
+// encodeDoc gob encode the document data
+func encodeDoc(data types.DocData) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+	err := enc.Encode(data)
+	return buf.Bytes(), err
 }
 
 func (engine *Engine) storeIndexDoc(shard int) {
+	defer engine.wg.Done()
+
 	for {
-		request := <-engine.storeIndexDocChans[shard]
+		var request storeIndexDocReq
+		select {
+		case request = <-engine.storeIndexDocChans[shard]:
+		case <-engine.closeChan:
+			return
+		}
+
+		if request.update != nil {
+			request.errChan <- engine.storeUpdateDoc(shard, request)
+			continue
+		}
+
+		if request.errChan != nil && len(request.keys) == 0 {
+			// storeBarrier
+			request.errChan <- nil
+			continue
+		}
+
+		if request.errChan != nil {
+			// 批量写入数据库
+			start := time.Now()
+			err := engine.storeBatchSet(shard, request)
+			engine.metrics.storeWrite.observe(time.Since(start))
+			if engine.contents != nil {
+				docIds := make([]string, len(request.keys))
+				for i, key := range request.keys {
+					docIds[i] = string(key)
+				}
+				engine.contents.remove(docIds...)
+			}
+
+			request.errChan <- err
+			atomic.AddUint64(&engine.numDocsStored, uint64(len(request.keys)))
+			continue
+		}
 
 		// 得到 key
 		b := []byte(request.docId)
 
 		// 得到 value
-		var buf bytes.Buffer
-		enc := gob.NewEncoder(&buf)
-		err := enc.Encode(request.data)
+		buf, err := encodeDoc(request.data)
 		if err != nil {
 			atomic.AddUint64(&engine.numDocsStored, 1)
 			continue
@@ -55,18 +108,106 @@ func (engine *Engine) storeIndexDoc(shard int) {
 		// 	engine.dbs[shard].Delete(b[0:length])
 		// }
 
-		// 将 key-value 写入数据库
-		engine.dbs[shard].Set(b, buf.Bytes())
+		// 将 key-value 写入数据库，跳过过期的版本
+		version := docVersion{version: request.data.Version}
+		start := time.Now()
+		engine.ifCurrent(request.docId, version, func() {
+			engine.dbs[shard].Set(b, buf)
+		})
+		engine.metrics.storeWrite.observe(time.Since(start))
+		engine.contents.remove(request.docId)
 
 		atomic.AddUint64(&engine.numDocsStored, 1)
 	}
 }
 
-func (engine *Engine) storeRemoveDoc(docId string, shard uint32) {
-	// 得到 key
-	b := []byte(docId)
-	// 从数据库删除该key
-	engine.dbs[shard].Delete(b)
+// storeBarrier 等待之前发送到存储协程的写入全部完成
+func (engine *Engine) storeBarrier() error {
+	errChans := make([]chan error, len(engine.storeIndexDocChans))
+	for shard := range engine.storeIndexDocChans {
+		// 存储协程按顺序处理请求
+		errChans[shard] = make(chan error, 1)
+		engine.storeIndexDocChans[shard] <- storeIndexDocReq{
+			errChan: errChans[shard]}
+	}
+
+	for _, errChan := range errChans {
+		if err := <-errChan; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// storeBatchSet 批量写入数据库，跳过过期的版本
+func (engine *Engine) storeBatchSet(shard int, request storeIndexDocReq) error {
+	if !engine.initOptions.Versioning {
+		return store.BatchSet(engine.dbs[shard], request.keys, request.values)
+	}
+
+	engine.versions.RLock()
+	defer engine.versions.RUnlock()
+
+	keys := make([][]byte, 0, len(request.keys))
+	values := make([][]byte, 0, len(request.values))
+	for i, key := range request.keys {
+		version := docVersion{version: request.versions[i]}
+		if engine.isCurrent(string(key), version) {
+			keys = append(keys, key)
+			values = append(values, request.values[i])
+		}
+	}
+
+	return store.BatchSet(engine.dbs[shard], keys, values)
+}
+
+// storeUpdateDoc read, update and rewrite the stored document
+func (engine *Engine) storeUpdateDoc(shard int, request storeIndexDocReq) error {
+	b := []byte(request.docId)
+	value, err := engine.dbs[shard].Get(b)
+	if err != nil || len(value) == 0 {
+		// 文档没有被存储
+		return nil
+	}
+
+	var data types.DocData
+	err = gob.NewDecoder(bytes.NewReader(value)).Decode(&data)
+	if err != nil {
+		return err
+	}
+	request.update(&data)
+
+	buf, err := encodeDoc(data)
+	if err != nil {
+		return err
+	}
+
+	err = engine.dbs[shard].Set(b, buf)
+	engine.contents.remove(request.docId)
+	return err
+}
+
+func (engine *Engine) storeRemoveDoc(docId string, shard uint32,
+	version uint64) {
+	defer engine.wg.Done()
+
+	tomb := docVersion{version: version, deleted: true}
+	engine.ifCurrent(docId, tomb, func() {
+		// 得到 key
+		b := []byte(docId)
+		// 从数据库删除该key
+		engine.dbs[shard].Delete(b)
+
+		if engine.initOptions.Versioning {
+			// 写入墓碑
+			buf, err := encodeDoc(types.DocData{Version: version})
+			if err == nil {
+				engine.dbs[shard].Set(tombstoneKey(docId), buf)
+			}
+		}
+	})
+	engine.contents.remove(docId)
 }
 
 // storeInit persistent storage init worker
@@ -81,10 +222,28 @@ func (engine *Engine) storeInit(shard int) {
 		dec := gob.NewDecoder(buf)
 		var data types.DocData
 		err := dec.Decode(&data)
-		if err == nil {
-			// 添加索引
-			engine.internalIndexDoc(docId, data, false)
+		if err != nil {
+			return nil
+		}
+
+		if isTombstone(key) {
+			// 恢复墓碑，墓碑的 key 排在文档之前
+			if engine.initOptions.Versioning {
+				docId = strings.TrimPrefix(docId, tombstonePrefix)
+				engine.loadVersion(docId, docVersion{
+					version: data.Version, deleted: true})
+			}
+			return nil
 		}
+
+		if engine.initOptions.Versioning &&
+			!engine.loadVersion(docId, docVersion{version: data.Version}) {
+			return nil
+		}
+
+		// 添加索引
+		engine.internalIndexDoc(docId, data, false, true)
+		engine.recovered(docId)
 		return nil
 	})
 	engine.storeInitChan <- true
@@ -0,0 +1,94 @@
+// Copyright 2016 ego authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package riot
+
+import (
+	"log"
+
+	"github.com/go-ego/riot/types"
+)
+
+// subField 子字段的索引和查询分析器
+type subField struct {
+	analyzer       *Analyzer
+	searchAnalyzer *Analyzer
+}
+
+// SubFieldToken get the keyword of the token in the sub field,
+// it can be used in the Logic expressions and SearchReq.Tokens
+func SubFieldToken(field, token string) string {
+	return field + ":" + token
+}
+
+// initSubFields 查找子字段的分析器，子字段只使用 EngineOpts.CharFilters
+func (engine *Engine) initSubFields(options types.EngineOpts) {
+	engine.subFields = make(map[string]subField)
+
+	for _, field := range options.SubFields {
+		if field.Name == "" {
+			log.Fatal("The sub field name is empty")
+		}
+		if _, ok := engine.subFields[field.Name]; ok {
+			log.Fatalf("Duplicate sub field: %s", field.Name)
+		}
+
+		search := field.SearchAnalyzer
+		if search == "" {
+			search = field.Analyzer
+		}
+
+		index, ok := engine.Analyzer(field.Analyzer)
+		if !ok {
+			log.Fatalf("Unknown analyzer of the sub field %s: %s",
+				field.Name, field.Analyzer)
+		}
+		query, ok := engine.Analyzer(search)
+		if !ok {
+			log.Fatalf("Unknown search analyzer of the sub field %s: %s",
+				field.Name, search)
+		}
+
+		engine.subFields[field.Name] = subField{
+			analyzer:       withFilters(index, engine.charFilters, nil),
+			searchAnalyzer: withFilters(query, engine.charFilters, nil),
+		}
+	}
+}
+
+// addSubFields 把子字段的关键词加入文档的关键词
+func (engine *Engine) addSubFields(content string, tokensMap map[string][]int) {
+	for name, field := range engine.subFields {
+		tokens, _ := field.analyzer.Analyze(content)
+		for _, token := range tokens {
+			keyword := SubFieldToken(name, token.Text)
+			tokensMap[keyword] = append(tokensMap[keyword], token.Start)
+		}
+	}
+}
+
+// subFieldTokens 使用子字段的查询分析器分析查询
+func (engine *Engine) subFieldTokens(name, text string) (tokens []string) {
+	field, ok := engine.subFields[name]
+	if !ok {
+		// 未知的子字段不匹配任何文档
+		return []string{SubFieldToken(name, text)}
+	}
+
+	analyzed, _ := field.searchAnalyzer.Analyze(text)
+	for _, token := range analyzed {
+		tokens = append(tokens, SubFieldToken(name, token.Text))
+	}
+	return
+}
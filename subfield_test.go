@@ -0,0 +1,61 @@
+package riot
+
+import (
+	"testing"
+
+	"github.com/go-ego/riot/types"
+	"github.com/vcaesar/tt"
+)
+
+func TestNGramTokenizer(t *testing.T) {
+	tokens := NGramTokenizer{Min: 2, Max: 3}.Tokenize("ab-cd1 x")
+	tt.Equal(t, []string{"ab", "cd", "cd1", "d1", "x"}, tokenTexts(tokens))
+	tt.Equal(t, []int{1, 4, 4, 5, 8}, []int{tokens[0].Start, tokens[1].Start,
+		tokens[2].Start, tokens[3].Start, tokens[4].Start})
+
+	tokens = NGramTokenizer{Min: 1, Max: 2}.Tokenize("世界人")
+	tt.Equal(t, []string{"世", "世界", "界", "界人", "人"}, tokenTexts(tokens))
+
+	tokens = EdgeNGramTokenizer{Min: 2, Max: 4}.Tokenize("iPhone 12")
+	tt.Equal(t, []string{"iP", "iPh", "iPho", "12"}, tokenTexts(tokens))
+	tt.Equal(t, 8, tokens[3].Start)
+}
+
+func TestSubFields(t *testing.T) {
+	var engine Engine
+	engine.Init(types.EngineOpts{
+		GseDict:     "./testdata/test_dict.txt",
+		IndexerOpts: inxOpts,
+		CharFilters: []string{"width"},
+		SubFields: []types.SubField{
+			{Name: "code", Analyzer: "ngram"},
+			{Name: "prefix", Analyzer: "edge_ngram", SearchAnalyzer: "simple"},
+		},
+	})
+	defer engine.Close()
+
+	engine.Index("1", types.DocData{Content: "型号 XK-2077A 世界人口"})
+	engine.Index("2", types.DocData{Content: "型号 ＸＫ-1999 七十亿"})
+	engine.Flush()
+
+	search := func(field, text string) []string {
+		outputs := engine.Search(types.SearchReq{Text: text, SubField: field})
+		var ids []string
+		for _, doc := range outputs.Docs.(types.ScoredDocs) {
+			ids = append(ids, doc.DocId)
+		}
+		return ids
+	}
+
+	tt.Equal(t, []string{"1"}, search("code", "077"))
+	tt.Equal(t, []string{"1"}, search("code", "界人"))
+	tt.Equal(t, 2, len(search("prefix", "xk")))
+	tt.Equal(t, []string{"2"}, search("prefix", "1999"))
+	tt.Equal(t, 0, len(search("prefix", "999")))
+	tt.Equal(t, 0, len(search("other", "xk")))
+
+	tt.Equal(t, []string{"prefix:xk"},
+		engine.Tokens(types.SearchReq{Text: "XK", SubField: "prefix"}))
+	// 主字段不受影响
+	tt.Equal(t, 0, len(searchIds(&engine, "界人")))
+}
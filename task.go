@@ -0,0 +1,341 @@
+// Copyright 2016 ego authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package riot
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-ego/riot/types"
+)
+
+const (
+	// TaskDeleteByQuery the kind of the DeleteByQuery task
+	TaskDeleteByQuery = "delete_by_query"
+	// TaskUpdateByQuery the kind of the UpdateByQuery task
+	TaskUpdateByQuery = "update_by_query"
+	// TaskReanalyze the kind of the ReanalyzeDocs task
+	TaskReanalyze = "reanalyze"
+
+	// maxTaskFailures 任务最多记录的失败文档数
+	maxTaskFailures = 100
+)
+
+// TaskOpts the options of the background task
+type TaskOpts struct {
+	// 每秒最多处理的文档数，0 表示不限制
+	Rate int
+}
+
+// TaskFailure a document which the task failed to process
+type TaskFailure struct {
+	DocId string
+	Err   error
+}
+
+// TaskStatus the status of the background task
+type TaskStatus struct {
+	Id   string
+	Kind string
+
+	// 查询匹配的文档数，已经处理的文档数和其中失败的文档数
+	Total, Done, Failed int
+	// 最多记录 100 个失败的文档
+	Failures []TaskFailure
+
+	Canceled bool
+	Finished bool
+}
+
+// Task a background task of DeleteByQuery, UpdateByQuery or ReanalyzeDocs
+// 后台任务，可以查询进度和取消
+type Task struct {
+	lock   sync.RWMutex
+	status TaskStatus
+
+	once   sync.Once
+	cancel chan struct{}
+	done   chan struct{}
+}
+
+// Id return the id of the task
+func (task *Task) Id() string {
+	return task.status.Id
+}
+
+// Status return the current status of the task
+func (task *Task) Status() TaskStatus {
+	task.lock.RLock()
+	defer task.lock.RUnlock()
+
+	status := task.status
+	status.Failures = append([]TaskFailure(nil), task.status.Failures...)
+	return status
+}
+
+// Cancel cancel the task, the processed documents are not restored
+func (task *Task) Cancel() {
+	task.once.Do(func() {
+		close(task.cancel)
+	})
+}
+
+// Wait block wait until the task is finished and return the status
+func (task *Task) Wait() TaskStatus {
+	<-task.done
+	return task.Status()
+}
+
+func (task *Task) canceled() bool {
+	select {
+	case <-task.cancel:
+		return true
+	default:
+		return false
+	}
+}
+
+// report 记录一个文档的处理结果
+func (task *Task) report(docId string, err error) {
+	task.lock.Lock()
+	defer task.lock.Unlock()
+
+	task.status.Done++
+	if err == nil {
+		return
+	}
+
+	task.status.Failed++
+	if len(task.status.Failures) < maxTaskFailures {
+		task.status.Failures = append(task.status.Failures,
+			TaskFailure{DocId: docId, Err: err})
+	}
+}
+
+// tasks 引擎的后台任务
+type tasks struct {
+	sync.RWMutex
+	seq   int
+	tasks map[string]*Task
+}
+
+// Task get the task by id
+func (engine *Engine) Task(id string) (*Task, bool) {
+	engine.tasks.RLock()
+	defer engine.tasks.RUnlock()
+
+	task, ok := engine.tasks.tasks[id]
+	return task, ok
+}
+
+// Tasks get the status of all the tasks, sorted by id
+func (engine *Engine) Tasks() []TaskStatus {
+	engine.tasks.RLock()
+	status := make([]TaskStatus, 0, len(engine.tasks.tasks))
+	for _, task := range engine.tasks.tasks {
+		status = append(status, task.Status())
+	}
+	engine.tasks.RUnlock()
+
+	sort.Slice(status, func(i, j int) bool {
+		return len(status[i].Id) < len(status[j].Id) ||
+			len(status[i].Id) == len(status[j].Id) && status[i].Id < status[j].Id
+	})
+
+	return status
+}
+
+// RemoveTask remove the finished task, return false if
+// the task does not exist or is still running
+func (engine *Engine) RemoveTask(id string) bool {
+	engine.tasks.Lock()
+	defer engine.tasks.Unlock()
+
+	task, ok := engine.tasks.tasks[id]
+	if !ok || !task.Status().Finished {
+		return false
+	}
+
+	delete(engine.tasks.tasks, id)
+	return true
+}
+
+// newTask 创建任务，设置好 Total 之后才加入 engine.tasks
+func (engine *Engine) newTask(kind string, total int) *Task {
+	engine.tasks.Lock()
+	defer engine.tasks.Unlock()
+
+	if engine.tasks.tasks == nil {
+		engine.tasks.tasks = make(map[string]*Task)
+	}
+	engine.tasks.seq++
+
+	task := &Task{
+		status: TaskStatus{Id: strconv.Itoa(engine.tasks.seq), Kind: kind,
+			Total: total},
+		cancel: make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	engine.tasks.tasks[task.status.Id] = task
+
+	return task
+}
+
+// queryIds 从各个 shard 的索引器直接查找全部匹配的文档，
+// 不经过排序器，也不读取文档内容，按所在的 shard 分组排列。
+// 各个 shard 依次查找，期间写入仍在继续，结果不是一致的快照
+func (engine *Engine) queryIds(request types.SearchReq) ([]string, error) {
+	if err := engine.begin(); err != nil {
+		return nil, err
+	}
+	defer engine.end()
+
+	tokens := engine.Tokens(request)
+	expiry := engine.hasExpiry()
+
+	var ids []string
+	for shard := 0; shard < engine.initOptions.NumShards; shard++ {
+		docs, _ := engine.indexers[shard].FilterLookup(request.Filters,
+			tokens, request.Labels, request.DocIds, false, request.Logic)
+		if expiry {
+			docs = engine.unexpired(docs)
+		}
+
+		for _, doc := range docs {
+			ids = append(ids, doc.DocId)
+		}
+	}
+
+	return ids, nil
+}
+
+// runTask 在后台按 opts.Rate 对查询结果中的文档逐个执行 fn
+func (engine *Engine) runTask(kind string, request types.SearchReq,
+	fn func(docId string) error, opts ...TaskOpts) (*Task, error) {
+	// 只处理查询时找到的文档，查询期间和之后写入的文档
+	// 可能被处理也可能不被处理
+	ids, err := engine.queryIds(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return engine.startTask(kind, ids, fn, opts...), nil
+}
+
+// startTask 在后台按 opts.Rate 对文档逐个执行 fn
+func (engine *Engine) startTask(kind string, ids []string,
+	fn func(docId string) error, opts ...TaskOpts) *Task {
+	task := engine.newTask(kind, len(ids))
+
+	var rate int
+	if len(opts) > 0 {
+		rate = opts[0].Rate
+	}
+
+	go func() {
+		defer close(task.done)
+
+		var throttle <-chan time.Time
+		if rate > 0 {
+			ticker := time.NewTicker(time.Second / time.Duration(rate))
+			defer ticker.Stop()
+			throttle = ticker.C
+		}
+
+		canceled := false
+		for _, id := range ids {
+			if throttle != nil {
+				select {
+				case <-throttle:
+				case <-task.cancel:
+				}
+			}
+
+			if task.canceled() {
+				canceled = true
+				break
+			}
+
+			err := fn(id)
+			task.report(id, err)
+			if err == ErrEngineClosed {
+				canceled = true
+				break
+			}
+		}
+
+		// 保证处理过的文档立即生效
+		engine.Flush()
+
+		task.lock.Lock()
+		task.status.Canceled = canceled
+		task.status.Finished = true
+		task.lock.Unlock()
+	}()
+
+	return task
+}
+
+// DeleteByQuery remove all the documents matched by the request
+// in a background task
+// 删除查询匹配的全部文档
+//
+// 查询在调用时执行一次，找到的文档按所在的 shard 依次删除，
+// 查询期间写入的文档不一定包含在内（不是一致的快照），
+// 返回的 Task 可以查询进度、等待完成和取消。
+func (engine *Engine) DeleteByQuery(request types.SearchReq,
+	opts ...TaskOpts) (*Task, error) {
+	return engine.runTask(TaskDeleteByQuery, request, func(docId string) error {
+		return engine.RemoveDoc(docId)
+	}, opts...)
+}
+
+// UpdateByQuery update all the documents matched by the request
+// with fn in a background task, the engine must use the store
+// 用 fn 更新查询匹配的全部文档
+//
+// 文档从持久化存储读取，fn 返回的文档重新加入索引。
+// 启用 Versioning 时文档在读取之后被修改会失败，返回 ErrVersionConflict，
+// fn 返回的 Version 会被忽略。使用持久化存储，否则返回 ErrNoStore。
+func (engine *Engine) UpdateByQuery(request types.SearchReq,
+	fn func(types.DocData) types.DocData, opts ...TaskOpts) (*Task, error) {
+	if !engine.initOptions.UseStore {
+		return nil, ErrNoStore
+	}
+
+	return engine.runTask(TaskUpdateByQuery, request, func(docId string) error {
+		return engine.updateStored(docId, fn)
+	}, opts...)
+}
+
+// updateStored 从持久化存储读取文档，用 fn 更新后重新加入索引
+func (engine *Engine) updateStored(docId string,
+	fn func(types.DocData) types.DocData) error {
+	data, err := engine.GetDoc(docId)
+	if err != nil {
+		return err
+	}
+
+	version := data.Version
+	data = fn(data)
+	if engine.initOptions.Versioning {
+		// 要求版本仍然是读取时的版本
+		data.Version = version + 1
+	}
+
+	return engine.Index(docId, data)
+}
@@ -0,0 +1,98 @@
+package riot
+
+import (
+	"encoding/gob"
+	"os"
+	"testing"
+
+	"github.com/go-ego/riot/types"
+	"github.com/vcaesar/tt"
+)
+
+func TestDeleteByQuery(t *testing.T) {
+	var engine Engine
+	engine.Init(engOpts)
+	AddDocs(&engine)
+
+	task, err := engine.DeleteByQuery(types.SearchReq{Text: "七十亿"})
+	tt.Nil(t, err)
+
+	status := task.Wait()
+	tt.Expect(t, "3", status.Total)
+	tt.Expect(t, "3", status.Done)
+	tt.Expect(t, "0", status.Failed)
+	tt.True(t, status.Finished)
+	tt.False(t, status.Canceled)
+
+	_, errs := engine.MultiGet([]string{"1", "2", "4", "5", "6"})
+	tt.Equal(t, []error{ErrDocNotFound, nil, nil,
+		ErrDocNotFound, ErrDocNotFound}, errs)
+
+	got, ok := engine.Task(task.Id())
+	tt.True(t, ok)
+	tt.True(t, got == task)
+	tt.Expect(t, "1", len(engine.Tasks()))
+	tt.True(t, engine.RemoveTask(task.Id()))
+	tt.Expect(t, "0", len(engine.Tasks()))
+
+	engine.Close()
+}
+
+func TestUpdateByQuery(t *testing.T) {
+	gob.Register(ScoringFields{})
+
+	var engine Engine
+	engine.Init(engOpts)
+	_, err := engine.UpdateByQuery(types.SearchReq{Text: "world"},
+		func(data types.DocData) types.DocData { return data })
+	tt.Equal(t, ErrNoStore, err)
+	engine.Close()
+
+	opts := engOpts
+	opts.UseStore = true
+	opts.StoreFolder = "riot.task"
+	opts.StoreShards = 2
+	opts.Versioning = true
+	os.RemoveAll("riot.task")
+	defer os.RemoveAll("riot.task")
+
+	var engine1 Engine
+	engine1.Init(opts)
+	AddDocs(&engine1)
+
+	task, err := engine1.UpdateByQuery(types.SearchReq{Text: "world"},
+		func(data types.DocData) types.DocData {
+			data.Labels = append(data.Labels, "updated")
+			return data
+		}, TaskOpts{Rate: 1000})
+	tt.Nil(t, err)
+
+	status := task.Wait()
+	tt.Expect(t, "4", status.Total)
+	tt.Expect(t, "4", status.Done)
+	tt.Expect(t, "0", status.Failed)
+
+	// 评分规则只保留有评分字段的文档
+	outputs := engine1.Search(types.SearchReq{
+		Text: "world", Labels: []string{"updated"}})
+	tt.Expect(t, "2", outputs.NumDocs)
+
+	doc, err := engine1.GetDoc("3")
+	tt.Nil(t, err)
+	tt.Equal(t, []string{"updated"}, doc.Labels)
+	tt.Expect(t, "2", doc.Version)
+
+	// 取消任务
+	task, err = engine1.DeleteByQuery(types.SearchReq{Text: "world"},
+		TaskOpts{Rate: 1})
+	tt.Nil(t, err)
+	task.Cancel()
+
+	status = task.Wait()
+	tt.True(t, status.Canceled)
+	tt.Expect(t, "0", status.Done)
+	tt.Expect(t, "2", engine1.Search(types.SearchReq{Text: "world"}).NumDocs)
+	tt.True(t, engine1.HasDoc("3"))
+
+	engine1.Close()
+}
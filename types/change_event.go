@@ -0,0 +1,38 @@
+// Copyright 2016 ego authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package types
+
+// ChangeOp the operation of the change event
+type ChangeOp string
+
+const (
+	// ChangeAdd 加入新文档
+	ChangeAdd ChangeOp = "add"
+	// ChangeUpdate 重新索引已有的文档，或者更新评分字段和属性
+	ChangeUpdate ChangeOp = "update"
+	// ChangeRemove 删除文档
+	ChangeRemove ChangeOp = "remove"
+)
+
+// ChangeEvent the change event of a document
+type ChangeEvent struct {
+	// 从 1 开始递增的序号
+	Seq     uint64   `json:"seq"`
+	DocId   string   `json:"doc_id"`
+	Op      ChangeOp `json:"op"`
+	Version uint64   `json:"version"`
+	// Unix 时间，单位纳秒
+	Time int64 `json:"time"`
+}
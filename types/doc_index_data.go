@@ -39,6 +39,24 @@ type DocData struct {
 
 	// 文档的评分字段，可以接纳任何类型的结构体
 	Fields interface{}
+
+	// 文档版本，仅在 EngineOpts.Versioning 启用时生效
+	// 0 表示由引擎递增版本，大于 0 表示外部版本，必须大于当前版本
+	Version uint64
+
+	// 路由键，决定文档分配到的索引 shard，为空时使用 docId
+	// 相同路由键的文档分配到同一个 shard
+	Routing string
+
+	// 过期时间，Unix 纳秒时间戳，0 表示不过期，见 EngineOpts.TTL
+	// 过期的文档不再出现在搜索结果中，并由后台协程删除
+	ExpireAt int64
+}
+
+// BatchDoc 批量加入索引的一个文档
+type BatchDoc struct {
+	DocId string
+	Data  DocData
 }
 
 // TokenData 文档的一个关键词
@@ -56,6 +56,21 @@ type EngineOpts struct {
 	// new, 分词规则
 	Using int `toml:"using"`
 
+	// 索引和查询使用的分析器的名字，见 Engine.RegisterAnalyzer；
+	// Analyzer 为空时由 Using 和 NotUseGse 决定，查询使用相同的 gse 分词；
+	// SearchAnalyzer 为空时和 Analyzer 相同
+	Analyzer       string `toml:"analyzer"`
+	SearchAnalyzer string `toml:"search_analyzer"`
+	// 索引、查询和 Segment 在分词前都使用的字符过滤器，可选
+	// t2s（繁体转简体）、s2t、width（全角转半角）和 cn_punct（中文标点）
+	CharFilters []string `toml:"char_filters"`
+	// 索引、查询和 Segment 都使用的关键词过滤器，可选
+	// possessive、ascii_folding 和 porter_stem，按顺序执行
+	TokenFilters []string `toml:"token_filters"`
+
+	// 子字段，使用另外的分析器索引文档正文，见 SearchReq.SubField
+	SubFields []SubField `toml:"sub_fields"`
+
 	// 半角逗号 "," 分隔的字典文件，具体用法见
 	// gse.Segmenter.LoadDict 函数的注释
 	GseDict   string `toml:"gse_dict"`
@@ -65,9 +80,11 @@ type EngineOpts struct {
 	// 停用词文件
 	StopTokenFile string `toml:"stop_file"`
 	// Gse search mode
-	GseMode bool   `toml:"gse_mode"`
-	Hmm     bool   `toml:"hmm"`
-	Model   string `toml:"model"`
+	GseMode bool `toml:"gse_mode"`
+	// 使用 HMM 识别新词，只用于 Engine.Segment 和 gse_search 分析器；
+	// 默认的查询和索引使用相同的分析器，不使用 HMM
+	Hmm   bool   `toml:"hmm"`
+	Model string `toml:"model"`
 
 	// 分词器线程数
 	// NumSegmenterThreads int
@@ -104,6 +121,44 @@ type EngineOpts struct {
 	StoreEngine string `toml:"store_engine"`
 
 	IDOnly bool `toml:"id_only"`
+
+	// 是否启用文档版本，启用后拒绝过期的索引和删除请求，
+	// 删除的文档保留版本墓碑，见 DocData.Version
+	Versioning bool `toml:"versioning"`
+
+	// 排序器只保存评分字段，搜索结果中文档的内容和属性
+	// 从持久化存储读取，需要 UseStore；
+	// ContentCacheSize 为缓存的文档数，0 表示不缓存
+	LazyContent      bool `toml:"lazy_content"`
+	ContentCacheSize int  `toml:"content_cache_size"`
+
+	// 文档默认的存活时间（毫秒），用于没有设置 DocData.ExpireAt 的文档，
+	// 0 表示不过期；SweepInterval 为清理过期文档的间隔（毫秒），默认 1000
+	TTL           int `toml:"ttl"`
+	SweepInterval int `toml:"sweep_interval"`
+
+	// 慢查询的阈值（毫秒），耗时超过阈值的查询以 JSON 行写入
+	// SlowQueryFile，为空时写入标准日志的输出；0 表示不记录
+	SlowQuery     int    `toml:"slow_query"`
+	SlowQueryFile string `toml:"slow_query_file"`
+
+	// 每个 shard 缓存的查询结果数，0 表示不缓存；
+	// shard 的索引或排序器改变之后缓存的结果自动失效
+	QueryCacheSize int `toml:"query_cache_size"`
+
+	// 保留的最近的文档变更事件数，订阅者可以从其中的序号继续订阅；
+	// 文档发送到所在 shard 时产生事件，已经被更新的版本取代的写入不产生事件；
+	// 0 表示不记录变更事件
+	ChangeLogSize int `toml:"change_log_size"`
+}
+
+// SubField the sub field indexes the content with another analyzer,
+// its keywords are prefixed with "Name:"; SearchAnalyzer is the same
+// as Analyzer if it is empty
+type SubField struct {
+	Name           string `toml:"name"`
+	Analyzer       string `toml:"analyzer"`
+	SearchAnalyzer string `toml:"search_analyzer"`
 }
 
 // Init init engine options
@@ -29,6 +29,9 @@ const (
 
 	// 默认插入索引表文档 CACHE SIZE
 	defaultDocCacheSize = 300000
+
+	// 默认每个 shard 缓存的过滤条件数
+	defaultFilterCacheSize = 256
 )
 
 // IndexerOpts 初始化索引器选项
@@ -41,6 +44,15 @@ type IndexerOpts struct {
 
 	// BM25 参数
 	BM25Parameters *BM25Parameters
+
+	// 自动刷新间隔（毫秒），大于 0 时每个 shard 定时将 CACHE 中等待
+	// 加入和删除的文档合并到索引表，新文档最迟在这个间隔后可以被搜索到；
+	// 0 表示只在 CACHE 满或者强制刷新时合并
+	RefreshInterval int
+
+	// 每个 shard 缓存的过滤条件 bitmap 个数，为 0 时使用默认值，
+	// 小于 0 时不缓存
+	FilterCacheSize int
 }
 
 // BM25Parameters 见http://en.wikipedia.org/wiki/Okapi_BM25
@@ -55,4 +67,8 @@ func (options *IndexerOpts) Init() {
 	if options.DocCacheSize == 0 {
 		options.DocCacheSize = defaultDocCacheSize
 	}
+
+	if options.FilterCacheSize == 0 {
+		options.FilterCacheSize = defaultFilterCacheSize
+	}
 }
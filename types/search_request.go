@@ -38,6 +38,13 @@ type SearchReq struct {
 	// 当不为 nil 时，仅从这些 DocIds 包含的键中搜索（忽略值）
 	DocIds map[string]bool
 
+	// 过滤条件，全部满足的文档才会被返回，不参与评分；
+	// 只有过滤条件没有搜索键时返回满足条件的全部文档
+	Filters []Filter
+
+	// 不为空时使用子字段的查询分析器分析 Text，只搜索子字段
+	SubField string
+
 	// 排序选项
 	RankOpts *RankOpts
 
@@ -51,6 +58,22 @@ type SearchReq struct {
 	// 不排序，对于可在引擎外部（比如客户端）排序情况适用
 	// 对返回文档很多的情况打开此选项可以有效节省时间
 	Orderless bool
+
+	// 设为 true 时在 SearchResp.Profile 中返回各 shard 的耗时
+	Profile bool
+}
+
+// Filter filter clause of the search, the clause is compiled to
+// a bitmap of the shard and cached until the index changes
+type Filter struct {
+	// 文档必须包含全部标签
+	Labels []string
+
+	// 不为空时文档必须是其中之一
+	DocIds []string
+
+	// 设为 true 时排除满足条件的文档
+	Not bool
 }
 
 // RankOpts rank options
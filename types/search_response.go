@@ -16,6 +16,8 @@
 package types
 
 import (
+	"time"
+
 	"github.com/go-ego/riot/utils"
 )
 
@@ -36,6 +38,37 @@ type BaseResp struct {
 
 	// 搜索到的文档个数。注意这是全部文档中满足条件的个数，可能比返回的文档数要大
 	NumDocs int
+
+	// 搜索失败的原因，比如引擎已关闭
+	Err error
+
+	// 查询的耗时，仅在 SearchReq.Profile 为 true 时返回
+	Profile *SearchProfile
+}
+
+// ShardProfile the profile of the search on one shard
+type ShardProfile struct {
+	Shard int `json:"shard"`
+
+	// 索引器查找的耗时和匹配的文档数
+	Lookup  time.Duration `json:"lookup_ns"`
+	NumDocs int           `json:"num_docs"`
+
+	// 排序器评分和排序的耗时，CountDocsOnly 和 Orderless 时为 0
+	Rank time.Duration `json:"rank_ns"`
+
+	// 结果来自查询缓存，见 EngineOpts.QueryCacheSize
+	Cached bool `json:"cached,omitempty"`
+}
+
+// SearchProfile the profile of the search, see SearchReq.Profile
+type SearchProfile struct {
+	// 按 shard 排列，超时的 shard 不包含在内
+	Shards []ShardProfile `json:"shards"`
+
+	// 最后一个 shard 返回之后合并、排序和截取结果的耗时
+	Merge time.Duration `json:"merge_ns"`
+	Total time.Duration `json:"total_ns"`
 }
 
 // SearchResp search response options
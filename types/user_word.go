@@ -0,0 +1,24 @@
+// Copyright 2016 ego authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package types
+
+// UserWord the word added to the gse dictionary at runtime
+type UserWord struct {
+	Text string `json:"text"`
+	// 词频，越大越优先切分为一个词，为 0 时使用默认的词频
+	Freq int `json:"freq,omitempty"`
+	// 词性
+	Pos string `json:"pos,omitempty"`
+}
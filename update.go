@@ -0,0 +1,87 @@
+// Copyright 2016 ego authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package riot
+
+import (
+	"errors"
+	"log"
+
+	"github.com/go-ego/murmur"
+	"github.com/go-ego/riot/types"
+)
+
+// ErrDocNotFound is returned when updating a document
+// which has not been indexed
+var ErrDocNotFound = errors.New("document not found")
+
+// UpdateFields update the scoring fields of the document
+// 更新文档的评分字段
+//
+// 只更新排序器中的评分字段和持久化存储中的文档，不重新分词和索引，
+// 适合频繁变化的排序信号，比如价格和点赞数。
+// 文档需要已经加入索引，否则返回 ErrDocNotFound。
+func (engine *Engine) UpdateFields(docId string, fields interface{}) error {
+	return engine.update(docId, func(shard int) bool {
+		return engine.rankers[shard].UpdateFields(docId, fields)
+	}, func(data *types.DocData) {
+		data.Fields = fields
+	})
+}
+
+// UpdateAttri update the attribute of the document
+// 更新文档的属性
+//
+// 和 UpdateFields 一样只更新排序器和持久化存储。
+func (engine *Engine) UpdateAttri(docId string, attri interface{}) error {
+	return engine.update(docId, func(shard int) bool {
+		return engine.rankers[shard].UpdateAttri(docId, attri)
+	}, func(data *types.DocData) {
+		data.Attri = attri
+	})
+}
+
+func (engine *Engine) update(docId string, updateRanker func(shard int) bool,
+	updateData func(data *types.DocData)) error {
+	if !engine.initialized {
+		log.Fatal("The engine must be initialized first.")
+	}
+
+	if err := engine.begin(); err != nil {
+		return err
+	}
+	defer engine.end()
+
+	if !updateRanker(engine.DocShard(docId)) {
+		return ErrDocNotFound
+	}
+
+	if !engine.initOptions.UseStore {
+		engine.updated(docId)
+		return nil
+	}
+
+	// 经过存储协程更新，保证和之前的写入顺序一致
+	hash := murmur.Sum32(docId) % uint32(engine.initOptions.StoreShards)
+	errChan := make(chan error, 1)
+	engine.storeIndexDocChans[hash] <- storeIndexDocReq{
+		docId: docId, update: updateData, errChan: errChan}
+
+	err := <-errChan
+	if err == nil {
+		engine.updated(docId)
+	}
+
+	return err
+}
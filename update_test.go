@@ -0,0 +1,54 @@
+package riot
+
+import (
+	"encoding/gob"
+	"os"
+	"testing"
+
+	"github.com/go-ego/riot/types"
+	"github.com/vcaesar/tt"
+)
+
+func TestUpdateFields(t *testing.T) {
+	gob.Register(ScoringFields{})
+	gob.Register(types.Attri{})
+
+	opts := engOpts
+	opts.UseStore = true
+	opts.StoreFolder = "riot.update"
+	opts.StoreShards = 2
+
+	var engine Engine
+	engine.Init(opts)
+
+	AddDocs(&engine)
+
+	attri := types.Attri{Title: "title"}
+	tt.Nil(t, engine.UpdateFields("5", ScoringFields{0, 10, 3}))
+	tt.Nil(t, engine.UpdateAttri("5", attri))
+	tt.Equal(t, ErrDocNotFound, engine.UpdateFields("10", ScoringFields{}))
+
+	outputs := engine.Search(Req1)
+	outDocs := outputs.Docs.(types.ScoredDocs)
+	tt.Expect(t, "2", len(outDocs))
+
+	tt.Expect(t, "5", outDocs[0].DocId)
+	tt.Expect(t, "30000", int(outDocs[0].Scores[0]*1000))
+	tt.Equal(t, attri, outDocs[0].Attri)
+
+	tt.Expect(t, "1", outDocs[1].DocId)
+	tt.Expect(t, "20000", int(outDocs[1].Scores[0]*1000))
+
+	ids, docs := engine.GetDBAllDocs()
+	for i, id := range ids {
+		if id == "5" {
+			tt.Equal(t, ScoringFields{0, 10, 3}, docs[i].Fields)
+			tt.Equal(t, attri, docs[i].Attri)
+			tt.Expect(t, "The world, 七十亿人口", docs[i].Content)
+		}
+	}
+
+	engine.Close()
+	tt.Equal(t, ErrEngineClosed, engine.UpdateFields("5", ScoringFields{}))
+	os.RemoveAll("riot.update")
+}
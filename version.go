@@ -0,0 +1,134 @@
+// Copyright 2016 ego authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package riot
+
+import (
+	"errors"
+	"strings"
+	"sync"
+)
+
+// ErrVersionConflict is returned when indexing or removing a document
+// with a version which is not greater than the current version
+var ErrVersionConflict = errors.New("version conflict")
+
+// tombstonePrefix 墓碑在数据库中的 key 前缀
+const tombstonePrefix = "\x00tombstone/"
+
+type docVersion struct {
+	version uint64
+	deleted bool
+}
+
+// versions 记录每个文档的当前版本，删除的文档保留版本作为墓碑
+type versions struct {
+	sync.RWMutex
+	docs map[string]docVersion
+}
+
+func tombstoneKey(docId string) []byte {
+	return []byte(tombstonePrefix + docId)
+}
+
+// isTombstone 判断数据库中的 key 是否为墓碑
+func isTombstone(key []byte) bool {
+	return strings.HasPrefix(string(key), tombstonePrefix)
+}
+
+// DocVersion get the current version of the document,
+// deleted is true if the document has been removed
+// 返回文档的当前版本，未启用 Versioning 时总是返回 0
+func (engine *Engine) DocVersion(docId string) (version uint64, deleted bool) {
+	engine.versions.RLock()
+	v := engine.versions.docs[docId]
+	engine.versions.RUnlock()
+
+	return v.version, v.deleted
+}
+
+// acceptVersion 检查并记录文档的新版本
+//
+// version 为 0 时使用内部计数，即当前版本加一；
+// 否则为外部版本，必须大于当前版本（包括墓碑），否则返回 ErrVersionConflict。
+func (engine *Engine) acceptVersion(docId string, version uint64,
+	deleted bool) (uint64, error) {
+	engine.versions.Lock()
+	defer engine.versions.Unlock()
+
+	cur := engine.versions.docs[docId]
+	if version == 0 {
+		version = cur.version + 1
+	} else if version <= cur.version {
+		return cur.version, ErrVersionConflict
+	}
+
+	engine.versions.docs[docId] = docVersion{version: version, deleted: deleted}
+	return version, nil
+}
+
+// replaceVersion 记录文档的版本，不返回版本冲突，用于 Reindex 重放
+// 源索引中的写入和 ReanalyzeDocs；已经有更新的版本时不记录，
+// 这个版本的写入随后被跳过
+func (engine *Engine) replaceVersion(docId string, version uint64) uint64 {
+	engine.versions.Lock()
+	defer engine.versions.Unlock()
+
+	cur := engine.versions.docs[docId]
+	if version == 0 {
+		version = cur.version + 1
+	}
+
+	if version > cur.version || version == cur.version && !cur.deleted {
+		engine.versions.docs[docId] = docVersion{version: version}
+	}
+	return version
+}
+
+// loadVersion 从数据库恢复文档版本，保留较大的版本
+func (engine *Engine) loadVersion(docId string, v docVersion) bool {
+	engine.versions.Lock()
+	defer engine.versions.Unlock()
+
+	if cur, ok := engine.versions.docs[docId]; ok && cur.version > v.version {
+		return false
+	}
+
+	engine.versions.docs[docId] = v
+	return true
+}
+
+// isCurrent 调用者需持有 versions 的读锁
+func (engine *Engine) isCurrent(docId string, v docVersion) bool {
+	return engine.versions.docs[docId] == v
+}
+
+// ifCurrent 在持有读锁时执行 fn，前提是 v 仍然是文档的当前版本，
+// 保证过期的写入不会覆盖较新的版本；未启用 Versioning 时直接执行
+func (engine *Engine) ifCurrent(docId string, v docVersion, fn func()) bool {
+	if !engine.initOptions.Versioning || docId == "0" {
+		fn()
+		return true
+	}
+
+	engine.versions.RLock()
+	defer engine.versions.RUnlock()
+
+	if !engine.isCurrent(docId, v) {
+		return false
+	}
+
+	fn()
+	return true
+}
@@ -0,0 +1,108 @@
+package riot
+
+import (
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/go-ego/riot/types"
+	"github.com/vcaesar/tt"
+)
+
+func versionOpts() types.EngineOpts {
+	return types.EngineOpts{
+		Using:       1,
+		GseDict:     "./testdata/test_dict.txt",
+		IndexerOpts: inxOpts,
+		NumShards:   1,
+		UseStore:    true,
+		StoreFolder: "riot.version",
+		StoreShards: 2,
+		Versioning:  true,
+	}
+}
+
+func TestVersioning(t *testing.T) {
+	var engine Engine
+	engine.Init(versionOpts())
+
+	// 内部版本
+	tt.Nil(t, engine.Index("1", types.DocData{Content: "The world"}))
+	tt.Nil(t, engine.Index("1", types.DocData{Content: "The world, 人口"}))
+	version, deleted := engine.DocVersion("1")
+	tt.Expect(t, "2", version)
+	tt.False(t, deleted)
+
+	// 外部版本
+	tt.Nil(t, engine.Index("2", types.DocData{Content: "The world", Version: 5}))
+	tt.Equal(t, ErrVersionConflict,
+		engine.Index("2", types.DocData{Content: "人口", Version: 3}))
+	tt.Equal(t, ErrVersionConflict,
+		engine.Index("2", types.DocData{Content: "人口", Version: 5}))
+
+	// 删除留下墓碑
+	tt.Equal(t, ErrVersionConflict, engine.RemoveDocVersion("2", 4))
+	tt.Nil(t, engine.RemoveDocVersion("2", 6))
+	version, deleted = engine.DocVersion("2")
+	tt.Expect(t, "6", version)
+	tt.True(t, deleted)
+	tt.Equal(t, ErrVersionConflict,
+		engine.Index("2", types.DocData{Content: "The world", Version: 6}))
+
+	errs := engine.IndexBatch([]types.BatchDoc{
+		{DocId: "1", Data: types.DocData{Content: "人口", Version: 1}},
+		{DocId: "3", Data: types.DocData{Content: "The world", Version: 2}},
+	})
+	tt.Equal(t, ErrVersionConflict, errs[0])
+	tt.Nil(t, errs[1])
+
+	engine.Flush()
+
+	outputs := engine.Search(types.SearchReq{Text: "world"})
+	outDocs := outputs.Docs.(types.ScoredDocs)
+	tt.Expect(t, "2", len(outDocs))
+	tt.Expect(t, "3", outDocs[0].DocId)
+	tt.Expect(t, "1", outDocs[1].DocId)
+	tt.Expect(t, "The world, 人口", outDocs[1].Content)
+
+	engine.Close()
+
+	// 从数据库恢复版本和墓碑
+	var engine1 Engine
+	engine1.Init(versionOpts())
+	engine1.Flush()
+
+	version, deleted = engine1.DocVersion("2")
+	tt.Expect(t, "6", version)
+	tt.True(t, deleted)
+	version, _ = engine1.DocVersion("1")
+	tt.Expect(t, "2", version)
+
+	tt.Equal(t, ErrVersionConflict,
+		engine1.Index("2", types.DocData{Content: "The world", Version: 6}))
+	tt.Expect(t, "2", len(engine1.GetDBAllIds()))
+
+	engine1.Close()
+	os.RemoveAll("riot.version")
+}
+
+func TestVersionRemoveOrder(t *testing.T) {
+	opts := versionOpts()
+	opts.UseStore = false
+	opts.NumShards = 4
+
+	var engine Engine
+	engine.Init(opts)
+	defer engine.Close()
+
+	// 删除紧跟在加入之后，加入可能还在分词，删除不能先于加入执行
+	for i := 0; i < 200; i++ {
+		docId := strconv.Itoa(i + 1)
+		tt.Nil(t, engine.Index(docId, types.DocData{Content: "The world", Version: 5}))
+		tt.Nil(t, engine.RemoveDocVersion(docId, 6))
+	}
+	engine.Flush()
+
+	outputs := engine.Search(types.SearchReq{Text: "world", CountDocsOnly: true})
+	tt.Expect(t, "0", outputs.NumDocs)
+}